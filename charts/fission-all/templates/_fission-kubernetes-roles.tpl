@@ -183,14 +183,23 @@ rules:
   - services
   - replicationcontrollers
   - events
+  - endpoints
   verbs:
   - get
   - list
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
 - apiGroups:
   - batch
   resources:
   - jobs
+  - cronjobs
   verbs:
   - get
   - list
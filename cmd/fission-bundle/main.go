@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	docopt "github.com/docopt/docopt-go"
 	"go.uber.org/zap"
@@ -67,8 +68,8 @@ func runExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface, lo
 	return executor.StartExecutor(ctx, clientGen, logger, mgr, port)
 }
 
-func runKubeWatcher(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *zap.Logger, mgr manager.Interface, routerUrl string) error {
-	return kubewatcher.Start(ctx, clientGen, logger, mgr, routerUrl)
+func runKubeWatcher(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *zap.Logger, mgr manager.Interface, routerUrl string, opts kubewatcher.Options) error {
+	return kubewatcher.Start(ctx, clientGen, logger, mgr, routerUrl, opts)
 }
 
 func runTimer(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *zap.Logger, mgr manager.Interface, routerUrl string) error {
@@ -105,6 +106,42 @@ func getPort(logger *zap.Logger, portArg interface{}) int {
 	return port
 }
 
+func getDurationArgWithDefault(logger *zap.Logger, arg interface{}, defaultValue time.Duration) time.Duration {
+	if arg == nil {
+		return defaultValue
+	}
+	argStr := arg.(string)
+	d, err := time.ParseDuration(argStr)
+	if err != nil {
+		logger.Fatal("invalid duration", zap.Error(err), zap.String("duration", argStr))
+	}
+	return d
+}
+
+func getIntArgWithDefault(logger *zap.Logger, arg interface{}, defaultValue int) int {
+	if arg == nil {
+		return defaultValue
+	}
+	argStr := arg.(string)
+	i, err := strconv.Atoi(argStr)
+	if err != nil {
+		logger.Fatal("invalid number", zap.Error(err), zap.String("value", argStr))
+	}
+	return i
+}
+
+func getFloatArgWithDefault(logger *zap.Logger, arg interface{}, defaultValue float64) float64 {
+	if arg == nil {
+		return defaultValue
+	}
+	argStr := arg.(string)
+	f, err := strconv.ParseFloat(argStr, 64)
+	if err != nil {
+		logger.Fatal("invalid number", zap.Error(err), zap.String("value", argStr))
+	}
+	return f
+}
+
 func getStringArgWithDefault(arg interface{}, defaultValue string) string {
 	if arg != nil {
 		return arg.(string)
@@ -181,7 +218,7 @@ Usage:
   fission-bundle --canaryConfig
   fission-bundle --routerPort=<port> [--executorUrl=<url>]
   fission-bundle --executorPort=<port> [--namespace=<namespace>] [--fission-namespace=<namespace>]
-  fission-bundle --kubewatcher [--routerUrl=<url>]
+  fission-bundle --kubewatcher [--routerUrl=<url>] [--watchTimeout=<duration>] [--watchAttemptTimeout=<duration>] [--publishConcurrency=<count>] [--unhealthyWatchRatio=<ratio>] [--maxPublishBodySize=<bytes>] [--reportOversizedEvents] [--eventBufferSize=<count>] [--eventBufferPolicy=<policy>] [--watchdogInterval=<duration>]
   fission-bundle --storageServicePort=<port> --storageType=<storateType>
   fission-bundle --builderMgr [--storageSvcUrl=<url>] [--envbuilder-namespace=<namespace>]
   fission-bundle --timer [--routerUrl=<url>]
@@ -203,6 +240,15 @@ Options:
   --filePath=<filePath>           Directory to store functions in.
   --namespace=<namespace>         Kubernetes namespace in which to run function containers. Defaults to 'fission-function'.
   --kubewatcher                   Start Kubernetes events watcher.
+  --watchTimeout=<duration>       How long the Kubernetes events watcher retries (re)starting a watch, e.g. 30s, 5m.
+  --watchAttemptTimeout=<duration>  How long the Kubernetes events watcher waits on a single attempt to start a watch, e.g. 5s.
+  --publishConcurrency=<count>    Maximum in-flight events the Kubernetes events watcher publishes per namespace.
+  --unhealthyWatchRatio=<ratio>   Fraction of watches failing to restart at which the Kubernetes events watcher reports unhealthy, between 0 and 1.
+  --maxPublishBodySize=<bytes>    Largest event body in bytes the Kubernetes events watcher publishes, 0 means no limit.
+  --reportOversizedEvents         Send an error report to the function in place of an event body above --maxPublishBodySize.
+  --eventBufferSize=<count>       Events of a watch the Kubernetes events watcher buffers while they wait to be published.
+  --eventBufferPolicy=<policy>    What the Kubernetes events watcher does once the event buffer of a watch is full, block or drop-oldest.
+  --watchdogInterval=<duration>   How often the Kubernetes events watcher tries to recreate the watches that failed or gave up, e.g. 30s.
   --timer                         Start Timer.
   --mqt                           Start message queue trigger.
   --mqt_keda					  Start message queue trigger of kind KEDA
@@ -270,7 +316,17 @@ Options:
 	}
 
 	if arguments["--kubewatcher"] == true {
-		err = runKubeWatcher(ctx, clientGen, logger, mgr, routerUrl)
+		opts := kubewatcher.DefaultOptions()
+		opts.WatchTimeout = getDurationArgWithDefault(logger, arguments["--watchTimeout"], opts.WatchTimeout)
+		opts.WatchAttemptTimeout = getDurationArgWithDefault(logger, arguments["--watchAttemptTimeout"], opts.WatchAttemptTimeout)
+		opts.PublishConcurrency = getIntArgWithDefault(logger, arguments["--publishConcurrency"], opts.PublishConcurrency)
+		opts.UnhealthyWatchRatio = getFloatArgWithDefault(logger, arguments["--unhealthyWatchRatio"], opts.UnhealthyWatchRatio)
+		opts.MaxPublishBodySize = getIntArgWithDefault(logger, arguments["--maxPublishBodySize"], opts.MaxPublishBodySize)
+		opts.ReportOversizedEvents = arguments["--reportOversizedEvents"] == true
+		opts.EventBufferSize = getIntArgWithDefault(logger, arguments["--eventBufferSize"], opts.EventBufferSize)
+		opts.EventBufferPolicy = kubewatcher.EventBufferPolicy(getStringArgWithDefault(arguments["--eventBufferPolicy"], string(opts.EventBufferPolicy)))
+		opts.WatchdogInterval = getDurationArgWithDefault(logger, arguments["--watchdogInterval"], opts.WatchdogInterval)
+		err = runKubeWatcher(ctx, clientGen, logger, mgr, routerUrl, opts)
 		if err != nil {
 			logger.Error("kubewatcher exited", zap.Error(err))
 			return
@@ -18,9 +18,7 @@ package mqtrigger
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"path"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -54,7 +52,7 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 	var secrets map[string][]byte
 	if len(secretsPath) > 0 {
 		// For authentication with message queue
-		secrets, err = readSecrets(logger, secretsPath)
+		secrets, err = messageQueue.ReadSecrets(logger, secretsPath)
 		if err != nil {
 			return err
 		}
@@ -80,36 +78,3 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 	}
 	return nil
 }
-
-func readSecrets(logger *zap.Logger, secretsPath string) (map[string][]byte, error) {
-	// return if no secrets exist
-	if _, err := os.Stat(secretsPath); os.IsNotExist(err) {
-		return nil, err
-	}
-
-	secretFiles, err := os.ReadDir(secretsPath)
-	if err != nil {
-		return nil, err
-	}
-
-	secrets := make(map[string][]byte)
-	for _, secretFile := range secretFiles {
-
-		fileName := secretFile.Name()
-		// /etc/secrets contain some hidden directories (like .data)
-		// ignore them
-		if !secretFile.IsDir() && !strings.HasPrefix(fileName, ".") {
-			logger.Info(fmt.Sprintf("Reading secret from %s", fileName))
-
-			filePath := path.Join(secretsPath, fileName)
-			secret, fileReadErr := os.ReadFile(filePath)
-			if fileReadErr != nil {
-				return nil, fileReadErr
-			}
-
-			secrets[fileName] = secret
-		}
-	}
-
-	return secrets, nil
-}
@@ -81,7 +81,7 @@ func App(clientOptions cmd.ClientOptions) *cobra.Command {
 	})
 
 	wrapper.SetFlags(rootCmd, flag.FlagSet{
-		Global: []flag.Flag{flag.GlobalVerbosity, flag.KubeContext, flag.Namespace},
+		Global: []flag.Flag{flag.GlobalVerbosity, flag.KubeContext, flag.Namespace, flag.ShowNamespace},
 	})
 
 	groups := helptemplate.CommandGroups{}
@@ -95,7 +95,7 @@ func App(clientOptions cmd.ClientOptions) *cobra.Command {
 
 	flagExposer := helptemplate.ActsAsRootCommand(rootCmd, nil, groups...)
 	// show global options in usage
-	flagExposer.ExposeFlags(rootCmd, flagkey.Server, flagkey.Verbosity, flagkey.KubeContext, flagkey.Namespace)
+	flagExposer.ExposeFlags(rootCmd, flagkey.Server, flagkey.Verbosity, flagkey.KubeContext, flagkey.Namespace, flagkey.ShowNamespace)
 
 	return rootCmd
 }
@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1
 
+import "time"
+
 var (
 	MinimumKubernetesVersion = [3]int{1, 19, 0}
 )
@@ -25,6 +27,18 @@ const (
 	DEFAULT_FUNCTION_TIMEOUT  int    = 60
 )
 
+const (
+	// MaxRetryBackoff is the largest delay allowed between message redeliveries of a message queue trigger
+	MaxRetryBackoff = 5 * time.Minute
+
+	// DefaultGzipMinSize is the smallest event body in bytes a kubernetes watch trigger compresses
+	DefaultGzipMinSize = 1024
+
+	// DefaultCircuitBreakerCooldown is how long the circuit breaker of a kubernetes
+	// watch trigger stays open if its CircuitBreakerCooldown is not set
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
 const (
 	// ResourceVersionCount env variable is used for updating configmaps and secrets in pods
 	ResourceVersionCount string = "RESOURCE_VERSION_COUNT"
@@ -84,6 +98,50 @@ const (
 	MessageQueueTypeKafka = "kafka"
 )
 
+const (
+	// MessageQueueErrorFormatRaw sends the error only to the error topic.
+	MessageQueueErrorFormatRaw MessageQueueErrorFormat = "raw"
+
+	// MessageQueueErrorFormatEnvelope sends a JSON object with the original
+	// message and the details of the failure to the error topic.
+	MessageQueueErrorFormatEnvelope MessageQueueErrorFormat = "envelope"
+)
+
+const (
+	// KubernetesWatchSinkFunction delivers events to the function over HTTP.
+	KubernetesWatchSinkFunction KubernetesWatchSinkType = "function"
+
+	// KubernetesWatchSinkMQTopic publishes events to a message queue topic.
+	KubernetesWatchSinkMQTopic KubernetesWatchSinkType = "mqtopic"
+)
+
+const (
+	// KubernetesWatchConcurrencySerial publishes the events of a watch one at
+	// a time, in the order they are received: each publish completes, after
+	// any retries, before the next event is published.
+	KubernetesWatchConcurrencySerial KubernetesWatchConcurrencyPolicy = "Serial"
+
+	// KubernetesWatchConcurrencyParallel publishes the events of a watch
+	// concurrently, up to the publish concurrency the kubewatcher allows per
+	// namespace, so they may reach the function out of order.
+	KubernetesWatchConcurrencyParallel KubernetesWatchConcurrencyPolicy = "Parallel"
+
+	// DefaultKubernetesWatchConcurrencyPolicy is the concurrency policy of a
+	// watch trigger that doesn't set one, it keeps the events in order.
+	DefaultKubernetesWatchConcurrencyPolicy = KubernetesWatchConcurrencySerial
+)
+
+// KubernetesWatchAllNamespaces may be set as the namespace of a watch trigger with AllNamespaces.
+const KubernetesWatchAllNamespaces = "*"
+
+// KubernetesWatchResourceVersionNow may be set as the resource version of a
+// watch trigger to only watch the changes made from now on.
+const KubernetesWatchResourceVersionNow = "now"
+
+// KubernetesWatchReservedHeaderPrefix is the prefix of the headers the kubewatcher
+// sets on events, the Headers of a watch trigger can't use it.
+const KubernetesWatchReservedHeaderPrefix = "X-Kubernetes-"
+
 const (
 	// FunctionReferenceFunctionName means that the function
 	// reference is simply by function name.
@@ -110,6 +168,13 @@ const (
 
 	// set a max number for iterations to prevent infinite processing of canary config
 	MaxIterationsForCanaryConfig = 10
+
+	// FunctionHealthAnnotation set to FunctionHealthUnhealthy on a function,
+	// e.g. by a health check failing during a rollout, marks it unhealthy.
+	// A router with ROUTER_SKIP_UNHEALTHY_FUNCTIONS then sends it no traffic
+	// of function weights references until the annotation is removed.
+	FunctionHealthAnnotation = "fission.io/health"
+	FunctionHealthUnhealthy  = "unhealthy"
 )
 
 const (
@@ -506,6 +506,35 @@ type (
 		// +nullable
 		// +optional
 		FunctionWeights map[string]int `json:"functionweights"`
+
+		// HeaderOverrides route requests carrying a given header value to a
+		// function of FunctionWeights regardless of the weights, e.g. to keep
+		// testers on the new function during a canary rollout. The headers come
+		// from the client, any client can pick a function of FunctionWeights
+		// this way, so overrides must not guard a function from clients.
+		// +optional
+		HeaderOverrides []FunctionHeaderOverride `json:"headeroverrides,omitempty"`
+
+		// PackageVersion pins a reference by name to the function while its package
+		// reference has this resource version. The reference fails to resolve once
+		// the function points to another version of its package.
+		// +optional
+		PackageVersion string `json:"packageversion,omitempty"`
+	}
+
+	// FunctionHeaderOverride forces the selection of a function for requests
+	// carrying a header with the given value.
+	FunctionHeaderOverride struct {
+		// Header is the name of the request header, e.g. X-Canary.
+		Header string `json:"header"`
+
+		// Value the header must have. An empty value matches any value.
+		// +optional
+		Value string `json:"value,omitempty"`
+
+		// Function is the name of the function to route to, it must be one
+		// of FunctionWeights.
+		Function string `json:"function"`
 	}
 
 	//
@@ -689,6 +718,12 @@ type (
 		// FunctionReference is a reference to the target function.
 		FunctionReference FunctionReference `json:"functionref"`
 
+		// Timeout is how many seconds the router waits for the function to
+		// respond to a request of the trigger. The FunctionTimeout of the
+		// function is used if it is not set.
+		// +optional
+		Timeout int `json:"timeout,omitempty"`
+
 		// If CreateIngress is true, router will create an ingress definition.
 		// +optional
 		CreateIngress bool `json:"createingress"`
@@ -728,9 +763,21 @@ type (
 	KubernetesWatchTriggerSpec struct {
 		Namespace string `json:"namespace"`
 
-		// Type of resource to watch (Pod, Service, etc.)
+		// Type of resource to watch (Pod, Service, ReplicationController, Job, CronJob, Event, Endpoints)
 		Type string `json:"type"`
 
+		// Types are further resource types to watch besides Type. The events of
+		// all types are sent to the function, which tells them apart by the
+		// X-Kubernetes-Object-Type header.
+		// +optional
+		Types []string `json:"types,omitempty"`
+
+		// IncludeOwnedJobs makes a watch of type CronJob also deliver the
+		// events of the Jobs the CronJobs spawn, told apart by the
+		// X-Kubernetes-Owner-CronJob header naming the CronJob of the Job.
+		// +optional
+		IncludeOwnedJobs bool `json:"includeOwnedJobs,omitempty"`
+
 		// Resource labels
 		// +optional
 		LabelSelector map[string]string `json:"labelselector"`
@@ -738,11 +785,177 @@ type (
 		// The reference to a function for kubewatcher to invoke with
 		// when receiving events.
 		FunctionReference FunctionReference `json:"functionref"`
+
+		// Paused stops the watch from invoking the function while keeping the trigger
+		// +optional
+		Paused bool `json:"paused,omitempty"`
+
+		// Filter is a JSONPath template, e.g. {.status.phase}, evaluated against
+		// the event object. Events for which it yields an empty result are skipped.
+		// +optional
+		Filter string `json:"filter,omitempty"`
+
+		// FilterValue, if set, is the result the Filter must yield for the event
+		// to be published.
+		// +optional
+		FilterValue string `json:"filterValue,omitempty"`
+
+		// Gzip compresses event bodies of at least GzipMinSize bytes and
+		// sets Content-Encoding: gzip on the request to the function.
+		// +optional
+		Gzip bool `json:"gzip,omitempty"`
+
+		// GzipMinSize is the minimum body size in bytes to compress,
+		// DefaultGzipMinSize is used if it is not set.
+		// +optional
+		GzipMinSize int `json:"gzipMinSize,omitempty"`
+
+		// DryRun logs the events that would be published instead of invoking the function
+		// +optional
+		DryRun bool `json:"dryRun,omitempty"`
+
+		// IncludeNormalEvents makes a watch of type Event deliver Normal events
+		// too, by default only the other ones, e.g. Warning, are delivered.
+		// +optional
+		IncludeNormalEvents bool `json:"includeNormalEvents,omitempty"`
+
+		// EventInvolvedObjectKind makes a watch of type Event deliver only the
+		// events about objects of the kind, e.g. Pod. Like EventReason, it is
+		// a field selector, so the API server filters the events.
+		// +optional
+		EventInvolvedObjectKind string `json:"eventInvolvedObjectKind,omitempty"`
+
+		// EventReason makes a watch of type Event deliver only the events with
+		// the reason, e.g. FailedScheduling. Events of type Normal also need
+		// IncludeNormalEvents.
+		// +optional
+		EventReason string `json:"eventReason,omitempty"`
+
+		// IncludeOldObject sends the previous state of the object in the
+		// X-Kubernetes-Old-Object header of Modified events, if it was seen.
+		// +optional
+		IncludeOldObject bool `json:"includeOldObject,omitempty"`
+
+		// Sink is where events are delivered, either the function (default) or
+		// the Topic of the message queue the kubewatcher is configured with.
+		// +optional
+		Sink KubernetesWatchSinkType `json:"sink,omitempty"`
+
+		// Topic to publish events to if Sink is mqtopic.
+		// +optional
+		Topic string `json:"topic,omitempty"`
+
+		// AllNamespaces watches the resources of all namespaces instead of
+		// Namespace, which must then be empty or "*". The kubewatcher must be
+		// allowed to watch the resource type cluster-wide.
+		// +optional
+		AllNamespaces bool `json:"allNamespaces,omitempty"`
+
+		// FunctionPath is appended to the URL of the function, e.g. /events/pod,
+		// so one function can tell the watches it serves apart by path.
+		// +optional
+		FunctionPath string `json:"functionPath,omitempty"`
+
+		// PruneFields are dot separated paths of fields, e.g. metadata.managedFields
+		// or status, removed from the object before it is sent. The Filter still
+		// sees the whole object.
+		// +optional
+		PruneFields []string `json:"pruneFields,omitempty"`
+
+		// MaxEventAge skips Added events of objects created longer ago, e.g. the
+		// existing objects replayed when a watch restarts from scratch. Modified
+		// and Deleted events are always delivered. String representation of
+		// time.Duration, ex : 30s, 5m
+		// +optional
+		MaxEventAge string `json:"maxEventAge,omitempty"`
+
+		// PublishTimeout is how long the function may take to respond to an
+		// event, the kubewatcher default is used if it is not set. String
+		// representation of time.Duration, ex : 500ms, 10m
+		// +optional
+		PublishTimeout string `json:"publishTimeout,omitempty"`
+
+		// PublishMethod is the HTTP method of the requests sending events to
+		// the function, one of POST (default), PUT or PATCH.
+		// +optional
+		PublishMethod string `json:"publishMethod,omitempty"`
+
+		// Transform reshapes the object before it is sent. It maps the fields of
+		// the JSON body sent to the function to JSONPath templates, e.g.
+		// {.status.phase}, evaluated against the event object. The Filter still
+		// sees the whole object.
+		// +optional
+		Transform map[string]string `json:"transform,omitempty"`
+
+		// ResourceVersion is where a new watch starts. Unset, it starts from the
+		// current state, sending the existing objects as Added events. "now"
+		// only sends the changes made from now on, and a resource version
+		// replays the changes made since, e.g. after an incident.
+		// +optional
+		ResourceVersion string `json:"resourceVersion,omitempty"`
+
+		// Headers are added to the requests sending events to the function,
+		// e.g. a shared secret the function authenticates them with. The
+		// X-Kubernetes-* headers set by the kubewatcher can't be overridden.
+		// +optional
+		Headers map[string]string `json:"headers,omitempty"`
+
+		// HeadersFromSecrets are like Headers, but take their values from a key
+		// of a secret in the namespace of the trigger. The secrets are read
+		// when the watch starts.
+		// +optional
+		HeadersFromSecrets map[string]apiv1.SecretKeySelector `json:"headersFromSecrets,omitempty"`
+
+		// CircuitBreakerThreshold is the number of consecutive failed deliveries
+		// to the function after which the kubewatcher opens the circuit breaker of
+		// the trigger: events are dropped for CircuitBreakerCooldown, then a single
+		// one is sent to test whether the function recovered. 0 disables it.
+		// +optional
+		CircuitBreakerThreshold int `json:"circuitBreakerThreshold,omitempty"`
+
+		// CircuitBreakerCooldown is how long the circuit breaker stays open,
+		// DefaultCircuitBreakerCooldown is used if it is not set. String
+		// representation of time.Duration, ex : 30s, 5m
+		// +optional
+		CircuitBreakerCooldown string `json:"circuitBreakerCooldown,omitempty"`
+
+		// SkipReplayOnError keeps a watch from replaying the existing objects
+		// after an error. By default, a watch that can't find out where to
+		// resume after an error restarts from scratch, sending all existing
+		// objects again as Added events so that no change is missed. With
+		// SkipReplayOnError it retries until it can resume from the current
+		// state instead: the changes made meanwhile are lost, but large
+		// namespaces aren't replayed to the function.
+		// +optional
+		SkipReplayOnError bool `json:"skipReplayOnError,omitempty"`
+
+		// ConcurrencyPolicy is how the events of the watch are delivered: one
+		// at a time and in order (Serial), or concurrently (Parallel). Parallel
+		// gives up ordering: e.g. the Deleted event of an object may reach the
+		// function before its Modified event. Serial is used if it is not set.
+		// +optional
+		ConcurrencyPolicy KubernetesWatchConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+		// LogLevel is the level of the logs of the watch in the kubewatcher,
+		// one of debug, info, warn or error, e.g. debug to troubleshoot a
+		// single trigger. The level of the kubewatcher is used if it is not
+		// set.
+		// +optional
+		LogLevel string `json:"logLevel,omitempty"`
 	}
 
+	// KubernetesWatchSinkType refers to where a kubernetes watch trigger delivers events
+	KubernetesWatchSinkType string
+
+	// KubernetesWatchConcurrencyPolicy refers to how a kubernetes watch trigger delivers concurrent events
+	KubernetesWatchConcurrencyPolicy string
+
 	// MessageQueueType refers to Type of message queue
 	MessageQueueType string
 
+	// MessageQueueErrorFormat refers to the format of messages sent to the error topic
+	MessageQueueErrorFormat string
+
 	// MessageQueueTriggerSpec defines a binding from a topic in a
 	// message queue to a function.
 	MessageQueueTriggerSpec struct {
@@ -758,22 +971,55 @@ type (
 		// Subscribed topic
 		Topic string `json:"topic"`
 
+		// TopicPattern makes Topic a regular expression, the trigger subscribes
+		// to every topic matching it. Only Kafka supports it.
+		// +optional
+		TopicPattern bool `json:"topicPattern,omitempty"`
+
 		// Topic for message queue trigger to sent response from function.
 		// +optional
 		ResponseTopic string `json:"respTopic,omitempty"`
 
+		// Further topics the response from function is sent to, in addition to
+		// ResponseTopic.
+		// +optional
+		ResponseTopics []string `json:"respTopics,omitempty"`
+
 		// Topic to collect error response sent from function
 		// +optional
 		ErrorTopic string `json:"errorTopic"`
 
+		// Format of the messages sent to ErrorTopic, raw (default) sends the
+		// error only, envelope a JSON object with the original message, the
+		// function, the error, the retry count and the time.
+		// +optional
+		ErrorFormat MessageQueueErrorFormat `json:"errorFormat,omitempty"`
+
 		// Maximum times for message queue trigger to retry
 		// +optional
 		MaxRetries int `json:"maxRetries"`
 
+		// Delay before redelivering a message whose function invocation failed, doubled on
+		// every further retry. String representation of time.Duration, ex : 500ms, 2s, 1m
+		// +optional
+		RetryBackoff string `json:"retryBackoff,omitempty"`
+
+		// Maximum number of messages a consumer fetches from the broker ahead of
+		// processing them. Lower it for slow functions so prefetched messages
+		// don't time out and get redelivered. Uses the consumer default if unset.
+		// KEDA connectors get it as MAX_INFLIGHT, those that can't bound
+		// prefetching ignore it.
+		// +optional
+		MaxInflight int `json:"maxInflight,omitempty"`
+
 		// Content type of payload
 		// +optional
 		ContentType string `json:"contentType"`
 
+		// Content type of the function response published to ResponseTopic, defaults to ContentType
+		// +optional
+		ResponseContentType string `json:"respContentType,omitempty"`
+
 		// The period to check each trigger source on every ScaledObject, and scale the deployment up or down accordingly
 		// +optional
 		PollingInterval *int32 `json:"pollingInterval,omitempty"`
@@ -798,6 +1044,11 @@ type (
 		// +optional
 		Secret string `json:"secret,omitempty"`
 
+		// AuthenticationRef is the name of an existing KEDA TriggerAuthentication
+		// in the trigger namespace, used instead of one generated from Secret.
+		// +optional
+		AuthenticationRef string `json:"authenticationRef,omitempty"`
+
 		// Kind of Message Queue Trigger to be created, by default its fission
 		// +optional
 		MqtKind string `json:"mqtkind,omitempty"`
@@ -836,6 +1087,12 @@ type (
 		// Old stable version of the function
 		OldFunction string `json:"oldfunction"`
 
+		// Other stable versions of the function sharing traffic with the old one.
+		// Their weights are decreased in proportion along with the old function's
+		// as the weight of the new function is incremented.
+		// +optional
+		OtherFunctions []string `json:"otherfunctions,omitempty"`
+
 		// Weight increment step for function
 		// +optional
 		WeightIncrement int `json:"weightincrement"`
@@ -891,3 +1148,18 @@ func (fn Function) GetRequestPerPod() int {
 	}
 	return fn.Spec.RequestsPerPod
 }
+
+// GetResponseTopics returns every topic the response from function is sent
+// to, ResponseTopic first.
+func (spec MessageQueueTriggerSpec) GetResponseTopics() []string {
+	if len(spec.ResponseTopic) == 0 {
+		return spec.ResponseTopics
+	}
+	return append([]string{spec.ResponseTopic}, spec.ResponseTopics...)
+}
+
+// GetStableFunctions returns the functions the new function takes traffic
+// from, OldFunction first.
+func (spec CanaryConfigSpec) GetStableFunctions() []string {
+	return append([]string{spec.OldFunction}, spec.OtherFunctions...)
+}
@@ -22,12 +22,17 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/robfig/cron/v3"
+	"go.uber.org/zap/zapcore"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/jsonpath"
 
 	"github.com/fission/fission/pkg/mqtrigger/validator"
 )
@@ -359,6 +364,19 @@ func (ref FunctionReference) Validate() error {
 		result = multierror.Append(result, ValidateKubeName("FunctionReference.Name", ref.Name))
 	}
 
+	if len(ref.PackageVersion) > 0 && ref.Type != FunctionReferenceTypeFunctionName {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionReference.PackageVersion", ref.PackageVersion, "only supported for function references by name"))
+	}
+
+	for _, o := range ref.HeaderOverrides {
+		if len(o.Header) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionReference.HeaderOverrides.Header", o.Header, "header must not be empty"))
+		}
+		if _, ok := ref.FunctionWeights[o.Function]; !ok {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionReference.HeaderOverrides.Function", o.Function, "not a function of FunctionWeights"))
+		}
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -436,6 +454,10 @@ func (spec HTTPTriggerSpec) Validate() error {
 
 	result = multierror.Append(result, spec.FunctionReference.Validate())
 
+	if spec.Timeout < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Timeout", spec.Timeout, "must be greater than or equal to 0"))
+	}
+
 	if len(spec.Host) > 0 {
 		e := validation.IsDNS1123Subdomain(spec.Host)
 		if len(e) > 0 {
@@ -502,19 +524,177 @@ func (spec KubernetesWatchTriggerSpec) Validate() error {
 	result := &multierror.Error{}
 
 	switch strings.ToUpper(spec.Type) {
-	case "POD", "SERVICE", "REPLICATIONCONTROLLER", "JOB":
+	case "POD", "SERVICE", "REPLICATIONCONTROLLER", "JOB", "CRONJOB", "EVENT", "ENDPOINTS":
 	default:
 		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "KubernetesWatchTriggerSpec.Type", spec.Type, "not a valid supported type"))
 	}
+	for _, t := range spec.Types {
+		switch strings.ToUpper(t) {
+		case "POD", "SERVICE", "REPLICATIONCONTROLLER", "JOB", "CRONJOB", "EVENT", "ENDPOINTS":
+		default:
+			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "KubernetesWatchTriggerSpec.Types", t, "not a valid supported type"))
+		}
+	}
+
+	if spec.IncludeOwnedJobs {
+		watchesCronJobs := strings.EqualFold(spec.Type, "CRONJOB")
+		for _, t := range spec.Types {
+			watchesCronJobs = watchesCronJobs || strings.EqualFold(t, "CRONJOB")
+		}
+		if !watchesCronJobs {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.IncludeOwnedJobs", spec.IncludeOwnedJobs, "only applies to watches of type CronJob"))
+		}
+	}
+
+	if len(spec.EventInvolvedObjectKind) > 0 || len(spec.EventReason) > 0 {
+		watchesEvents := strings.EqualFold(spec.Type, "EVENT")
+		for _, t := range spec.Types {
+			watchesEvents = watchesEvents || strings.EqualFold(t, "EVENT")
+		}
+		if !watchesEvents && len(spec.EventInvolvedObjectKind) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.EventInvolvedObjectKind", spec.EventInvolvedObjectKind, "only applies to watches of type Event"))
+		}
+		if !watchesEvents && len(spec.EventReason) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.EventReason", spec.EventReason, "only applies to watches of type Event"))
+		}
+	}
+
+	if spec.AllNamespaces {
+		if spec.Namespace != "" && spec.Namespace != KubernetesWatchAllNamespaces {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.Namespace", spec.Namespace, "must be empty or * with allNamespaces"))
+		}
+	} else if spec.Namespace == "" || spec.Namespace == KubernetesWatchAllNamespaces {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.Namespace", spec.Namespace, "set allNamespaces to watch all namespaces"))
+	} else {
+		result = multierror.Append(result, ValidateKubeName("KubernetesWatchTriggerSpec.Namespace", spec.Namespace))
+	}
 
 	result = multierror.Append(result,
-		ValidateKubeName("KubernetesWatchTriggerSpec.Namespace", spec.Namespace),
 		ValidateKubeLabel("KubernetesWatchTriggerSpec.LabelSelector", spec.LabelSelector),
 		spec.FunctionReference.Validate())
 
+	if len(spec.Filter) > 0 {
+		if err := jsonpath.New("filter").Parse(spec.Filter); err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.Filter", spec.Filter, err.Error()))
+		}
+	} else if len(spec.FilterValue) > 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.FilterValue", spec.FilterValue, "filter value is set without a filter"))
+	}
+
+	if spec.GzipMinSize < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.GzipMinSize", spec.GzipMinSize, "must be greater than or equal to 0"))
+	}
+
+	switch spec.ConcurrencyPolicy {
+	case "", KubernetesWatchConcurrencySerial, KubernetesWatchConcurrencyParallel:
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "KubernetesWatchTriggerSpec.ConcurrencyPolicy", spec.ConcurrencyPolicy, "not a valid concurrency policy, expected Serial or Parallel"))
+	}
+
+	if len(spec.LogLevel) > 0 {
+		if _, err := zapcore.ParseLevel(spec.LogLevel); err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.LogLevel", spec.LogLevel, "not a valid log level, expected debug, info, warn or error"))
+		}
+	}
+
+	switch spec.Sink {
+	case "", KubernetesWatchSinkFunction:
+	case KubernetesWatchSinkMQTopic:
+		if len(spec.Topic) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.Topic", spec.Topic, "topic is required for sink mqtopic"))
+		}
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "KubernetesWatchTriggerSpec.Sink", spec.Sink, "not a valid sink, expected function or mqtopic"))
+	}
+
+	if len(spec.FunctionPath) > 0 {
+		if !strings.HasPrefix(spec.FunctionPath, "/") {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.FunctionPath", spec.FunctionPath, "must begin with /"))
+		}
+		if strings.ContainsAny(spec.FunctionPath, "?#") {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.FunctionPath", spec.FunctionPath, "must not contain a query string or fragment"))
+		}
+	}
+
+	if len(spec.MaxEventAge) > 0 {
+		age, err := time.ParseDuration(spec.MaxEventAge)
+		if err != nil || age < 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.MaxEventAge", spec.MaxEventAge, "not a valid positive duration"))
+		}
+	}
+
+	if spec.CircuitBreakerThreshold < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.CircuitBreakerThreshold", spec.CircuitBreakerThreshold, "must be greater than or equal to 0"))
+	}
+	if len(spec.CircuitBreakerCooldown) > 0 {
+		cooldown, err := time.ParseDuration(spec.CircuitBreakerCooldown)
+		if err != nil || cooldown <= 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.CircuitBreakerCooldown", spec.CircuitBreakerCooldown, "not a valid positive duration"))
+		}
+	}
+
+	if len(spec.PublishTimeout) > 0 {
+		timeout, err := time.ParseDuration(spec.PublishTimeout)
+		if err != nil || timeout <= 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.PublishTimeout", spec.PublishTimeout, "not a valid positive duration"))
+		}
+	}
+
+	switch spec.PublishMethod {
+	case "", http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "KubernetesWatchTriggerSpec.PublishMethod", spec.PublishMethod, "not a supported method, must be one of POST, PUT or PATCH"))
+	}
+
+	if len(spec.ResourceVersion) > 0 && spec.ResourceVersion != KubernetesWatchResourceVersionNow {
+		if _, err := strconv.ParseUint(spec.ResourceVersion, 10, 64); err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.ResourceVersion", spec.ResourceVersion, "must be a numeric resource version or now"))
+		}
+	}
+
+	for field, expr := range spec.Transform {
+		if len(field) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.Transform", field, "field name must not be empty"))
+		}
+		if err := jsonpath.New(field).Parse(expr); err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.Transform", expr, err.Error()))
+		}
+	}
+
+	for _, field := range spec.PruneFields {
+		if slices.Contains(strings.Split(field, "."), "") {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.PruneFields", field, "must be a dot separated path of fields, e.g. metadata.managedFields"))
+		}
+	}
+
+	for name := range spec.Headers {
+		result = multierror.Append(result, validateWatchHeaderName("KubernetesWatchTriggerSpec.Headers", name))
+	}
+	for name, ref := range spec.HeadersFromSecrets {
+		result = multierror.Append(result, validateWatchHeaderName("KubernetesWatchTriggerSpec.HeadersFromSecrets", name))
+		if _, ok := spec.Headers[name]; ok {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.HeadersFromSecrets", name, "header is also set in headers"))
+		}
+		if len(ref.Name) == 0 || len(ref.Key) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KubernetesWatchTriggerSpec.HeadersFromSecrets", name, "secret name and key are required"))
+		}
+	}
+
 	return result.ErrorOrNil()
 }
 
+// validateWatchHeaderName checks that name is a valid HTTP header name the
+// kubewatcher doesn't set itself.
+func validateWatchHeaderName(field string, name string) error {
+	if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 {
+		return MakeValidationErr(ErrorInvalidValue, field, name, errs...)
+	}
+	if strings.HasPrefix(http.CanonicalHeaderKey(name), KubernetesWatchReservedHeaderPrefix) {
+		return MakeValidationErr(ErrorInvalidValue, field, name, "headers prefixed with "+KubernetesWatchReservedHeaderPrefix+" are reserved")
+	}
+	return nil
+}
+
 func (spec MessageQueueTriggerSpec) Validate() error {
 	result := &multierror.Error{}
 
@@ -523,13 +703,64 @@ func (spec MessageQueueTriggerSpec) Validate() error {
 	if !validator.IsValidMessageQueue((string)(spec.MessageQueueType), spec.MqtKind) {
 		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "MessageQueueTriggerSpec.MessageQueueType", spec.MessageQueueType, "not a supported message queue type"))
 	} else {
-		if !validator.IsValidTopic((string)(spec.MessageQueueType), spec.Topic, spec.MqtKind) {
+		if spec.TopicPattern {
+			if !validator.IsTopicPatternSupported((string)(spec.MessageQueueType)) {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.TopicPattern", spec.TopicPattern, fmt.Sprintf("not supported by message queue type %s", spec.MessageQueueType)))
+			}
+			if _, err := regexp.Compile(spec.Topic); err != nil {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.Topic", spec.Topic, "not a valid regular expression: "+err.Error()))
+			}
+		} else if !validator.IsValidTopic((string)(spec.MessageQueueType), spec.Topic, spec.MqtKind) {
 			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.Topic", spec.Topic, "not a valid topic"))
 		}
 
 		if len(spec.ResponseTopic) > 0 && !validator.IsValidTopic((string)(spec.MessageQueueType), spec.ResponseTopic, spec.MqtKind) {
 			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.ResponseTopic", spec.ResponseTopic, "not a valid topic"))
 		}
+		for _, t := range spec.ResponseTopics {
+			if !validator.IsValidTopic((string)(spec.MessageQueueType), t, spec.MqtKind) {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.ResponseTopics", t, "not a valid topic"))
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range spec.GetResponseTopics() {
+		switch {
+		case seen[t]:
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.ResponseTopics", t, "duplicate response topic"))
+		case !spec.TopicPattern && t == spec.Topic:
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.ResponseTopics", t, "cannot equal the listen topic"))
+		case t == spec.ErrorTopic:
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.ResponseTopics", t, "cannot equal the error topic"))
+		}
+		seen[t] = true
+	}
+
+	switch spec.ErrorFormat {
+	case "", MessageQueueErrorFormatRaw, MessageQueueErrorFormatEnvelope:
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "MessageQueueTriggerSpec.ErrorFormat", spec.ErrorFormat, "not a valid error format, expected raw or envelope"))
+	}
+
+	if spec.MaxInflight < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.MaxInflight", spec.MaxInflight, "must be greater than or equal to 0"))
+	}
+
+	if len(spec.RetryBackoff) > 0 {
+		backoff, err := time.ParseDuration(spec.RetryBackoff)
+		if err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.RetryBackoff", spec.RetryBackoff, "not a valid duration"))
+		} else if backoff < 0 || backoff > MaxRetryBackoff {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.RetryBackoff", spec.RetryBackoff, fmt.Sprintf("must be between 0 and %v", MaxRetryBackoff)))
+		}
+	}
+
+	if len(spec.AuthenticationRef) > 0 {
+		if len(spec.Secret) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "MessageQueueTriggerSpec.AuthenticationRef", spec.AuthenticationRef, "cannot be set together with Secret"))
+		}
+		result = multierror.Append(result, ValidateKubeName("MessageQueueTriggerSpec.AuthenticationRef", spec.AuthenticationRef))
 	}
 
 	return result.ErrorOrNil()
@@ -149,6 +149,11 @@ func (in *CanaryConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CanaryConfigSpec) DeepCopyInto(out *CanaryConfigSpec) {
 	*out = *in
+	if in.OtherFunctions != nil {
+		in, out := &in.OtherFunctions, &out.OtherFunctions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryConfigSpec.
@@ -382,6 +387,21 @@ func (in *FunctionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionHeaderOverride) DeepCopyInto(out *FunctionHeaderOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionHeaderOverride.
+func (in *FunctionHeaderOverride) DeepCopy() *FunctionHeaderOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionHeaderOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionPackageRef) DeepCopyInto(out *FunctionPackageRef) {
 	*out = *in
@@ -408,6 +428,11 @@ func (in *FunctionReference) DeepCopyInto(out *FunctionReference) {
 			(*out)[key] = val
 		}
 	}
+	if in.HeaderOverrides != nil {
+		in, out := &in.HeaderOverrides, &out.HeaderOverrides
+		*out = make([]FunctionHeaderOverride, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionReference.
@@ -643,6 +668,11 @@ func (in *KubernetesWatchTriggerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesWatchTriggerSpec) DeepCopyInto(out *KubernetesWatchTriggerSpec) {
 	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LabelSelector != nil {
 		in, out := &in.LabelSelector, &out.LabelSelector
 		*out = make(map[string]string, len(*in))
@@ -651,6 +681,32 @@ func (in *KubernetesWatchTriggerSpec) DeepCopyInto(out *KubernetesWatchTriggerSp
 		}
 	}
 	in.FunctionReference.DeepCopyInto(&out.FunctionReference)
+	if in.PruneFields != nil {
+		in, out := &in.PruneFields, &out.PruneFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HeadersFromSecrets != nil {
+		in, out := &in.HeadersFromSecrets, &out.HeadersFromSecrets
+		*out = make(map[string]corev1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesWatchTriggerSpec.
@@ -725,6 +781,11 @@ func (in *MessageQueueTriggerList) DeepCopyObject() runtime.Object {
 func (in *MessageQueueTriggerSpec) DeepCopyInto(out *MessageQueueTriggerSpec) {
 	*out = *in
 	in.FunctionReference.DeepCopyInto(&out.FunctionReference)
+	if in.ResponseTopics != nil {
+		in, out := &in.ResponseTopics, &out.ResponseTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PollingInterval != nil {
 		in, out := &in.PollingInterval, &out.PollingInterval
 		*out = new(int32)
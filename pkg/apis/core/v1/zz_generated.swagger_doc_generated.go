@@ -63,6 +63,7 @@ var map_CanaryConfigSpec = map[string]string{
 	"trigger":          "HTTP trigger that this config references",
 	"newfunction":      "New version of the function",
 	"oldfunction":      "Old stable version of the function",
+	"otherfunctions":   "Other stable versions of the function sharing traffic with the old one. Their weights are decreased in proportion along with the old function's as the weight of the new function is incremented.",
 	"weightincrement":  "Weight increment step for function",
 	"duration":         "Weight increment interval, string representation of time.Duration, ex : 1m, 2h, 2d (default: \"2m\")",
 	"failurethreshold": "Threshold in percentage beyond which the new version of the function is considered unstable",
@@ -179,11 +180,24 @@ func (FunctionPackageRef) SwaggerDoc() map[string]string {
 	return map_FunctionPackageRef
 }
 
+var map_FunctionHeaderOverride = map[string]string{
+	"":         "FunctionHeaderOverride forces the selection of a function for requests carrying a header with the given value.",
+	"header":   "Header is the name of the request header, e.g. X-Canary.",
+	"value":    "Value the header must have. An empty value matches any value.",
+	"function": "Function is the name of the function to route to, it must be one of FunctionWeights.",
+}
+
+func (FunctionHeaderOverride) SwaggerDoc() map[string]string {
+	return map_FunctionHeaderOverride
+}
+
 var map_FunctionReference = map[string]string{
 	"":                "FunctionReference refers to a function",
 	"type":            "Type indicates whether this function reference is by name or selector. For now, the only supported reference type is by \"name\".  Future reference types:\n  * Function by label or annotation\n  * Branch or tag of a versioned function\n  * A \"rolling upgrade\" from one version of a function to another\nAvailable value: - name - function-weights",
 	"name":            "Name of the function.",
 	"functionweights": "Function Reference by weight. this map contains function name as key and its weight as the value. This is for canary upgrade purpose.",
+	"headeroverrides": "HeaderOverrides route requests carrying a given header value to a function of FunctionWeights regardless of the weights, e.g. to keep testers on the new function during a canary rollout. The headers come from the client, any client can pick a function of FunctionWeights this way, so overrides must not guard a function from clients.",
+	"packageversion":  "PackageVersion pins a reference by name to the function while its package reference has this resource version. The reference fails to resolve once the function points to another version of its package.",
 }
 
 func (FunctionReference) SwaggerDoc() map[string]string {
@@ -236,6 +250,7 @@ var map_HTTPTriggerSpec = map[string]string{
 	"method":        "Use Methods instead of Method. This field is going to be deprecated in a future release HTTP method to access a function.",
 	"methods":       "HTTP methods to access a function",
 	"functionref":   "FunctionReference is a reference to the target function.",
+	"timeout":       "Timeout is how many seconds the router waits for the function to respond to a request of the trigger. The FunctionTimeout of the function is used if it is not set.",
 	"createingress": "If CreateIngress is true, router will create an ingress definition.",
 	"ingressconfig": "IngressConfig for router to set up Ingress.",
 }
@@ -283,10 +298,39 @@ func (KubernetesWatchTriggerList) SwaggerDoc() map[string]string {
 }
 
 var map_KubernetesWatchTriggerSpec = map[string]string{
-	"":              "KubernetesWatchTriggerSpec defines spec of KuberenetesWatchTrigger",
-	"type":          "Type of resource to watch (Pod, Service, etc.)",
-	"labelselector": "Resource labels",
-	"functionref":   "The reference to a function for kubewatcher to invoke with when receiving events.",
+	"":                        "KubernetesWatchTriggerSpec defines spec of KuberenetesWatchTrigger",
+	"type":                    "Type of resource to watch (Pod, Service, ReplicationController, Job, CronJob, Event, Endpoints)",
+	"types":                   "Types are further resource types to watch besides Type. The events of all types are sent to the function, which tells them apart by the X-Kubernetes-Object-Type header.",
+	"includeOwnedJobs":        "IncludeOwnedJobs makes a watch of type CronJob also deliver the events of the Jobs the CronJobs spawn, told apart by the X-Kubernetes-Owner-CronJob header naming the CronJob of the Job.",
+	"labelselector":           "Resource labels",
+	"functionref":             "The reference to a function for kubewatcher to invoke with when receiving events.",
+	"paused":                  "Paused stops the watch from invoking the function while keeping the trigger",
+	"filter":                  "Filter is a JSONPath template, e.g. {.status.phase}, evaluated against the event object. Events for which it yields an empty result are skipped.",
+	"filterValue":             "FilterValue, if set, is the result the Filter must yield for the event to be published.",
+	"gzip":                    "Gzip compresses event bodies of at least GzipMinSize bytes and sets Content-Encoding: gzip on the request to the function.",
+	"gzipMinSize":             "GzipMinSize is the minimum body size in bytes to compress, DefaultGzipMinSize is used if it is not set.",
+	"dryRun":                  "DryRun logs the events that would be published instead of invoking the function",
+	"includeNormalEvents":     "IncludeNormalEvents makes a watch of type Event deliver Normal events too, by default only the other ones, e.g. Warning, are delivered.",
+	"eventInvolvedObjectKind": "EventInvolvedObjectKind makes a watch of type Event deliver only the events about objects of the kind, e.g. Pod. Like EventReason, it is a field selector, so the API server filters the events.",
+	"eventReason":             "EventReason makes a watch of type Event deliver only the events with the reason, e.g. FailedScheduling. Events of type Normal also need IncludeNormalEvents.",
+	"includeOldObject":        "IncludeOldObject sends the previous state of the object in the X-Kubernetes-Old-Object header of Modified events, if it was seen.",
+	"sink":                    "Sink is where events are delivered, either the function (default) or the Topic of the message queue the kubewatcher is configured with.",
+	"topic":                   "Topic to publish events to if Sink is mqtopic.",
+	"allNamespaces":           "AllNamespaces watches the resources of all namespaces instead of Namespace, which must then be empty or \"*\". The kubewatcher must be allowed to watch the resource type cluster-wide.",
+	"functionPath":            "FunctionPath is appended to the URL of the function, e.g. /events/pod, so one function can tell the watches it serves apart by path.",
+	"pruneFields":             "PruneFields are dot separated paths of fields, e.g. metadata.managedFields or status, removed from the object before it is sent. The Filter still sees the whole object.",
+	"maxEventAge":             "MaxEventAge skips Added events of objects created longer ago, e.g. the existing objects replayed when a watch restarts from scratch. Modified and Deleted events are always delivered. String representation of time.Duration, ex : 30s, 5m",
+	"publishTimeout":          "PublishTimeout is how long the function may take to respond to an event, the kubewatcher default is used if it is not set. String representation of time.Duration, ex : 500ms, 10m",
+	"publishMethod":           "PublishMethod is the HTTP method of the requests sending events to the function, one of POST (default), PUT or PATCH.",
+	"resourceVersion":         "ResourceVersion is where a new watch starts. Unset, it starts from the current state, sending the existing objects as Added events. \"now\" only sends the changes made from now on, and a resource version replays the changes made since, e.g. after an incident.",
+	"transform":               "Transform reshapes the object before it is sent. It maps the fields of the JSON body sent to the function to JSONPath templates, e.g. {.status.phase}, evaluated against the event object. The Filter still sees the whole object.",
+	"headers":                 "Headers are added to the requests sending events to the function, e.g. a shared secret the function authenticates them with. The X-Kubernetes-* headers set by the kubewatcher can't be overridden.",
+	"headersFromSecrets":      "HeadersFromSecrets are like Headers, but take their values from a key of a secret in the namespace of the trigger. The secrets are read when the watch starts.",
+	"circuitBreakerThreshold": "CircuitBreakerThreshold is the number of consecutive failed deliveries to the function after which the kubewatcher opens the circuit breaker of the trigger: events are dropped for CircuitBreakerCooldown, then a single one is sent to test whether the function recovered. 0 disables it.",
+	"circuitBreakerCooldown":  "CircuitBreakerCooldown is how long the circuit breaker stays open, DefaultCircuitBreakerCooldown is used if it is not set. String representation of time.Duration, ex : 30s, 5m",
+	"skipReplayOnError":       "SkipReplayOnError keeps a watch from replaying the existing objects after an error. By default, a watch that can't find out where to resume after an error restarts from scratch, sending all existing objects again as Added events so that no change is missed. With SkipReplayOnError it retries until it can resume from the current state instead: the changes made meanwhile are lost, but large namespaces aren't replayed to the function.",
+	"concurrencyPolicy":       "ConcurrencyPolicy is how the events of the watch are delivered: one at a time and in order (Serial), or concurrently (Parallel). Parallel gives up ordering: e.g. the Deleted event of an object may reach the function before its Modified event. Serial is used if it is not set.",
+	"logLevel":                "LogLevel is the level of the logs of the watch in the kubewatcher, one of debug, info, warn or error, e.g. debug to troubleshoot a single trigger. The level of the kubewatcher is used if it is not set.",
 }
 
 func (KubernetesWatchTriggerSpec) SwaggerDoc() map[string]string {
@@ -310,22 +354,29 @@ func (MessageQueueTriggerList) SwaggerDoc() map[string]string {
 }
 
 var map_MessageQueueTriggerSpec = map[string]string{
-	"":                 "MessageQueueTriggerSpec defines a binding from a topic in a message queue to a function.",
-	"functionref":      "The reference to a function for message queue trigger to invoke with when receiving messages from subscribed topic.",
-	"messageQueueType": "Type of message queue (NATS, Kafka, AzureQueue)",
-	"topic":            "Subscribed topic",
-	"respTopic":        "Topic for message queue trigger to sent response from function.",
-	"errorTopic":       "Topic to collect error response sent from function",
-	"maxRetries":       "Maximum times for message queue trigger to retry",
-	"contentType":      "Content type of payload",
-	"pollingInterval":  "The period to check each trigger source on every ScaledObject, and scale the deployment up or down accordingly",
-	"cooldownPeriod":   "The period to wait after the last trigger reported active before scaling the deployment back to 0",
-	"minReplicaCount":  "Minimum number of replicas KEDA will scale the deployment down to",
-	"maxReplicaCount":  "Maximum number of replicas KEDA will scale the deployment up to",
-	"metadata":         "ScalerTrigger fields",
-	"secret":           "Secret name",
-	"mqtkind":          "Kind of Message Queue Trigger to be created, by default its fission",
-	"podspec":          "(Optional) Podspec allows modification of deployed runtime pod with Kubernetes PodSpec The merging logic is briefly described below and detailed MergePodSpec function - Volumes mounts and env variables for function and fetcher container are appended - All additional containers and init containers are appended - Volume definitions are appended - Lists such as tolerations, ImagePullSecrets, HostAliases are appended - Structs are merged and variables from pod spec take precedence",
+	"":                  "MessageQueueTriggerSpec defines a binding from a topic in a message queue to a function.",
+	"functionref":       "The reference to a function for message queue trigger to invoke with when receiving messages from subscribed topic.",
+	"messageQueueType":  "Type of message queue (NATS, Kafka, AzureQueue)",
+	"topic":             "Subscribed topic",
+	"topicPattern":      "TopicPattern makes Topic a regular expression, the trigger subscribes to every topic matching it. Only Kafka supports it.",
+	"respTopic":         "Topic for message queue trigger to sent response from function.",
+	"respTopics":        "Further topics the response from function is sent to, in addition to ResponseTopic.",
+	"errorTopic":        "Topic to collect error response sent from function",
+	"errorFormat":       "Format of the messages sent to ErrorTopic, raw (default) sends the error only, envelope a JSON object with the original message, the function, the error, the retry count and the time.",
+	"maxRetries":        "Maximum times for message queue trigger to retry",
+	"retryBackoff":      "Delay before redelivering a message whose function invocation failed, doubled on every further retry. String representation of time.Duration, ex : 500ms, 2s, 1m",
+	"maxInflight":       "Maximum number of messages a consumer fetches from the broker ahead of processing them. Lower it for slow functions so prefetched messages don't time out and get redelivered. Uses the consumer default if unset. KEDA connectors get it as MAX_INFLIGHT, those that can't bound prefetching ignore it.",
+	"contentType":       "Content type of payload",
+	"respContentType":   "Content type of the function response published to ResponseTopic, defaults to ContentType",
+	"pollingInterval":   "The period to check each trigger source on every ScaledObject, and scale the deployment up or down accordingly",
+	"cooldownPeriod":    "The period to wait after the last trigger reported active before scaling the deployment back to 0",
+	"minReplicaCount":   "Minimum number of replicas KEDA will scale the deployment down to",
+	"maxReplicaCount":   "Maximum number of replicas KEDA will scale the deployment up to",
+	"metadata":          "ScalerTrigger fields",
+	"secret":            "Secret name",
+	"authenticationRef": "AuthenticationRef is the name of an existing KEDA TriggerAuthentication in the trigger namespace, used instead of one generated from Secret.",
+	"mqtkind":           "Kind of Message Queue Trigger to be created, by default its fission",
+	"podspec":           "(Optional) Podspec allows modification of deployed runtime pod with Kubernetes PodSpec The merging logic is briefly described below and detailed MergePodSpec function - Volumes mounts and env variables for function and fetcher container are appended - All additional containers and init containers are appended - Volume definitions are appended - Lists such as tolerations, ImagePullSecrets, HostAliases are appended - Structs are merged and variables from pod spec take precedence",
 }
 
 func (MessageQueueTriggerSpec) SwaggerDoc() map[string]string {
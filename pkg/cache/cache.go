@@ -18,6 +18,8 @@ package cache
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	ferror "github.com/fission/fission/pkg/error"
@@ -31,6 +33,8 @@ const (
 	DELETE
 	EXPIRE
 	COPY
+	LEN
+	KEYS
 )
 
 type (
@@ -44,6 +48,12 @@ type (
 		ctimeExpiry    time.Duration
 		atimeExpiry    time.Duration
 		requestChannel chan *request[K, V]
+		// expired counts the entries removed because they were too old
+		expired atomic.Uint64
+		// cleanupInterval is how often expired entries are removed
+		cleanupInterval time.Duration
+		done            chan struct{}
+		closeOnce       sync.Once
 	}
 
 	request[K comparable, V any] struct {
@@ -56,7 +66,9 @@ type (
 		error
 		existingValue V
 		mapCopy       map[K]V
+		keys          []K
 		value         V
+		len           int
 	}
 )
 
@@ -72,12 +84,29 @@ func (c *Cache[K, V]) IsOld(v *Value[V]) bool {
 	return false
 }
 
+// DefaultCleanupInterval is how often MakeCache removes expired entries.
+const DefaultCleanupInterval = time.Minute
+
+// MakeCache returns a cache whose entries expire ctimeExpiry after they were
+// set or atimeExpiry after they were last read, never if zero. Expired entries
+// are removed every DefaultCleanupInterval.
 func MakeCache[K comparable, V any](ctimeExpiry, atimeExpiry time.Duration) *Cache[K, V] {
+	return MakeCacheWithCleanup[K, V](ctimeExpiry, atimeExpiry, DefaultCleanupInterval)
+}
+
+// MakeCacheWithCleanup is MakeCache removing expired entries every
+// cleanupInterval instead. Call Close to stop the goroutines of the cache.
+func MakeCacheWithCleanup[K comparable, V any](ctimeExpiry, atimeExpiry, cleanupInterval time.Duration) *Cache[K, V] {
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultCleanupInterval
+	}
 	c := &Cache[K, V]{
-		cache:          make(map[K]*Value[V]),
-		ctimeExpiry:    ctimeExpiry,
-		atimeExpiry:    atimeExpiry,
-		requestChannel: make(chan *request[K, V]),
+		cache:           make(map[K]*Value[V]),
+		ctimeExpiry:     ctimeExpiry,
+		atimeExpiry:     atimeExpiry,
+		requestChannel:  make(chan *request[K, V]),
+		cleanupInterval: cleanupInterval,
+		done:            make(chan struct{}),
 	}
 	go c.service()
 	if ctimeExpiry != time.Duration(0) || atimeExpiry != time.Duration(0) {
@@ -86,9 +115,44 @@ func MakeCache[K comparable, V any](ctimeExpiry, atimeExpiry time.Duration) *Cac
 	return c
 }
 
+// Close stops the goroutines of the cache. Requests made after Close fail
+// without blocking.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// do sends the request to the service goroutine and waits for its response.
+func (c *Cache[K, V]) do(req *request[K, V]) *response[K, V] {
+	select {
+	case <-c.done:
+		return closedResponse[K, V]()
+	default:
+	}
+	req.responseChannel = make(chan *response[K, V])
+	select {
+	case c.requestChannel <- req:
+	case <-c.done:
+		return closedResponse[K, V]()
+	}
+	return <-req.responseChannel
+}
+
+func closedResponse[K comparable, V any]() *response[K, V] {
+	return &response[K, V]{
+		error: ferror.MakeError(ferror.ErrorInternal, "cache is closed"),
+	}
+}
+
 func (c *Cache[K, V]) service() {
 	for {
-		req := <-c.requestChannel
+		var req *request[K, V]
+		select {
+		case req = <-c.requestChannel:
+		case <-c.done:
+			return
+		}
 		resp := &response[K, V]{}
 		switch req.requestType {
 		case GET:
@@ -100,6 +164,7 @@ func (c *Cache[K, V]) service() {
 				resp.error = ferror.MakeError(ferror.ErrorNotFound,
 					fmt.Sprintf("key '%v' expired (atime %v)", req.key, val.atime))
 				delete(c.cache, req.key)
+				c.expired.Add(1)
 			} else {
 				// update atime
 				val.atime = time.Now()
@@ -129,6 +194,7 @@ func (c *Cache[K, V]) service() {
 			for k, v := range c.cache {
 				if c.IsOld(v) {
 					delete(c.cache, k)
+					c.expired.Add(1)
 				}
 			}
 			// no response
@@ -138,6 +204,15 @@ func (c *Cache[K, V]) service() {
 				resp.mapCopy[k] = v.value
 			}
 			req.responseChannel <- resp
+		case LEN:
+			resp.len = len(c.cache)
+			req.responseChannel <- resp
+		case KEYS:
+			resp.keys = make([]K, 0, len(c.cache))
+			for k := range c.cache {
+				resp.keys = append(resp.keys, k)
+			}
+			req.responseChannel <- resp
 		default:
 			resp.error = ferror.MakeError(ferror.ErrorInvalidArgument,
 				fmt.Sprintf("invalid request type: %v", req.requestType))
@@ -147,56 +222,74 @@ func (c *Cache[K, V]) service() {
 }
 
 func (c *Cache[K, V]) Get(key K) (V, error) {
-	respChannel := make(chan *response[K, V])
-	c.requestChannel <- &request[K, V]{
-		requestType:     GET,
-		key:             key,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	resp := c.do(&request[K, V]{
+		requestType: GET,
+		key:         key,
+	})
 	return resp.value, resp.error
 }
 
 // if key exists in the cache, the new value is NOT set; instead an
 // error and the old value are returned
 func (c *Cache[K, V]) Set(key K, value V) (V, error) {
-	respChannel := make(chan *response[K, V])
-	c.requestChannel <- &request[K, V]{
-		requestType:     SET,
-		key:             key,
-		value:           value,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	resp := c.do(&request[K, V]{
+		requestType: SET,
+		key:         key,
+		value:       value,
+	})
 	return resp.existingValue, resp.error
 }
 
 func (c *Cache[K, V]) Delete(key K) error {
-	respChannel := make(chan *response[K, V])
-	c.requestChannel <- &request[K, V]{
-		requestType:     DELETE,
-		key:             key,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	resp := c.do(&request[K, V]{
+		requestType: DELETE,
+		key:         key,
+	})
 	return resp.error
 }
 
 func (c *Cache[K, V]) Copy() map[K]V {
-	respChannel := make(chan *response[K, V])
-	c.requestChannel <- &request[K, V]{
-		requestType:     COPY,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	resp := c.do(&request[K, V]{
+		requestType: COPY,
+	})
 	return resp.mapCopy
 }
 
+// Len returns the number of entries, including expired ones not removed yet.
+func (c *Cache[K, V]) Len() int {
+	resp := c.do(&request[K, V]{
+		requestType: LEN,
+	})
+	return resp.len
+}
+
+// Keys returns the keys of the entries in no particular order, including
+// expired ones not removed yet.
+func (c *Cache[K, V]) Keys() []K {
+	resp := c.do(&request[K, V]{
+		requestType: KEYS,
+	})
+	return resp.keys
+}
+
+// Expired returns the number of entries removed so far because they expired.
+func (c *Cache[K, V]) Expired() uint64 {
+	return c.expired.Load()
+}
+
 func (c *Cache[K, V]) expiryService() {
+	ticker := time.NewTicker(c.cleanupInterval)
+	defer ticker.Stop()
 	for {
-		time.Sleep(time.Minute)
-		c.requestChannel <- &request[K, V]{
-			requestType: EXPIRE,
+		select {
+		case <-ticker.C:
+		case <-c.done:
+			return
+		}
+		select {
+		case c.requestChannel <- &request[K, V]{requestType: EXPIRE}:
+		case <-c.done:
+			return
 		}
 	}
 }
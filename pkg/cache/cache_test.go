@@ -18,6 +18,7 @@ package cache
 
 import (
 	"log"
+	"sync"
 	"testing"
 	"time"
 )
@@ -46,6 +47,9 @@ func TestCache(t *testing.T) {
 	if len(cc) != 2 {
 		log.Panicf("expected 2 items")
 	}
+	if c.Len() != 2 {
+		log.Panicf("expected length 2, got %v", c.Len())
+	}
 
 	err = c.Delete("a")
 	checkErr(err)
@@ -62,4 +66,83 @@ func TestCache(t *testing.T) {
 	if err == nil {
 		log.Panicf("found expired element")
 	}
+	if c.Expired() != 1 {
+		log.Panicf("expected 1 expired element, got %v", c.Expired())
+	}
+}
+
+func TestCacheKeysConcurrent(t *testing.T) {
+	c := MakeCache[int, int](0, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := c.Set(i*100+j, j)
+				checkErr(err)
+				// the keys include at least those set by this goroutine so far
+				if n := len(c.Keys()); n < j+1 {
+					t.Errorf("expected at least %v keys, got %v", j+1, n)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	keys := c.Keys()
+	if len(keys) != 1000 || c.Len() != 1000 {
+		t.Fatalf("expected 1000 keys, got %v (length %v)", len(keys), c.Len())
+	}
+	seen := make(map[int]bool)
+	for _, k := range keys {
+		if seen[k] {
+			t.Errorf("duplicate key %v", k)
+		}
+		seen[k] = true
+	}
+}
+
+func TestCacheCleanupInterval(t *testing.T) {
+	c := MakeCacheWithCleanup[string, string](10*time.Millisecond, 0, 20*time.Millisecond)
+	defer c.Close()
+
+	_, err := c.Set("a", "b")
+	checkErr(err)
+
+	// the entry is removed without being read again
+	deadline := time.Now().Add(time.Second)
+	for c.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected the expired entry to be removed, got length %v", c.Len())
+	}
+	if c.Expired() != 1 {
+		t.Errorf("expected 1 expired entry, got %v", c.Expired())
+	}
+}
+
+func TestCacheClose(t *testing.T) {
+	c := MakeCache[string, string](time.Minute, 0)
+	_, err := c.Set("a", "b")
+	checkErr(err)
+
+	c.Close()
+	c.Close()
+
+	done := make(chan error)
+	go func() {
+		_, err := c.Get("a")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected an error from a closed cache")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("request to a closed cache blocked")
+	}
 }
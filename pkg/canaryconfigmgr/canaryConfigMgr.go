@@ -32,6 +32,7 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/canaryconfigmgr/weights"
 	"github.com/fission/fission/pkg/crd"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	"github.com/fission/fission/pkg/utils"
@@ -202,7 +203,7 @@ func (canaryCfgMgr *canaryConfigMgr) processCanaryConfig(ctx *context.Context, c
 		case <-ticker.C:
 			// every weightIncrementDuration, check if failureThreshold has reached.
 			// if yes, rollback.
-			// else, increment the weight of new function and decrement old function by `weightIncrement`
+			// else, increment the weight of new function by `weightIncrement` and decrement old functions proportionally
 			canaryCfgMgr.logger.Info("processing canary config",
 				zap.String("name", canaryConfig.ObjectMeta.Name),
 				zap.String("namespace", canaryConfig.ObjectMeta.Namespace),
@@ -458,8 +459,7 @@ func (canaryCfgMgr *canaryConfigMgr) updateCanaryConfigStatusWithRetries(ctx con
 
 func (canaryCfgMgr *canaryConfigMgr) rollback(ctx context.Context, canaryConfig *fv1.CanaryConfig, trigger *fv1.HTTPTrigger) error {
 	functionWeights := trigger.Spec.FunctionReference.FunctionWeights
-	functionWeights[canaryConfig.Spec.NewFunction] = 0
-	functionWeights[canaryConfig.Spec.OldFunction] = 100
+	weights.Revert(functionWeights, canaryConfig.Spec.NewFunction, canaryConfig.Spec.GetStableFunctions())
 
 	err := canaryCfgMgr.updateHttpTriggerWithRetries(ctx, trigger.ObjectMeta.Name, trigger.ObjectMeta.Namespace, functionWeights)
 	if err != nil {
@@ -473,21 +473,9 @@ func (canaryCfgMgr *canaryConfigMgr) rollback(ctx context.Context, canaryConfig
 }
 
 func (canaryCfgMgr *canaryConfigMgr) rollForward(ctx context.Context, canaryConfig *fv1.CanaryConfig, trigger *fv1.HTTPTrigger) (bool, error) {
-	doneProcessingCanaryConfig := false
-
 	functionWeights := trigger.Spec.FunctionReference.FunctionWeights
-	if functionWeights[canaryConfig.Spec.NewFunction]+canaryConfig.Spec.WeightIncrement >= 100 {
-		doneProcessingCanaryConfig = true
-		functionWeights[canaryConfig.Spec.NewFunction] = 100
-		functionWeights[canaryConfig.Spec.OldFunction] = 0
-	} else {
-		functionWeights[canaryConfig.Spec.NewFunction] += canaryConfig.Spec.WeightIncrement
-		if functionWeights[canaryConfig.Spec.OldFunction]-canaryConfig.Spec.WeightIncrement < 0 {
-			functionWeights[canaryConfig.Spec.OldFunction] = 0
-		} else {
-			functionWeights[canaryConfig.Spec.OldFunction] -= canaryConfig.Spec.WeightIncrement
-		}
-	}
+	doneProcessingCanaryConfig := weights.Shift(functionWeights, canaryConfig.Spec.NewFunction,
+		canaryConfig.Spec.GetStableFunctions(), canaryConfig.Spec.WeightIncrement)
 
 	canaryCfgMgr.logger.Info("incremented functionWeights",
 		zap.String("name", canaryConfig.ObjectMeta.Name),
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package weights moves traffic weights between the functions of an
+// HTTP trigger during a canary rollout.
+package weights
+
+import (
+	"sort"
+)
+
+// Shift moves up to step weight from the functions in from to the function to.
+// The weight taken from each function is proportional to its current weight,
+// so the relative distribution among them is preserved. It returns true once
+// none of the functions in from has any weight left.
+func Shift(weights map[string]int, to string, from []string, step int) bool {
+	total := sumOf(weights, from)
+	if step > total {
+		step = total
+	}
+	for name, share := range distribute(weights, from, step) {
+		weights[name] -= share
+	}
+	weights[to] += step
+	return total-step == 0
+}
+
+// Revert moves all weight of the function from back to the functions in to,
+// in proportion to their current weights. If none of them has any weight
+// left, everything goes to the first one.
+func Revert(weights map[string]int, from string, to []string) {
+	if len(to) == 0 {
+		return
+	}
+	amount := weights[from]
+	weights[from] = 0
+	if sumOf(weights, to) == 0 {
+		weights[to[0]] += amount
+		return
+	}
+	for name, share := range distribute(weights, to, amount) {
+		weights[name] += share
+	}
+}
+
+func sumOf(weights map[string]int, names []string) int {
+	sum := 0
+	for _, name := range names {
+		if weights[name] > 0 {
+			sum += weights[name]
+		}
+	}
+	return sum
+}
+
+// distribute splits amount among names in proportion to their weights using
+// the largest remainder method, so the shares always add up to amount.
+func distribute(weights map[string]int, names []string, amount int) map[string]int {
+	total := sumOf(weights, names)
+	shares := make(map[string]int, len(names))
+	if total == 0 || amount == 0 {
+		return shares
+	}
+
+	type remainder struct {
+		name  string
+		value int
+	}
+	remainders := make([]remainder, 0, len(names))
+	left := amount
+	for _, name := range names {
+		w := weights[name]
+		if w <= 0 {
+			continue
+		}
+		shares[name] = amount * w / total
+		left -= shares[name]
+		remainders = append(remainders, remainder{name: name, value: amount * w % total})
+	}
+
+	sort.SliceStable(remainders, func(i, j int) bool {
+		return remainders[i].value > remainders[j].value
+	})
+	for i := 0; left > 0; i++ {
+		shares[remainders[i%len(remainders)].name]++
+		left--
+	}
+	return shares
+}
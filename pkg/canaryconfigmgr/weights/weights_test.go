@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package weights
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShift(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights map[string]int
+		from    []string
+		step    int
+		want    map[string]int
+		done    bool
+	}{
+		{
+			name:    "two functions",
+			weights: map[string]int{"new": 0, "old": 100},
+			from:    []string{"old"},
+			step:    20,
+			want:    map[string]int{"new": 20, "old": 80},
+		},
+		{
+			name:    "two functions last step",
+			weights: map[string]int{"new": 90, "old": 10},
+			from:    []string{"old"},
+			step:    20,
+			want:    map[string]int{"new": 100, "old": 0},
+			done:    true,
+		},
+		{
+			name:    "proportional across three functions",
+			weights: map[string]int{"new": 0, "a": 60, "b": 40},
+			from:    []string{"a", "b"},
+			step:    20,
+			want:    map[string]int{"new": 20, "a": 48, "b": 32},
+		},
+		{
+			name:    "rounding keeps the total",
+			weights: map[string]int{"new": 0, "a": 50, "b": 25, "c": 25},
+			from:    []string{"a", "b", "c"},
+			step:    10,
+			want:    map[string]int{"new": 10, "a": 45, "b": 22, "c": 23},
+		},
+		{
+			name:    "untouched function keeps its weight",
+			weights: map[string]int{"new": 0, "a": 80, "fixed": 20},
+			from:    []string{"a"},
+			step:    100,
+			want:    map[string]int{"new": 80, "a": 0, "fixed": 20},
+			done:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			done := Shift(test.weights, "new", test.from, test.step)
+			if done != test.done {
+				t.Errorf("expected done %v, got %v", test.done, done)
+			}
+			if !reflect.DeepEqual(test.weights, test.want) {
+				t.Errorf("expected weights %v, got %v", test.want, test.weights)
+			}
+		})
+	}
+}
+
+func TestRevert(t *testing.T) {
+	weights := map[string]int{"new": 20, "a": 48, "b": 32}
+	Revert(weights, "new", []string{"a", "b"})
+	want := map[string]int{"new": 0, "a": 60, "b": 40}
+	if !reflect.DeepEqual(weights, want) {
+		t.Errorf("expected weights %v, got %v", want, weights)
+	}
+
+	weights = map[string]int{"new": 100, "a": 0, "b": 0}
+	Revert(weights, "new", []string{"a", "b"})
+	want = map[string]int{"new": 0, "a": 100, "b": 0}
+	if !reflect.DeepEqual(weights, want) {
+		t.Errorf("expected weights %v, got %v", want, weights)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canaryconfig
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type CloneSubCommand struct {
+	cmd.CommandActioner
+	canary *fv1.CanaryConfig
+}
+
+// Clone creates a copy of a canary config rolling out another function.
+func Clone(input cli.Input) error {
+	return (&CloneSubCommand{}).do(input)
+}
+
+func (opts *CloneSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CloneSubCommand) complete(input cli.Input) (err error) {
+	dest := input.String(flagkey.CanaryCloneDest)
+	err = validateName(dest)
+	if err != nil {
+		return err
+	}
+
+	_, ns, err := opts.GetResourceNamespace(input, flagkey.NamespaceCanary)
+	if err != nil {
+		return errors.Wrap(err, "error cloning canary config")
+	}
+
+	source, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(ns).Get(input.Context(), input.String(flagkey.CanaryCloneSource), metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error getting canary config")
+	}
+
+	spec := *source.Spec.DeepCopy()
+	spec.NewFunction = input.String(flagkey.CanaryCloneFunc)
+	if input.IsSet(flagkey.CanaryHTTPTriggerName) {
+		spec.Trigger = input.String(flagkey.CanaryHTTPTriggerName)
+	}
+	if input.IsSet(flagkey.CanaryOldFunc) {
+		spec.OldFunction = input.String(flagkey.CanaryOldFunc)
+	}
+	if input.IsSet(flagkey.CanaryOtherFuncs) {
+		spec.OtherFunctions = input.StringSlice(flagkey.CanaryOtherFuncs)
+	}
+
+	// the functions must be referenced by the trigger like for a new config
+	_, err = checkReferences(input.Context(), opts.Client(), ns, &spec)
+	if err != nil {
+		return err
+	}
+
+	opts.canary = &fv1.CanaryConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dest,
+			Namespace: ns,
+		},
+		Spec: spec,
+		Status: fv1.CanaryConfigStatus{
+			Status: fv1.CanaryConfigStatusPending,
+		},
+	}
+	return nil
+}
+
+func (opts *CloneSubCommand) run(input cli.Input) error {
+	_, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(opts.canary.ObjectMeta.Namespace).Create(input.Context(), opts.canary, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error creating canary config")
+	}
+
+	fmt.Printf("canary config '%s' cloned to '%s'\n", input.String(flagkey.CanaryCloneSource), opts.canary.ObjectMeta.Name)
+	return nil
+}
@@ -17,7 +17,11 @@ limitations under the License.
 package canaryconfig
 
 import (
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
 	"github.com/fission/fission/pkg/fission-cli/flag"
@@ -32,18 +36,20 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(createCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.CanaryName, flag.CanaryTriggerName, flag.CanaryNewFunc, flag.CanaryOldFunc},
-		Optional: []flag.Flag{flag.CanaryWeightIncrement, flag.CanaryIncrementInterval, flag.CanaryFailureThreshold, flag.NamespaceFunction},
+		Optional: []flag.Flag{flag.CanaryOtherFuncs, flag.CanaryWeightIncrement, flag.CanaryIncrementInterval, flag.CanaryFailureThreshold, flag.NamespaceFunction, flag.OutputFormat},
 	})
 
 	getCmd := &cobra.Command{
 		Use:     "get",
 		Aliases: []string{},
-		Short:   "View parameters in a canary config",
-		RunE:    wrapper.Wrapper(Get),
+		Short:   "View parameters and rollout progress of a canary config",
+		Long: "Show the parameters of a canary config and its rollout progress, read from the function weights of its HTTP trigger. " +
+			"The failure rate the canary controller computes from the metrics of the new function is not shown.",
+		RunE: wrapper.Wrapper(Get),
 	}
 	wrapper.SetFlags(getCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.CanaryName},
-		Optional: []flag.Flag{flag.NamespaceCanary},
+		Optional: []flag.Flag{flag.NamespaceCanary, flag.OutputFormat},
 	})
 
 	updateCmd := &cobra.Command{
@@ -65,7 +71,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(deleteCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.CanaryName},
-		Optional: []flag.Flag{flag.NamespaceCanary, flag.IgnoreNotFound},
+		Optional: []flag.Flag{flag.NamespaceCanary, flag.IgnoreNotFound, flag.CanaryWait, flag.CanaryWaitTimeout, flag.CanaryRollback},
 	})
 
 	listCmd := &cobra.Command{
@@ -79,13 +85,34 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceCanary, flag.AllNamespaces},
 	})
 
+	cloneCmd := &cobra.Command{
+		Use:     "clone",
+		Aliases: []string{},
+		Short:   "Clone a canary config to roll out another function",
+		Long:    "Create a copy of a canary config with a new name and new function, the HTTP trigger and old functions are kept unless given",
+		RunE:    wrapper.Wrapper(Clone),
+	}
+	wrapper.SetFlags(cloneCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.CanaryCloneSource, flag.CanaryCloneDest, flag.CanaryCloneFunc},
+		Optional: []flag.Flag{flag.CanaryTriggerName, flag.CanaryOldFunc, flag.CanaryOtherFuncs, flag.NamespaceCanary},
+	})
+
 	command := &cobra.Command{
 		Use:     "canary",
 		Aliases: []string{"canary-config"},
 		Short:   "Create, Update and manage canary configs",
 	}
 
-	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd)
+	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd, cloneCmd)
 
 	return command
 }
+
+// validateName checks the canary config name before it is sent to the
+// server, which would only return a less helpful error.
+func validateName(name string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return errors.Errorf("invalid canary config name '%s': %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
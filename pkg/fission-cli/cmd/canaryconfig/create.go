@@ -17,6 +17,7 @@ limitations under the License.
 package canaryconfig
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/canaryconfigmgr/weights"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
@@ -32,7 +34,32 @@ import (
 
 type CreateSubCommand struct {
 	cmd.CommandActioner
-	canary *fv1.CanaryConfig
+	canary  *fv1.CanaryConfig
+	trigger *fv1.HTTPTrigger
+}
+
+// createResult is what create prints with --output: the canary config along
+// with the rollout it is going to perform on the HTTP trigger.
+type createResult struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Spec      fv1.CanaryConfigSpec   `json:"spec"`
+	Status    fv1.CanaryConfigStatus `json:"status"`
+
+	// InitialWeights are the function weights of the HTTP trigger before the
+	// rollout starts.
+	InitialWeights map[string]int `json:"initialWeights"`
+
+	// Schedule lists the function weights after each weight increment,
+	// assuming the new function never crosses the failure threshold.
+	Schedule []rolloutStep `json:"schedule"`
+}
+
+// rolloutStep is the weight distribution the canary config sets on the HTTP
+// trigger After the given time since the rollout started.
+type rolloutStep struct {
+	After   string         `json:"after"`
+	Weights map[string]int `json:"weights"`
 }
 
 func Create(input cli.Input) error {
@@ -51,9 +78,14 @@ func (opts *CreateSubCommand) complete(input cli.Input) (err error) {
 	// canary configs can be created for functions in the same namespace
 
 	name := input.String(flagkey.CanaryName)
+	err = validateName(name)
+	if err != nil {
+		return err
+	}
 	ht := input.String(flagkey.CanaryHTTPTriggerName)
 	newFunc := input.String(flagkey.CanaryNewFunc)
 	oldFunc := input.String(flagkey.CanaryOldFunc)
+	otherFuncs := input.StringSlice(flagkey.CanaryOtherFuncs)
 
 	_, fnNs, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
@@ -63,39 +95,32 @@ func (opts *CreateSubCommand) complete(input cli.Input) (err error) {
 	failureThreshold := input.Int(flagkey.CanaryFailureThreshold)
 	incrementInterval := input.String(flagkey.CanaryIncrementInterval)
 
+	if input.IsSet(flagkey.OutputFormat) {
+		err = util.ValidateOutputFormat(input.String(flagkey.OutputFormat))
+		if err != nil {
+			return err
+		}
+	}
+
 	// check for time parsing
 	_, err = time.ParseDuration(incrementInterval)
 	if err != nil {
 		return errors.Wrap(err, "error parsing time duration")
 	}
 
-	// check that the trigger exists in the same namespace.
-	htTrigger, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(fnNs).Get(input.Context(), ht, metav1.GetOptions{})
-	if err != nil {
-		return errors.Wrap(err, "error finding http trigger referenced in the canary config")
-	}
-
-	// check that the trigger has function reference type function weights
-	if htTrigger.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionWeights {
-		return errors.New("canary config cannot be created for http triggers that do not reference functions by weights")
+	spec := fv1.CanaryConfigSpec{
+		Trigger:                 ht,
+		NewFunction:             newFunc,
+		OldFunction:             oldFunc,
+		OtherFunctions:          otherFuncs,
+		WeightIncrement:         incrementStep,
+		WeightIncrementDuration: incrementInterval,
+		FailureThreshold:        failureThreshold,
+		FailureType:             fv1.FailureTypeStatusCode,
 	}
-
-	// check that the trigger references same functions in the function weights
-	_, ok := htTrigger.Spec.FunctionReference.FunctionWeights[newFunc]
-	if !ok {
-		return fmt.Errorf("HTTP Trigger doesn't reference the function %s in Canary Config", newFunc)
-	}
-
-	_, ok = htTrigger.Spec.FunctionReference.FunctionWeights[oldFunc]
-	if !ok {
-		return fmt.Errorf("HTTP Trigger doesn't reference the function %s in Canary Config", oldFunc)
-	}
-
-	// check that the functions exist in the same namespace
-	fnList := []string{newFunc, oldFunc}
-	err = util.CheckFunctionExistence(input.Context(), opts.Client(), fnList, fnNs)
+	opts.trigger, err = checkReferences(input.Context(), opts.Client(), fnNs, &spec)
 	if err != nil {
-		return errors.Wrap(err, "error checking functions existence")
+		return err
 	}
 
 	// finally create canaryCfg in the same namespace as the functions referenced
@@ -104,15 +129,7 @@ func (opts *CreateSubCommand) complete(input cli.Input) (err error) {
 			Name:      name,
 			Namespace: fnNs,
 		},
-		Spec: fv1.CanaryConfigSpec{
-			Trigger:                 ht,
-			NewFunction:             newFunc,
-			OldFunction:             oldFunc,
-			WeightIncrement:         incrementStep,
-			WeightIncrementDuration: incrementInterval,
-			FailureThreshold:        failureThreshold,
-			FailureType:             fv1.FailureTypeStatusCode,
-		},
+		Spec: spec,
 		Status: fv1.CanaryConfigStatus{
 			Status: fv1.CanaryConfigStatusPending,
 		},
@@ -122,11 +139,107 @@ func (opts *CreateSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *CreateSubCommand) run(input cli.Input) error {
-	_, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(opts.canary.ObjectMeta.Namespace).Create(input.Context(), opts.canary, metav1.CreateOptions{})
+	created, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(opts.canary.ObjectMeta.Namespace).Create(input.Context(), opts.canary, metav1.CreateOptions{})
 	if err != nil {
 		return errors.Wrap(err, "error creating canary config")
 	}
 
+	if input.IsSet(flagkey.OutputFormat) {
+		interval, _ := time.ParseDuration(created.Spec.WeightIncrementDuration)
+		initialWeights := opts.trigger.Spec.FunctionReference.FunctionWeights
+		return util.PrintObject(input.String(flagkey.OutputFormat), createResult{
+			Name:           created.ObjectMeta.Name,
+			Namespace:      created.ObjectMeta.Namespace,
+			Spec:           created.Spec,
+			Status:         created.Status,
+			InitialWeights: initialWeights,
+			Schedule:       rolloutSchedule(&created.Spec, initialWeights, interval),
+		})
+	}
+
 	fmt.Printf("canary config '%s' created\n", opts.canary.ObjectMeta.Name)
 	return nil
 }
+
+// rolloutSchedule computes the function weights the canary config sets on the
+// HTTP trigger at each increment, the same way the canary config manager does,
+// until the new function receives all the traffic of the stable ones.
+func rolloutSchedule(spec *fv1.CanaryConfigSpec, initialWeights map[string]int, interval time.Duration) []rolloutStep {
+	schedule := []rolloutStep{}
+	if spec.WeightIncrement <= 0 {
+		return schedule
+	}
+	current := make(map[string]int, len(initialWeights))
+	for fn, w := range initialWeights {
+		current[fn] = w
+	}
+	stable := spec.GetStableFunctions()
+	for step := 1; ; step++ {
+		done := weights.Shift(current, spec.NewFunction, stable, spec.WeightIncrement)
+		snapshot := make(map[string]int, len(current))
+		for fn, w := range current {
+			snapshot[fn] = w
+		}
+		schedule = append(schedule, rolloutStep{
+			After:   (time.Duration(step) * interval).String(),
+			Weights: snapshot,
+		})
+		if done {
+			return schedule
+		}
+	}
+}
+
+// checkReferences checks that the HTTP trigger of the canary config references
+// its functions by weights and that the functions exist in the namespace. It
+// returns the HTTP trigger.
+func checkReferences(ctx context.Context, client cmd.Client, namespace string, spec *fv1.CanaryConfigSpec) (*fv1.HTTPTrigger, error) {
+	// check that the trigger exists in the same namespace.
+	htTrigger, err := client.FissionClientSet.CoreV1().HTTPTriggers(namespace).Get(ctx, spec.Trigger, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding http trigger referenced in the canary config")
+	}
+
+	// check that the trigger has function reference type function weights
+	if htTrigger.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionWeights {
+		return nil, errors.New("canary config cannot be created for http triggers that do not reference functions by weights")
+	}
+
+	if spec.NewFunction == spec.OldFunction {
+		return nil, fmt.Errorf("function %s cannot be both the new and the old function", spec.NewFunction)
+	}
+
+	// check that the trigger references same functions in the function weights
+	_, ok := htTrigger.Spec.FunctionReference.FunctionWeights[spec.NewFunction]
+	if !ok {
+		return nil, fmt.Errorf("HTTP Trigger doesn't reference the function %s in Canary Config", spec.NewFunction)
+	}
+
+	_, ok = htTrigger.Spec.FunctionReference.FunctionWeights[spec.OldFunction]
+	if !ok {
+		return nil, fmt.Errorf("HTTP Trigger doesn't reference the function %s in Canary Config", spec.OldFunction)
+	}
+
+	seen := make(map[string]bool, len(spec.OtherFunctions))
+	for _, fn := range spec.OtherFunctions {
+		if fn == spec.NewFunction || fn == spec.OldFunction {
+			return nil, fmt.Errorf("function %s is already referenced as the new or old function", fn)
+		}
+		if seen[fn] {
+			return nil, fmt.Errorf("function %s is given more than once", fn)
+		}
+		seen[fn] = true
+		_, ok = htTrigger.Spec.FunctionReference.FunctionWeights[fn]
+		if !ok {
+			return nil, fmt.Errorf("HTTP Trigger doesn't reference the function %s in Canary Config", fn)
+		}
+	}
+
+	// check that the functions exist in the same namespace
+	fnList := append([]string{spec.NewFunction}, spec.GetStableFunctions()...)
+	err = util.CheckFunctionExistence(ctx, client, fnList, namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error checking functions existence")
+	}
+	return htTrigger, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canaryconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+)
+
+func TestRolloutSchedule(t *testing.T) {
+	spec := &fv1.CanaryConfigSpec{
+		NewFunction:     "new",
+		OldFunction:     "old",
+		OtherFunctions:  []string{"other"},
+		WeightIncrement: 40,
+	}
+	initial := map[string]int{"new": 0, "old": 50, "other": 50}
+
+	schedule := rolloutSchedule(spec, initial, time.Minute)
+	assert.Equal(t, []rolloutStep{
+		{After: "1m0s", Weights: map[string]int{"new": 40, "old": 30, "other": 30}},
+		{After: "2m0s", Weights: map[string]int{"new": 80, "old": 10, "other": 10}},
+		{After: "3m0s", Weights: map[string]int{"new": 100, "old": 0, "other": 0}},
+	}, schedule)
+	assert.Equal(t, map[string]int{"new": 0, "old": 50, "other": 50}, initial, "initial weights must not be modified")
+
+	spec.WeightIncrement = 0
+	assert.Empty(t, rolloutSchedule(spec, initial, time.Minute))
+}
+
+func TestStableFunctions(t *testing.T) {
+	spec := &fv1.CanaryConfigSpec{NewFunction: "new", OldFunction: "old", OtherFunctions: []string{"other"}}
+	assert.Equal(t, "old,other", stableFunctions(spec, nil))
+	assert.Equal(t, "old:30,other:0", stableFunctions(spec, map[string]int{"new": 70, "old": 30}))
+}
+
+func TestCheckReferences(t *testing.T) {
+	objects := []runtime.Object{&fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default"},
+		Spec: fv1.HTTPTriggerSpec{FunctionReference: fv1.FunctionReference{
+			Type:            fv1.FunctionReferenceTypeFunctionWeights,
+			FunctionWeights: map[string]int{"new": 0, "old": 90, "other": 10},
+		}},
+	}}
+	for _, name := range []string{"new", "old", "other"} {
+		objects = append(objects, &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+	client := cmd.Client{FissionClientSet: fake.NewSimpleClientset(objects...)}
+	ctx := context.Background()
+
+	spec := &fv1.CanaryConfigSpec{Trigger: "orders", NewFunction: "new", OldFunction: "old", OtherFunctions: []string{"other"}}
+	_, err := checkReferences(ctx, client, "default", spec)
+	assert.NoError(t, err)
+
+	spec.OtherFunctions = []string{"other", "other"}
+	_, err = checkReferences(ctx, client, "default", spec)
+	assert.Error(t, err, "a function given twice must be rejected")
+
+	spec = &fv1.CanaryConfigSpec{Trigger: "orders", NewFunction: "old", OldFunction: "old"}
+	_, err = checkReferences(ctx, client, "default", spec)
+	assert.Error(t, err, "the new function must differ from the old one")
+}
@@ -17,11 +17,18 @@ limitations under the License.
 package canaryconfig
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/canaryconfigmgr/weights"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
@@ -42,7 +49,25 @@ func (opts *DeleteSubCommand) run(input cli.Input) (err error) {
 		return errors.Wrap(err, "error in deleting canaryConfig ")
 	}
 
-	err = opts.Client().FissionClientSet.CoreV1().CanaryConfigs(namespace).Delete(input.Context(), input.String(flagkey.CanaryName), metav1.DeleteOptions{})
+	name := input.String(flagkey.CanaryName)
+	err = validateName(name)
+	if err != nil {
+		return err
+	}
+
+	// remember the canary config before it is gone
+	var canaryCfg *fv1.CanaryConfig
+	if input.Bool(flagkey.CanaryWait) || input.Bool(flagkey.CanaryRollback) {
+		canaryCfg, err = opts.Client().FissionClientSet.CoreV1().CanaryConfigs(namespace).Get(input.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			if input.Bool(flagkey.IgnoreNotFound) && util.IsNotFound(err) {
+				return nil
+			}
+			return errors.Wrap(err, "error getting canary config")
+		}
+	}
+
+	err = opts.Client().FissionClientSet.CoreV1().CanaryConfigs(namespace).Delete(input.Context(), name, metav1.DeleteOptions{})
 	if err != nil {
 		if input.Bool(flagkey.IgnoreNotFound) && util.IsNotFound(err) {
 			return nil
@@ -50,6 +75,84 @@ func (opts *DeleteSubCommand) run(input cli.Input) (err error) {
 		return errors.Wrap(err, "error deleting canary config")
 	}
 
-	fmt.Printf("canaryconfig '%v.%v' deleted\n", input.String(flagkey.CanaryName), namespace)
+	// the canary config is deleted first so that the controller stops changing the weights
+	if input.Bool(flagkey.CanaryRollback) && canaryCfg.Status.Status == fv1.CanaryConfigStatusPending {
+		err = opts.rollback(input.Context(), canaryCfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.Bool(flagkey.CanaryWait) {
+		err = opts.waitForWeightsFinalized(input.Context(), name, namespace, canaryCfg.Spec.Trigger, input.Duration(flagkey.CanaryWaitTimeout))
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("canaryconfig '%v.%v' deleted\n", name, namespace)
 	return nil
 }
+
+// rollback moves the weight of the new function back to the old functions of
+// the HTTP trigger referenced by the canary config.
+func (opts *DeleteSubCommand) rollback(ctx context.Context, canaryCfg *fv1.CanaryConfig) error {
+	triggers := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(canaryCfg.ObjectMeta.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ht, err := triggers.Get(ctx, canaryCfg.Spec.Trigger, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if ht.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionWeights {
+			return nil
+		}
+		weights.Revert(ht.Spec.FunctionReference.FunctionWeights, canaryCfg.Spec.NewFunction, canaryCfg.Spec.GetStableFunctions())
+		_, err = triggers.Update(ctx, ht, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "error rolling back function weights of http trigger")
+	}
+	return nil
+}
+
+// waitForWeightsFinalized waits until the canary config is removed and the function
+// weights of the referenced HTTP trigger stop changing, i.e. the canary controller
+// is no longer adjusting them.
+func (opts *DeleteSubCommand) waitForWeightsFinalized(ctx context.Context, name, namespace, trigger string, timeout time.Duration) error {
+	var lastWeights map[string]int
+	seen := false
+
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		_, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !util.IsNotFound(err) {
+			return false, errors.Wrap(err, "error getting canary config")
+		}
+		configGone := err != nil
+
+		ht, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).Get(ctx, trigger, metav1.GetOptions{})
+		if err != nil {
+			if util.IsNotFound(err) {
+				// nothing left to roll back
+				return true, nil
+			}
+			return false, errors.Wrap(err, "error getting http trigger referenced in the canary config")
+		}
+
+		fnWeights := ht.Spec.FunctionReference.FunctionWeights
+		if configGone && seen && reflect.DeepEqual(lastWeights, fnWeights) {
+			return true, nil
+		}
+		lastWeights = fnWeights
+		seen = true
+		return false, nil
+	})
+	// an interrupted poll with ctx still alive means the timeout passed
+	if wait.Interrupted(err) && ctx.Err() == nil {
+		return errors.Errorf("timed out waiting for function weights of http trigger '%v' to be finalized", trigger)
+	}
+	return err
+}
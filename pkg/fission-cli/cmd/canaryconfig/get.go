@@ -19,20 +19,40 @@ package canaryconfig
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type GetSubCommand struct {
 	cmd.CommandActioner
 }
 
+// canaryConfigProgress is the canary config along with its rollout
+// progress as observed on the referenced HTTP trigger.
+type canaryConfigProgress struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Spec      fv1.CanaryConfigSpec   `json:"spec"`
+	Status    fv1.CanaryConfigStatus `json:"status"`
+
+	// CurrentWeight is the weight of the new function on the HTTP trigger;
+	// it's unset if the trigger cannot be found.
+	CurrentWeight *int `json:"currentWeight,omitempty"`
+
+	// FunctionWeights is the full weight distribution of the HTTP trigger.
+	FunctionWeights map[string]int `json:"functionWeights,omitempty"`
+}
+
 func Get(input cli.Input) error {
 	return (&GetSubCommand{}).run(input)
 }
@@ -44,17 +64,63 @@ func (opts *GetSubCommand) run(input cli.Input) (err error) {
 		return errors.Wrap(err, "error getting canary config")
 	}
 
-	canaryCfg, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(namespace).Get(input.Context(), input.String(flagkey.CanaryName), metav1.GetOptions{})
+	name := input.String(flagkey.CanaryName)
+	err = validateName(name)
+	if err != nil {
+		return err
+	}
+
+	canaryCfg, err := opts.Client().FissionClientSet.CoreV1().CanaryConfigs(namespace).Get(input.Context(), name, metav1.GetOptions{})
 	if err != nil {
 		return errors.Wrap(err, "error getting canary config")
 	}
 
+	progress := canaryConfigProgress{
+		Name:      canaryCfg.ObjectMeta.Name,
+		Namespace: canaryCfg.ObjectMeta.Namespace,
+		Spec:      canaryCfg.Spec,
+		Status:    canaryCfg.Status,
+	}
+
+	// the canary controller reports progress by adjusting the weights on the trigger
+	ht, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).Get(input.Context(), canaryCfg.Spec.Trigger, metav1.GetOptions{})
+	if err != nil {
+		console.Warn(fmt.Sprintf("error getting http trigger '%v' referenced in the canary config: %v", canaryCfg.Spec.Trigger, err))
+	} else {
+		weight := ht.Spec.FunctionReference.FunctionWeights[canaryCfg.Spec.NewFunction]
+		progress.CurrentWeight = &weight
+		progress.FunctionWeights = ht.Spec.FunctionReference.FunctionWeights
+	}
+
+	if input.IsSet(flagkey.OutputFormat) {
+		return util.PrintObject(input.String(flagkey.OutputFormat), progress)
+	}
+
+	currentWeight := "-"
+	if progress.CurrentWeight != nil {
+		currentWeight = fmt.Sprintf("%v", *progress.CurrentWeight)
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "TRIGGER", "FUNCTION-N", "FUNCTION-N-1", "WEIGHT-INCREMENT", "INTERVAL", "FAILURE-THRESHOLD", "FAILURE-TYPE", "STATUS")
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-		canaryCfg.ObjectMeta.Name, canaryCfg.Spec.Trigger, canaryCfg.Spec.NewFunction, canaryCfg.Spec.OldFunction, canaryCfg.Spec.WeightIncrement, canaryCfg.Spec.WeightIncrementDuration,
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "TRIGGER", "FUNCTION-N", "FUNCTION-N-1", "CURRENT-WEIGHT", "WEIGHT-INCREMENT", "INTERVAL", "FAILURE-THRESHOLD", "FAILURE-TYPE", "STATUS")
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+		canaryCfg.ObjectMeta.Name, canaryCfg.Spec.Trigger, canaryCfg.Spec.NewFunction, stableFunctions(&canaryCfg.Spec, progress.FunctionWeights), currentWeight, canaryCfg.Spec.WeightIncrement, canaryCfg.Spec.WeightIncrementDuration,
 		canaryCfg.Spec.FailureThreshold, canaryCfg.Spec.FailureType, canaryCfg.Status.Status)
 
 	w.Flush()
 	return nil
 }
+
+// stableFunctions lists the functions the canary config shifts weight from,
+// with their weights on the trigger if they are known, e.g. "old:30,other:30".
+func stableFunctions(spec *fv1.CanaryConfigSpec, weights map[string]int) string {
+	stable := spec.GetStableFunctions()
+	if weights == nil {
+		return strings.Join(stable, ",")
+	}
+	parts := make([]string, 0, len(stable))
+	for _, fn := range stable {
+		parts = append(parts, fmt.Sprintf("%v:%v", fn, weights[fn]))
+	}
+	return strings.Join(parts, ",")
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"sync"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/utils"
 )
 
 type (
@@ -45,26 +47,50 @@ func (c *CommandActioner) Client() Client {
 	return defaultClient
 }
 
-func (c *CommandActioner) GetResourceNamespace(input cli.Input, deprecatedFlag string) (namespace, currentNS string, err error) {
-	namespace = input.String(deprecatedFlag)
-	currentNS = namespace
+// NamespaceSource describes where the namespace of a CLI request came from.
+type NamespaceSource string
 
-	if input.String(flagkey.Namespace) != "" {
-		namespace = input.String(flagkey.Namespace)
-		currentNS = namespace
-		console.Verbose(2, "Namespace for resource %s ", currentNS)
-		return namespace, currentNS, err
-	}
+const (
+	NamespaceSourceFlag       NamespaceSource = "--namespace flag"
+	NamespaceSourceEnv        NamespaceSource = "FISSION_DEFAULT_NAMESPACE environment variable"
+	NamespaceSourceKubeConfig NamespaceSource = "kubeconfig context"
+)
 
-	if namespace == "" {
-		if os.Getenv("FISSION_DEFAULT_NAMESPACE") != "" {
-			currentNS = os.Getenv("FISSION_DEFAULT_NAMESPACE")
-		} else {
-			currentNS = c.Client().Namespace
-			return namespace, currentNS, err
+// ResolveNamespace picks the namespace for a resource. The first non-empty
+// source wins, in this order:
+//
+//  1. the --namespace (-n) flag
+//  2. the deprecated per-resource flag, e.g. --fnNamespace or --canaryNamespace
+//  3. the FISSION_DEFAULT_NAMESPACE environment variable
+//  4. the namespace of the current kubeconfig context (contextNS)
+func ResolveNamespace(input cli.Input, deprecatedFlag, contextNS string) (string, NamespaceSource) {
+	if ns := input.String(flagkey.Namespace); ns != "" {
+		return ns, NamespaceSourceFlag
+	}
+	if deprecatedFlag != "" {
+		if ns := input.String(deprecatedFlag); ns != "" {
+			return ns, NamespaceSource("--" + deprecatedFlag + " flag")
 		}
 	}
+	if ns := os.Getenv(utils.ENV_DEFAULT_NAMESPACE); ns != "" {
+		return ns, NamespaceSourceEnv
+	}
+	return contextNS, NamespaceSourceKubeConfig
+}
 
+// GetResourceNamespace resolves the namespace of a resource following the
+// precedence documented on ResolveNamespace. currentNS is always the resolved
+// namespace, while namespace is only set when it was given explicitly through
+// a flag. With --show-namespace the chosen source is printed to stderr.
+func (c *CommandActioner) GetResourceNamespace(input cli.Input, deprecatedFlag string) (namespace, currentNS string, err error) {
+	currentNS, source := ResolveNamespace(input, deprecatedFlag, c.Client().Namespace)
+	if source != NamespaceSourceEnv && source != NamespaceSourceKubeConfig {
+		namespace = currentNS
+	}
+
+	if input.Bool(flagkey.ShowNamespace) {
+		fmt.Fprintf(os.Stderr, "Using namespace %q from %s\n", currentNS, source)
+	}
 	console.Verbose(2, "Namespace for resource %s ", currentNS)
 	return namespace, currentNS, nil
 }
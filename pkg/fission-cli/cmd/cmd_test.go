@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/dummy"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/utils"
+)
+
+func TestResolveNamespace(t *testing.T) {
+	tests := []struct {
+		name       string
+		flags      map[string]string
+		env        string
+		wantNS     string
+		wantSource NamespaceSource
+	}{
+		{
+			name:       "namespace flag wins over everything",
+			flags:      map[string]string{flagkey.Namespace: "flag-ns", flagkey.NamespaceFunction: "fn-ns"},
+			env:        "env-ns",
+			wantNS:     "flag-ns",
+			wantSource: NamespaceSourceFlag,
+		},
+		{
+			name:       "deprecated flag wins over env",
+			flags:      map[string]string{flagkey.NamespaceFunction: "fn-ns"},
+			env:        "env-ns",
+			wantNS:     "fn-ns",
+			wantSource: NamespaceSource("--fnNamespace flag"),
+		},
+		{
+			name:       "env wins over kubeconfig",
+			env:        "env-ns",
+			wantNS:     "env-ns",
+			wantSource: NamespaceSourceEnv,
+		},
+		{
+			name:       "kubeconfig context as fallback",
+			wantNS:     "context-ns",
+			wantSource: NamespaceSourceKubeConfig,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(utils.ENV_DEFAULT_NAMESPACE, test.env)
+			input := dummy.TestFlagSet()
+			for k, v := range test.flags {
+				input.Set(k, v)
+			}
+			ns, source := ResolveNamespace(input, flagkey.NamespaceFunction, "context-ns")
+			assert.Equal(t, test.wantNS, ns)
+			assert.Equal(t, test.wantSource, source)
+		})
+	}
+}
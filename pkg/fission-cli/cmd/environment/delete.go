@@ -23,10 +23,12 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type DeleteSubCommand struct {
@@ -47,17 +49,23 @@ func (opts *DeleteSubCommand) do(input cli.Input) (err error) {
 	envName := input.String(flagkey.EnvName)
 
 	if !input.Bool(flagkey.EnvForce) {
-		fns, err := opts.Client().FissionClientSet.CoreV1().Functions(metav1.NamespaceAll).List(input.Context(), metav1.ListOptions{})
+		errInUse := errors.New("Environment is used by at least one function.")
+		err := util.EachPage(input.Context(), opts.Client().FissionClientSet.CoreV1().Functions(metav1.NamespaceAll).List, func(fns *fv1.FunctionList) error {
+			for _, fn := range fns.Items {
+				if fn.Spec.Environment.Name == envName &&
+					fn.Spec.Environment.Namespace == currentContextNS {
+					// stops listing the remaining pages
+					return errInUse
+				}
+			}
+			return nil
+		})
+		if err == errInUse {
+			return err
+		}
 		if err != nil {
 			return errors.Wrap(err, "Error getting functions wrt environment.")
 		}
-
-		for _, fn := range fns.Items {
-			if fn.Spec.Environment.Name == envName &&
-				fn.Spec.Environment.Namespace == currentContextNS {
-				return errors.New("Environment is used by at least one function.")
-			}
-		}
 	}
 
 	err = opts.Client().FissionClientSet.CoreV1().Environments(currentContextNS).Delete(input.Context(), envName, metav1.DeleteOptions{})
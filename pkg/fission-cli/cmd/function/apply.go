@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	applyv1 "github.com/fission/fission/pkg/generated/applyconfiguration/core/v1"
+)
+
+// applyFunction server-side applies the function as fieldManager, so that it
+// only owns the fields it sets and doesn't conflict with other controllers,
+// e.g. the canary controller. fieldManager is the --field-manager flag, which
+// defaults to the CLI's own field manager. With force, fields owned by other
+// field managers are taken over instead of failing with a conflict.
+func applyFunction(ctx context.Context, c cmd.Client, fn *fv1.Function, fieldManager string, force bool) (*fv1.Function, error) {
+	return c.FissionClientSet.CoreV1().Functions(fn.ObjectMeta.Namespace).Apply(ctx, functionApplyConfiguration(fn),
+		metav1.ApplyOptions{FieldManager: fieldManager, Force: force})
+}
+
+// functionApplyConfiguration builds the apply configuration of the fields of
+// the function that are set. Fields left at their zero value are not applied,
+// so that the CLI doesn't take ownership of them.
+func functionApplyConfiguration(fn *fv1.Function) *applyv1.FunctionApplyConfiguration {
+	spec := fn.Spec
+	specConfig := applyv1.FunctionSpec()
+
+	if len(spec.Environment.Name) > 0 {
+		specConfig.WithEnvironment(applyv1.EnvironmentReference().
+			WithNamespace(spec.Environment.Namespace).
+			WithName(spec.Environment.Name))
+	}
+	if len(spec.Package.PackageRef.Name) > 0 || len(spec.Package.FunctionName) > 0 {
+		pkgConfig := applyv1.FunctionPackageRef()
+		if len(spec.Package.PackageRef.Name) > 0 {
+			pkgRef := applyv1.PackageRef().
+				WithNamespace(spec.Package.PackageRef.Namespace).
+				WithName(spec.Package.PackageRef.Name)
+			if len(spec.Package.PackageRef.ResourceVersion) > 0 {
+				pkgRef.WithResourceVersion(spec.Package.PackageRef.ResourceVersion)
+			}
+			pkgConfig.WithPackageRef(pkgRef)
+		}
+		if len(spec.Package.FunctionName) > 0 {
+			pkgConfig.WithFunctionName(spec.Package.FunctionName)
+		}
+		specConfig.WithPackage(pkgConfig)
+	}
+	if len(spec.Resources.Limits) > 0 || len(spec.Resources.Requests) > 0 || len(spec.Resources.Claims) > 0 {
+		specConfig.WithResources(spec.Resources)
+	}
+	if invokeStrategy := invokeStrategyApplyConfiguration(spec.InvokeStrategy); invokeStrategy != nil {
+		specConfig.WithInvokeStrategy(invokeStrategy)
+	}
+	if spec.FunctionTimeout != 0 {
+		specConfig.WithFunctionTimeout(spec.FunctionTimeout)
+	}
+	if spec.Concurrency != 0 {
+		specConfig.WithConcurrency(spec.Concurrency)
+	}
+	if spec.RequestsPerPod != 0 {
+		specConfig.WithRequestsPerPod(spec.RequestsPerPod)
+	}
+	if spec.OnceOnly {
+		specConfig.WithOnceOnly(spec.OnceOnly)
+	}
+	if spec.RetainPods != 0 {
+		specConfig.WithRetainPods(spec.RetainPods)
+	}
+	for _, s := range spec.Secrets {
+		specConfig.WithSecrets(applyv1.SecretReference().WithNamespace(s.Namespace).WithName(s.Name))
+	}
+	for _, c := range spec.ConfigMaps {
+		specConfig.WithConfigMaps(applyv1.ConfigMapReference().WithNamespace(c.Namespace).WithName(c.Name))
+	}
+	if spec.IdleTimeout != nil {
+		specConfig.WithIdleTimeout(*spec.IdleTimeout)
+	}
+	if spec.PodSpec != nil {
+		specConfig.WithPodSpec(*spec.PodSpec)
+	}
+
+	return applyv1.Function(fn.ObjectMeta.Name, fn.ObjectMeta.Namespace).
+		WithLabels(fn.ObjectMeta.Labels).
+		WithAnnotations(fn.ObjectMeta.Annotations).
+		WithSpec(specConfig)
+}
+
+// invokeStrategyApplyConfiguration returns the apply configuration of the set
+// fields of the invoke strategy, nil if none is set.
+func invokeStrategyApplyConfiguration(is fv1.InvokeStrategy) *applyv1.InvokeStrategyApplyConfiguration {
+	es := is.ExecutionStrategy
+	execStrategy := applyv1.ExecutionStrategy()
+	set := false
+	if len(es.ExecutorType) > 0 {
+		execStrategy.WithExecutorType(es.ExecutorType)
+		set = true
+	}
+	if es.MinScale != 0 {
+		execStrategy.WithMinScale(es.MinScale)
+		set = true
+	}
+	if es.MaxScale != 0 {
+		execStrategy.WithMaxScale(es.MaxScale)
+		set = true
+	}
+	if es.TargetCPUPercent != 0 {
+		execStrategy.WithTargetCPUPercent(es.TargetCPUPercent)
+		set = true
+	}
+	if es.SpecializationTimeout != 0 {
+		execStrategy.WithSpecializationTimeout(es.SpecializationTimeout)
+		set = true
+	}
+	if len(es.Metrics) > 0 {
+		execStrategy.WithMetrics(es.Metrics...)
+		set = true
+	}
+	if es.Behavior != nil {
+		execStrategy.WithBehavior(*es.Behavior)
+		set = true
+	}
+
+	if !set && len(is.StrategyType) == 0 {
+		return nil
+	}
+	invokeStrategy := applyv1.InvokeStrategy()
+	if set {
+		invokeStrategy.WithExecutionStrategy(execStrategy)
+	}
+	if len(is.StrategyType) > 0 {
+		invokeStrategy.WithStrategyType(is.StrategyType)
+	}
+	return invokeStrategy
+}
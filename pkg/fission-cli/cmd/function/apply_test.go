@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/dummy"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+func TestFunctionApplyConfiguration(t *testing.T) {
+	idleTimeout := 60
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hello",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "hello"},
+		},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Namespace: "default", Name: "nodejs"},
+			Package: fv1.FunctionPackageRef{
+				PackageRef:   fv1.PackageRef{Namespace: "default", Name: "hello-pkg", ResourceVersion: "12"},
+				FunctionName: "hello.js",
+			},
+			Secrets:    []fv1.SecretReference{{Namespace: "default", Name: "s"}},
+			ConfigMaps: []fv1.ConfigMapReference{{Namespace: "default", Name: "c"}},
+			InvokeStrategy: fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypePoolmgr,
+					MaxScale:              1,
+					SpecializationTimeout: 120,
+				},
+			},
+			FunctionTimeout: 60,
+			IdleTimeout:     &idleTimeout,
+			Concurrency:     500,
+			RequestsPerPod:  1,
+		},
+	}
+
+	data, err := json.Marshal(functionApplyConfiguration(fn))
+	require.NoError(t, err)
+
+	var applied fv1.Function
+	require.NoError(t, json.Unmarshal(data, &applied))
+	assert.Equal(t, "Function", applied.Kind)
+	assert.Equal(t, fn.ObjectMeta.Name, applied.ObjectMeta.Name)
+	assert.Equal(t, fn.ObjectMeta.Labels, applied.ObjectMeta.Labels)
+	assert.Equal(t, fn.Spec, applied.Spec)
+}
+
+func TestFunctionApplyConfigurationSkipsZeroFields(t *testing.T) {
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Namespace: "default", Name: "nodejs"},
+		},
+	}
+
+	data, err := json.Marshal(functionApplyConfiguration(fn))
+	require.NoError(t, err)
+
+	var applied map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &applied))
+	spec, ok := applied["spec"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Len(t, spec, 1)
+	assert.Contains(t, spec, "environment")
+}
+
+func TestForceConflictsRequiresApply(t *testing.T) {
+	flags := dummy.TestFlagSet()
+	flags.Set(flagkey.FnName, "hello")
+	flags.Set(flagkey.FnForceConflicts, true)
+
+	err := (&CreateSubCommand{}).complete(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force-conflicts requires --apply")
+
+	err = (&UpdateSubCommand{}).complete(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force-conflicts requires --apply")
+}
+
+func TestFieldManagerRequiresApply(t *testing.T) {
+	flags := dummy.TestFlagSet()
+	flags.Set(flagkey.FnName, "hello")
+	flags.Set(flagkey.FnFieldManager, "team-a")
+
+	err := (&CreateSubCommand{}).complete(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--field-manager requires --apply")
+
+	err = (&UpdateSubCommand{}).complete(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--field-manager requires --apply")
+}
@@ -49,7 +49,7 @@ func Commands() *cobra.Command {
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin,
 			flag.ReplicasMax, flag.RunTimeTargetCPU,
-			flag.NamespaceFunction, flag.SpecSave, flag.SpecDry},
+			flag.NamespaceFunction, flag.SpecSave, flag.SpecDry, flag.FnApply, flag.FnFieldManager, flag.FnForceConflicts},
 	})
 
 	getCmd := &cobra.Command{
@@ -97,7 +97,7 @@ func Commands() *cobra.Command {
 			flag.RunTimeMaxMemory, flag.ReplicasMin, flag.ReplicasMax,
 			flag.RunTimeTargetCPU,
 
-			flag.NamespaceFunction, flag.NamespaceEnvironment, flag.SpecSave,
+			flag.NamespaceFunction, flag.NamespaceEnvironment, flag.SpecSave, flag.FnApply, flag.FnFieldManager, flag.FnForceConflicts,
 		},
 	})
 
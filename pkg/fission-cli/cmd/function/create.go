@@ -64,6 +64,12 @@ func (opts *CreateSubCommand) do(input cli.Input) error {
 
 func (opts *CreateSubCommand) complete(input cli.Input) error {
 	fnName := input.String(flagkey.FnName)
+	if input.IsSet(flagkey.FnFieldManager) && !input.Bool(flagkey.FnApply) {
+		return errors.Errorf("--%v requires --%v", flagkey.FnFieldManager, flagkey.FnApply)
+	}
+	if input.Bool(flagkey.FnForceConflicts) && !input.Bool(flagkey.FnApply) {
+		return errors.Errorf("--%v requires --%v", flagkey.FnForceConflicts, flagkey.FnApply)
+	}
 
 	userProvidedNS, fnNamespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
@@ -368,13 +374,20 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 		return nil
 	}
 
-	_, err := opts.Client().FissionClientSet.CoreV1().Functions(opts.function.ObjectMeta.Namespace).Create(input.Context(), opts.function, metav1.CreateOptions{})
-	if err != nil {
-		return errors.Wrap(err, "error creating function")
+	if input.Bool(flagkey.FnApply) {
+		_, err := applyFunction(input.Context(), opts.Client(), opts.function, input.String(flagkey.FnFieldManager), input.Bool(flagkey.FnForceConflicts))
+		if err != nil {
+			return errors.Wrap(err, "error applying function")
+		}
+		fmt.Printf("function '%s' applied\n", opts.function.ObjectMeta.Name)
+	} else {
+		_, err := opts.Client().FissionClientSet.CoreV1().Functions(opts.function.ObjectMeta.Namespace).Create(input.Context(), opts.function, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "error creating function")
+		}
+		fmt.Printf("function '%s' created\n", opts.function.ObjectMeta.Name)
 	}
 
-	fmt.Printf("function '%s' created\n", opts.function.ObjectMeta.Name)
-
 	// Allow the user to specify an HTTP trigger while creating a function.
 	triggerUrl := input.String(flagkey.HtUrl)
 	prefix := input.String(flagkey.HtPrefix)
@@ -413,7 +426,7 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 			},
 		},
 	}
-	_, err = opts.Client().FissionClientSet.CoreV1().HTTPTriggers(opts.function.ObjectMeta.Namespace).Create(input.Context(), ht, metav1.CreateOptions{})
+	_, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(opts.function.ObjectMeta.Namespace).Create(input.Context(), ht, metav1.CreateOptions{})
 	if err != nil {
 		return errors.Wrap(err, "error creating HTTP trigger")
 	}
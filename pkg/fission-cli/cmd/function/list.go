@@ -25,9 +25,11 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -47,38 +49,40 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 	if input.Bool(flagkey.AllNamespaces) {
 		namespace = metav1.NamespaceAll
 	}
-	fns, err := opts.Client().FissionClientSet.CoreV1().Functions(namespace).List(input.Context(), metav1.ListOptions{})
-
-	if err != nil {
-		return errors.Wrap(err, "error listing functions")
-	}
 
+	// rows are written page by page, tabwriter aligns them on Flush
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
 
 	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "ENV", "EXECUTORTYPE", "MINSCALE", "MAXSCALE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "SECRETS", "CONFIGMAPS", "NAMESPACE")
-	for _, f := range fns.Items {
-		secrets := f.Spec.Secrets
-		configMaps := f.Spec.ConfigMaps
-		var secretsList, configMapList []string
-		for _, secret := range secrets {
-			secretsList = append(secretsList, secret.Name)
+	err = util.EachPage(input.Context(), opts.Client().FissionClientSet.CoreV1().Functions(namespace).List, func(fns *fv1.FunctionList) error {
+		for _, f := range fns.Items {
+			secrets := f.Spec.Secrets
+			configMaps := f.Spec.ConfigMaps
+			var secretsList, configMapList []string
+			for _, secret := range secrets {
+				secretsList = append(secretsList, secret.Name)
+			}
+			for _, configMap := range configMaps {
+				configMapList = append(configMapList, configMap.Name)
+			}
+
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+				f.ObjectMeta.Name, f.Spec.Environment.Name,
+				f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType,
+				f.Spec.InvokeStrategy.ExecutionStrategy.MinScale,
+				f.Spec.InvokeStrategy.ExecutionStrategy.MaxScale,
+				f.Spec.Resources.Requests.Cpu().String(),
+				f.Spec.Resources.Limits.Cpu().String(),
+				f.Spec.Resources.Requests.Memory().String(),
+				f.Spec.Resources.Limits.Memory().String(),
+				strings.Join(secretsList, ","),
+				strings.Join(configMapList, ","),
+				f.ObjectMeta.Namespace)
 		}
-		for _, configMap := range configMaps {
-			configMapList = append(configMapList, configMap.Name)
-		}
-
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			f.ObjectMeta.Name, f.Spec.Environment.Name,
-			f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType,
-			f.Spec.InvokeStrategy.ExecutionStrategy.MinScale,
-			f.Spec.InvokeStrategy.ExecutionStrategy.MaxScale,
-			f.Spec.Resources.Requests.Cpu().String(),
-			f.Spec.Resources.Limits.Cpu().String(),
-			f.Spec.Resources.Requests.Memory().String(),
-			f.Spec.Resources.Limits.Memory().String(),
-			strings.Join(secretsList, ","),
-			strings.Join(configMapList, ","),
-			f.ObjectMeta.Namespace)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing functions")
 	}
 	w.Flush()
 
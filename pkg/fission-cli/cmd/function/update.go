@@ -53,6 +53,12 @@ func (opts *UpdateSubCommand) do(input cli.Input) error {
 
 func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	fnName := input.String(flagkey.FnName)
+	if input.IsSet(flagkey.FnFieldManager) && !input.Bool(flagkey.FnApply) {
+		return errors.Errorf("--%v requires --%v", flagkey.FnFieldManager, flagkey.FnApply)
+	}
+	if input.Bool(flagkey.FnForceConflicts) && !input.Bool(flagkey.FnApply) {
+		return errors.Errorf("--%v requires --%v", flagkey.FnForceConflicts, flagkey.FnApply)
+	}
 	_, fnNamespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
 		return errors.Wrap(err, "error in updating function ")
@@ -263,6 +269,14 @@ func (opts *UpdateSubCommand) run(input cli.Input) error {
 		}
 		return nil
 	}
+	if input.Bool(flagkey.FnApply) {
+		_, err := applyFunction(input.Context(), opts.Client(), opts.function, input.String(flagkey.FnFieldManager), input.Bool(flagkey.FnForceConflicts))
+		if err != nil {
+			return errors.Wrap(err, "error applying function")
+		}
+		fmt.Printf("Function '%v' applied\n", opts.function.ObjectMeta.Name)
+		return nil
+	}
 	_, err := opts.Client().FissionClientSet.CoreV1().Functions(opts.function.Namespace).Update(input.Context(), opts.function, metav1.UpdateOptions{})
 	if err != nil {
 		return errors.Wrap(err, "error updating function")
@@ -34,7 +34,7 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.HtUrl, flag.HtName, flag.HtMethod, flag.HtIngress,
 			flag.HtIngressRule, flag.HtIngressAnnotation, flag.HtIngressTLS,
 			flag.HtFnWeight, flag.HtHost, flag.NamespaceFunction, flag.SpecSave, flag.SpecDry,
-			flag.HtPrefix, flag.HtKeepPrefix},
+			flag.HtPrefix, flag.HtKeepPrefix, flag.HtTimeout},
 	})
 
 	getCmd := &cobra.Command{
@@ -58,7 +58,7 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.HtUrl, flag.HtFnName,
 			flag.HtMethod, flag.HtIngress, flag.HtIngressRule, flag.HtIngressAnnotation,
 			flag.HtIngressTLS, flag.HtFnWeight, flag.HtHost, flag.NamespaceTrigger,
-			flag.HtPrefix, flag.HtKeepPrefix},
+			flag.HtPrefix, flag.HtKeepPrefix, flag.HtTimeout},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -82,13 +82,36 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceTrigger, flag.HtFnFilter, flag.AllNamespaces},
 	})
 
+	resolveCmd := &cobra.Command{
+		Use:     "resolve",
+		Aliases: []string{},
+		Short:   "Show the function(s) an HTTP trigger resolves to",
+		Long:    "Resolve the function reference of an HTTP trigger the same way the router does and print the functions and their weights",
+		RunE:    wrapper.Wrapper(Resolve),
+	}
+	wrapper.SetFlags(resolveCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.HtName},
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.HtSkipUnhealthy, flag.HtCoalesceWeights},
+	})
+
+	resolveAllCmd := &cobra.Command{
+		Use:     "resolve-all",
+		Aliases: []string{},
+		Short:   "Show the function(s) all HTTP triggers resolve to",
+		Long:    "Resolve the function references of all HTTP triggers in a namespace, or across all namespaces, and flag the triggers the router fails to resolve",
+		RunE:    wrapper.Wrapper(ResolveAll),
+	}
+	wrapper.SetFlags(resolveAllCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.AllNamespaces, flag.HtSkipUnhealthy, flag.HtCoalesceWeights},
+	})
+
 	command := &cobra.Command{
 		Use:     "httptrigger",
 		Aliases: []string{"ht", "route"},
 		Short:   "Create, update and manage HTTP triggers",
 	}
 
-	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd)
+	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd, resolveCmd, resolveAllCmd)
 
 	return command
 }
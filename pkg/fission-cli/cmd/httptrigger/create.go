@@ -194,6 +194,14 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		opts.trigger.Spec.KeepPrefix = input.Bool(flagkey.HtKeepPrefix)
 	}
 
+	if input.IsSet(flagkey.HtTimeout) {
+		timeout := input.Int(flagkey.HtTimeout)
+		if timeout <= 0 {
+			return errors.Errorf("--%v must be greater than 0", flagkey.HtTimeout)
+		}
+		opts.trigger.Spec.Timeout = timeout
+	}
+
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
@@ -66,7 +67,7 @@ func (opts *DeleteSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *DeleteSubCommand) run(input cli.Input) error {
-	triggers, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(opts.namespace).List(input.Context(), metav1.ListOptions{})
+	triggers, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().HTTPTriggers(opts.namespace).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
 	if err != nil {
 		return errors.Wrap(err, "error getting HTTP trigger list")
 	}
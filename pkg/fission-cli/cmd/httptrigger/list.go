@@ -24,6 +24,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -48,7 +49,7 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 	if input.Bool(flagkey.AllNamespaces) {
 		namespace = v1.NamespaceAll
 	}
-	hts, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).List(input.Context(), v1.ListOptions{})
+	hts, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
 
 	if err != nil {
 		return errors.Wrap(err, "error listing HTTP triggers")
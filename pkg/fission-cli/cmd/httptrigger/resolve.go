@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httptrigger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/router/resolve"
+)
+
+type ResolveSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Resolve prints the function(s) an HTTP trigger resolves to, the same way
+// the router resolves its function reference.
+func Resolve(input cli.Input) error {
+	return (&ResolveSubCommand{}).do(input)
+}
+
+func (opts *ResolveSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *ResolveSubCommand) run(input cli.Input) error {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
+	if err != nil {
+		return errors.Wrap(err, "error resolving http trigger")
+	}
+
+	ht, err := opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).Get(input.Context(), input.String(flagkey.HtName), metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error getting http trigger")
+	}
+
+	// the triggers sharing the route are only needed to coalesce their weights
+	var triggers []fv1.HTTPTrigger
+	if input.Bool(flagkey.HtCoalesceWeights) {
+		hts, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
+		if err != nil {
+			return errors.Wrap(err, "error listing HTTP triggers")
+		}
+		triggers = hts.Items
+	}
+
+	res, err := resolveTrigger(input.Context(), opts.Client(), ht, triggers, input.Bool(flagkey.HtSkipUnhealthy))
+	if err != nil {
+		return err
+	}
+
+	if len(res.coalescedInto) > 0 {
+		fmt.Printf("function weights coalesced into trigger %s, which serves the route\n\n", res.coalescedInto)
+	} else if len(res.sharedWith) > 0 {
+		fmt.Printf("function weights coalesced with the triggers %s sharing the route\n\n", strings.Join(res.sharedWith, ", "))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", "FUNCTION", "WEIGHT", "NAMESPACE", "HEALTH")
+	for _, fn := range res.functions() {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", fn.name, fn.weight, ht.Namespace, fn.health())
+	}
+	w.Flush()
+
+	if len(res.HeaderOverrides) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		fmt.Fprintf(w, "%v\t%v\t%v\n", "HEADER", "VALUE", "FUNCTION")
+		for _, o := range res.HeaderOverrides {
+			fmt.Fprintf(w, "%v\t%v\t%v\n", o.Header, o.Value, o.Function)
+		}
+		w.Flush()
+	}
+
+	return nil
+}
+
+// resolution is what the router resolves an HTTP trigger to.
+type resolution struct {
+	*resolve.Result
+	// coalescedInto is the trigger serving the route, if the function weights
+	// of the trigger are coalesced into another trigger sharing the route
+	coalescedInto string
+	// sharedWith are the other triggers whose function weights are coalesced
+	// with the ones of the trigger
+	sharedWith []string
+}
+
+// resolvedFunction is a function of a resolution with the weight it gets.
+type resolvedFunction struct {
+	name      string
+	weight    int
+	unhealthy bool
+}
+
+func (fn resolvedFunction) health() string {
+	if fn.unhealthy {
+		return "unhealthy"
+	}
+	return "healthy"
+}
+
+// functions returns the functions of the resolution sorted by name. A function
+// given no weight, e.g. as it is unhealthy, has weight 0.
+func (res *resolution) functions() []resolvedFunction {
+	if res.Function != nil {
+		return []resolvedFunction{{name: res.Function.ObjectMeta.Name, weight: 100}}
+	}
+	weights := make(map[string]int, len(res.Weights))
+	for _, w := range res.Weights {
+		weights[w.Name] = w.Weight
+	}
+	fns := make([]resolvedFunction, 0, len(res.Functions))
+	for name := range res.Functions {
+		fns = append(fns, resolvedFunction{name: name, weight: weights[name], unhealthy: slices.Contains(res.Unhealthy, name)})
+	}
+	sort.Slice(fns, func(i, j int) bool {
+		return fns[i].name < fns[j].name
+	})
+	return fns
+}
+
+// resolveTrigger resolves the function reference of the trigger the same way
+// the router does, see resolve.FunctionReference. If triggers are given, the
+// function weights of the ones sharing the route of the trigger are coalesced
+// first, see resolve.CoalesceTriggers.
+func resolveTrigger(ctx context.Context, client cmd.Client, ht *fv1.HTTPTrigger, triggers []fv1.HTTPTrigger, skipUnhealthy bool) (*resolution, error) {
+	res := &resolution{}
+	fr := &ht.Spec.FunctionReference
+	if triggers != nil {
+		groups, coalesced := resolve.CoalesceTriggers(triggers)
+		key := resolve.TriggerKey(ht)
+		primary := key
+		if p, ok := coalesced[key]; ok {
+			res.coalescedInto = p
+			primary = p
+		}
+		if group, ok := groups[primary]; ok {
+			merged := resolve.CoalesceFunctionReferences(group)
+			fr = &merged
+			for i := range group {
+				if k := resolve.TriggerKey(&group[i]); k != key {
+					res.sharedWith = append(res.sharedWith, k)
+				}
+			}
+		}
+	}
+
+	get := func(name string) (*fv1.Function, bool, error) {
+		fn, err := client.FissionClientSet.CoreV1().Functions(ht.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		return fn, true, nil
+	}
+	result, err := resolve.FunctionReference(ht.Namespace, fr, get, skipUnhealthy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error resolving function reference of trigger %s/%s", ht.Namespace, ht.Name)
+	}
+	res.Result = result
+	return res, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httptrigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+)
+
+func TestResolveTrigger(t *testing.T) {
+	client := cmd.Client{FissionClientSet: fake.NewSimpleClientset(
+		&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "stable", Namespace: "default"}},
+		&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: "default",
+			Annotations: map[string]string{fv1.FunctionHealthAnnotation: fv1.FunctionHealthUnhealthy}}},
+	)}
+	weighted := func(name string, created int64, weights map[string]int) fv1.HTTPTrigger {
+		return fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.Unix(created, 0)},
+			Spec: fv1.HTTPTriggerSpec{
+				RelativeURL: "/orders",
+				Method:      "GET",
+				FunctionReference: fv1.FunctionReference{
+					Type:            fv1.FunctionReferenceTypeFunctionWeights,
+					FunctionWeights: weights,
+				},
+			},
+		}
+	}
+	triggers := []fv1.HTTPTrigger{
+		weighted("older", 10, map[string]int{"stable": 90, "canary": 10}),
+		weighted("newer", 20, map[string]int{"stable": 100}),
+	}
+
+	res, err := resolveTrigger(context.Background(), client, &triggers[0], nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, []resolvedFunction{{name: "canary", weight: 10, unhealthy: true}, {name: "stable", weight: 90}}, res.functions())
+
+	res, err = resolveTrigger(context.Background(), client, &triggers[0], nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, []resolvedFunction{{name: "canary", weight: 0, unhealthy: true}, {name: "stable", weight: 90}}, res.functions())
+
+	res, err = resolveTrigger(context.Background(), client, &triggers[1], triggers, false)
+	require.NoError(t, err)
+	assert.Equal(t, "default/older", res.coalescedInto)
+	assert.Equal(t, []resolvedFunction{{name: "canary", weight: 10, unhealthy: true}, {name: "stable", weight: 190}}, res.functions())
+
+	triggers[1].Spec.FunctionReference.FunctionWeights = map[string]int{"missing": 100}
+	_, err = resolveTrigger(context.Background(), client, &triggers[1], nil, false)
+	assert.ErrorContains(t, err, "function default/missing does not exist")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httptrigger
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type ResolveAllSubCommand struct {
+	cmd.CommandActioner
+}
+
+// ResolveAll resolves the function references of all HTTP triggers and
+// prints the functions and weights of each, flagging the triggers that
+// the router would fail to resolve.
+func ResolveAll(input cli.Input) error {
+	return (&ResolveAllSubCommand{}).do(input)
+}
+
+func (opts *ResolveAllSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *ResolveAllSubCommand) run(input cli.Input) error {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
+	if err != nil {
+		return errors.Wrap(err, "error resolving http triggers")
+	}
+	if input.Bool(flagkey.AllNamespaces) {
+		namespace = metav1.NamespaceAll
+	}
+
+	hts, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().HTTPTriggers(namespace).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
+	if err != nil {
+		return errors.Wrap(err, "error listing HTTP triggers")
+	}
+
+	// the weights of triggers sharing a route are coalesced across all listed triggers
+	var triggers []fv1.HTTPTrigger
+	if input.Bool(flagkey.HtCoalesceWeights) {
+		triggers = hts.Items
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "TRIGGER", "NAMESPACE", "FUNCTION", "WEIGHT", "STATUS")
+	for i := range hts.Items {
+		ht := &hts.Items[i]
+		res, err := resolveTrigger(input.Context(), opts.Client(), ht, triggers, input.Bool(flagkey.HtSkipUnhealthy))
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", ht.Name, ht.Namespace, "", "", err.Error())
+			continue
+		}
+		if len(res.coalescedInto) > 0 {
+			// the router serves the route by the trigger it is coalesced into
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", ht.Name, ht.Namespace, "", "", "coalesced into "+res.coalescedInto)
+			continue
+		}
+		for _, fn := range res.functions() {
+			status := "ok"
+			if fn.unhealthy {
+				status = fn.health()
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", ht.Name, ht.Namespace, fn.name, fn.weight, status)
+		}
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d HTTP triggers failed to resolve", failed, len(hts.Items))
+	}
+	return nil
+}
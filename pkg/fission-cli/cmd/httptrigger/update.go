@@ -86,6 +86,14 @@ func (opts *UpdateSubCommand) complete(input cli.Input) (err error) {
 		ht.Spec.KeepPrefix = input.Bool(flagkey.HtKeepPrefix)
 	}
 
+	if input.IsSet(flagkey.HtTimeout) {
+		timeout := input.Int(flagkey.HtTimeout)
+		if timeout < 0 {
+			return errors.Errorf("--%v must be greater than or equal to 0", flagkey.HtTimeout)
+		}
+		ht.Spec.Timeout = timeout
+	}
+
 	methods := input.StringSlice(flagkey.HtMethod)
 	if len(methods) > 0 {
 		for _, method := range methods {
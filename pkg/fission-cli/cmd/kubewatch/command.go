@@ -31,7 +31,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(createCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.KwFnName},
-		Optional: []flag.Flag{flag.KwName, flag.KwObjType, flag.NamespaceFunction, flag.SpecSave, flag.SpecDry},
+		Optional: []flag.Flag{flag.KwName, flag.KwObjType, flag.KwFilter, flag.KwFilterVal, flag.KwGzip, flag.KwGzipMin, flag.KwDryRun, flag.KwAllEvents, flag.KwEventKind, flag.KwEventReason, flag.KwOldObject, flag.KwOwnedJobs, flag.KwSink, flag.KwTopic, flag.KwPath, flag.KwAllNamespaces, flag.KwPrune, flag.KwMaxEventAge, flag.KwPublishTimeout, flag.KwPublishMethod, flag.KwTransform, flag.KwSince, flag.KwHeader, flag.KwHeaderSecret, flag.KwBreakerFails, flag.KwBreakerCool, flag.KwSkipReplay, flag.KwConcurrency, flag.KwLogLevel, flag.NamespaceFunction, flag.SpecSave, flag.SpecDry},
 		// TODO: add label selector flag
 		// flag.KwLabelsFlag
 	})
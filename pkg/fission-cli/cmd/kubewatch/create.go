@@ -18,9 +18,15 @@ package kubewatch
 
 import (
 	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
@@ -62,7 +68,60 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return errors.Wrap(err, "error in listing function ")
 	}
 
-	objType := input.String(flagkey.KwObjType)
+	var objTypes []string
+	for _, t := range strings.Split(input.String(flagkey.KwObjType), ",") {
+		t = strings.TrimSpace(t)
+		if len(t) > 0 {
+			objTypes = append(objTypes, t)
+		}
+	}
+	if len(objTypes) == 0 {
+		return errors.Errorf("need a resource type to watch, use --%v", flagkey.KwObjType)
+	}
+
+	filter := input.String(flagkey.KwFilter)
+	filterValue := input.String(flagkey.KwFilterVal)
+	if len(filter) > 0 {
+		err = jsonpath.New("filter").Parse(filter)
+		if err != nil {
+			return errors.Wrapf(err, "invalid filter '%v'", filter)
+		}
+	} else if len(filterValue) > 0 {
+		return errors.Errorf("--%v requires --%v", flagkey.KwFilterVal, flagkey.KwFilter)
+	}
+
+	gzipMinSize := input.Int(flagkey.KwGzipMin)
+	if gzipMinSize < 0 {
+		return errors.Errorf("--%v must be greater than or equal to 0", flagkey.KwGzipMin)
+	}
+
+	sink := fv1.KubernetesWatchSinkType(input.String(flagkey.KwSink))
+	topic := input.String(flagkey.KwTopic)
+	switch sink {
+	case fv1.KubernetesWatchSinkFunction:
+		if len(topic) > 0 {
+			return errors.Errorf("--%v requires --%v %v", flagkey.KwTopic, flagkey.KwSink, fv1.KubernetesWatchSinkMQTopic)
+		}
+	case fv1.KubernetesWatchSinkMQTopic:
+		if len(topic) == 0 {
+			return errors.Errorf("need a topic to publish events to, use --%v", flagkey.KwTopic)
+		}
+		if input.IsSet(flagkey.KwPath) {
+			return errors.Errorf("--%v requires --%v %v", flagkey.KwPath, flagkey.KwSink, fv1.KubernetesWatchSinkFunction)
+		}
+	default:
+		return errors.Errorf("invalid sink '%v', expected %v or %v", sink, fv1.KubernetesWatchSinkFunction, fv1.KubernetesWatchSinkMQTopic)
+	}
+
+	functionPath := input.String(flagkey.KwPath)
+	if len(functionPath) > 0 {
+		if !strings.HasPrefix(functionPath, "/") {
+			return errors.Errorf("--%v must begin with /", flagkey.KwPath)
+		}
+		if strings.ContainsAny(functionPath, "?#") {
+			return errors.Errorf("--%v must not contain a query string", flagkey.KwPath)
+		}
+	}
 
 	if input.Bool(flagkey.SpecSave) {
 		specDir := util.GetSpecDir(input)
@@ -87,19 +146,154 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		}
 	}
 
+	var transform map[string]string
+	for _, t := range input.StringSlice(flagkey.KwTransform) {
+		field, expr, ok := strings.Cut(t, "=")
+		if !ok || len(field) == 0 {
+			return errors.Errorf("invalid --%v '%v', expected field=template, e.g. phase={.status.phase}", flagkey.KwTransform, t)
+		}
+		err = jsonpath.New(field).Parse(expr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid --%v template '%v'", flagkey.KwTransform, expr)
+		}
+		if transform == nil {
+			transform = make(map[string]string)
+		}
+		transform[field] = expr
+	}
+
+	var headers map[string]string
+	for _, h := range input.StringSlice(flagkey.KwHeader) {
+		name, value, ok := strings.Cut(h, "=")
+		if !ok || len(name) == 0 {
+			return errors.Errorf("invalid --%v '%v', expected name=value, e.g. X-Source=kubewatcher", flagkey.KwHeader, h)
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[name] = value
+	}
+
+	var headersFromSecrets map[string]apiv1.SecretKeySelector
+	for _, h := range input.StringSlice(flagkey.KwHeaderSecret) {
+		name, ref, ok := strings.Cut(h, "=")
+		secret, key, refOk := strings.Cut(ref, ":")
+		if !ok || !refOk || len(name) == 0 || len(secret) == 0 || len(key) == 0 {
+			return errors.Errorf("invalid --%v '%v', expected name=secret:key, e.g. X-Token=mysecret:token", flagkey.KwHeaderSecret, h)
+		}
+		if headersFromSecrets == nil {
+			headersFromSecrets = make(map[string]apiv1.SecretKeySelector)
+		}
+		headersFromSecrets[name] = apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: secret},
+			Key:                  key,
+		}
+	}
+
+	pruneFields := input.StringSlice(flagkey.KwPrune)
+	for _, field := range pruneFields {
+		if slices.Contains(strings.Split(field, "."), "") {
+			return errors.Errorf("invalid --%v '%v', expected a dot separated path of fields, e.g. metadata.managedFields", flagkey.KwPrune, field)
+		}
+	}
+
+	var maxEventAge string
+	if input.IsSet(flagkey.KwMaxEventAge) {
+		age := input.Duration(flagkey.KwMaxEventAge)
+		if age <= 0 {
+			return errors.Errorf("--%v must be greater than 0", flagkey.KwMaxEventAge)
+		}
+		maxEventAge = age.String()
+	}
+
+	var publishTimeout string
+	if input.IsSet(flagkey.KwPublishTimeout) {
+		timeout := input.Duration(flagkey.KwPublishTimeout)
+		if timeout <= 0 {
+			return errors.Errorf("--%v must be greater than 0", flagkey.KwPublishTimeout)
+		}
+		publishTimeout = timeout.String()
+	}
+
+	publishMethod := strings.ToUpper(input.String(flagkey.KwPublishMethod))
+	switch publishMethod {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return errors.Errorf("--%v must be one of POST, PUT or PATCH, got %q", flagkey.KwPublishMethod, input.String(flagkey.KwPublishMethod))
+	}
+	// POST is the default, leave it out of the spec
+	if publishMethod == http.MethodPost {
+		publishMethod = ""
+	}
+
+	breakerThreshold := input.Int(flagkey.KwBreakerFails)
+	if breakerThreshold < 0 {
+		return errors.Errorf("--%v must be greater than or equal to 0", flagkey.KwBreakerFails)
+	}
+	var breakerCooldown string
+	if input.IsSet(flagkey.KwBreakerCool) {
+		cooldown := input.Duration(flagkey.KwBreakerCool)
+		if cooldown <= 0 {
+			return errors.Errorf("--%v must be greater than 0", flagkey.KwBreakerCool)
+		}
+		breakerCooldown = cooldown.String()
+	}
+
+	since := input.String(flagkey.KwSince)
+	if len(since) > 0 && since != fv1.KubernetesWatchResourceVersionNow {
+		_, err = strconv.ParseUint(since, 10, 64)
+		if err != nil {
+			return errors.Errorf("--%v must be %v or a numeric resource version, got %q", flagkey.KwSince, fv1.KubernetesWatchResourceVersionNow, since)
+		}
+	}
+
+	allNamespaces := input.Bool(flagkey.KwAllNamespaces)
+	watchNamespace := namespace
+	if allNamespaces {
+		watchNamespace = ""
+	}
+
 	opts.watcher = &fv1.KubernetesWatchTrigger{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      watchName,
 			Namespace: namespace,
 		},
 		Spec: fv1.KubernetesWatchTriggerSpec{
-			Namespace: namespace,
-			Type:      objType,
+			Namespace:     watchNamespace,
+			AllNamespaces: allNamespaces,
+			Type:          objTypes[0],
+			Types:         objTypes[1:],
 			//LabelSelector: labels,
 			FunctionReference: fv1.FunctionReference{
 				Name: fnName,
 				Type: fv1.FunctionReferenceTypeFunctionName,
 			},
+			Filter:                  filter,
+			FilterValue:             filterValue,
+			Gzip:                    input.Bool(flagkey.KwGzip),
+			GzipMinSize:             gzipMinSize,
+			DryRun:                  input.Bool(flagkey.KwDryRun),
+			IncludeNormalEvents:     input.Bool(flagkey.KwAllEvents),
+			EventInvolvedObjectKind: input.String(flagkey.KwEventKind),
+			EventReason:             input.String(flagkey.KwEventReason),
+			IncludeOldObject:        input.Bool(flagkey.KwOldObject),
+			IncludeOwnedJobs:        input.Bool(flagkey.KwOwnedJobs),
+			Sink:                    sink,
+			Topic:                   topic,
+			FunctionPath:            functionPath,
+			PruneFields:             pruneFields,
+			MaxEventAge:             maxEventAge,
+			PublishTimeout:          publishTimeout,
+			PublishMethod:           publishMethod,
+			Transform:               transform,
+			ResourceVersion:         since,
+			Headers:                 headers,
+			HeadersFromSecrets:      headersFromSecrets,
+			CircuitBreakerThreshold: breakerThreshold,
+			CircuitBreakerCooldown:  breakerCooldown,
+			SkipReplayOnError:       input.Bool(flagkey.KwSkipReplay),
+			ConcurrencyPolicy:       fv1.KubernetesWatchConcurrencyPolicy(input.String(flagkey.KwConcurrency)),
+			LogLevel:                input.String(flagkey.KwLogLevel),
 		},
 	}
 
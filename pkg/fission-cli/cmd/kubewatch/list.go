@@ -19,6 +19,7 @@ package kubewatch
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -60,7 +62,7 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 	if input.Bool(flagkey.AllNamespaces) {
 		opts.namespace = metav1.NamespaceAll
 	}
-	ws, err = opts.Client().FissionClientSet.CoreV1().KubernetesWatchTriggers(opts.namespace).List(input.Context(), metav1.ListOptions{})
+	ws, err = util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().KubernetesWatchTriggers(opts.namespace).List, func(l *v1.KubernetesWatchTriggerList) *[]v1.KubernetesWatchTrigger { return &l.Items })
 
 	if err != nil {
 		return errors.Wrap(err, "error listing kubewatchers")
@@ -72,7 +74,7 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 		"NAME", "NAMESPACE", "OBJTYPE", "LABELS", "FUNCTION_NAME")
 	for _, wa := range ws.Items {
 		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			wa.ObjectMeta.Name, wa.Spec.Namespace, wa.Spec.Type, wa.Spec.LabelSelector, wa.Spec.FunctionReference.Name)
+			wa.ObjectMeta.Name, wa.Spec.Namespace, strings.Join(append([]string{wa.Spec.Type}, wa.Spec.Types...), ","), wa.Spec.LabelSelector, wa.Spec.FunctionReference.Name)
 	}
 	w.Flush()
 
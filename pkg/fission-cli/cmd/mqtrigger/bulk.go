@@ -0,0 +1,256 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// manifestFlags are the create flags a trigger definition in a manifest may set.
+var manifestFlags = []flag.Flag{
+	flag.MqtName, flag.MqtFnName, flag.Namespace, flag.MqtMQType, flag.MqtKind, flag.MqtTopic,
+	flag.MqtTopicPattern, flag.MqtRespTopic, flag.MqtErrorTopic, flag.MqtErrorFormat, flag.MqtMaxRetries, flag.MqtRetryBackoff, flag.MqtMaxInflight,
+	flag.MqtMsgContentType, flag.MqtRespContentType, flag.MqtPollingInterval, flag.MqtCooldownPeriod,
+	flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtSecret, flag.MqtTriggerAuth, flag.MqtMetadata, flag.MqtLabel,
+	flag.MqtForce,
+}
+
+// manifestInput is a cli.Input whose flags are set by a trigger definition of
+// a manifest. Flags the definition does not set fall back to the command line.
+type manifestInput struct {
+	cli.Input
+	values map[string]interface{}
+}
+
+// parseManifest reads a JSON array of trigger definitions, each an object
+// keyed by create flag names, e.g. {"name": "t1", "function": "f", "topic": "in"}.
+func parseManifest(input cli.Input, data []byte) ([]*manifestInput, error) {
+	var defs []map[string]interface{}
+	err := json.Unmarshal(data, &defs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing manifest, expected a JSON array of trigger definitions")
+	}
+
+	flagTypes := make(map[string]flag.FlagType, len(manifestFlags))
+	for _, f := range manifestFlags {
+		flagTypes[f.Name] = f.Type
+	}
+
+	inputs := make([]*manifestInput, 0, len(defs))
+	result := &multierror.Error{}
+	for i, def := range defs {
+		values := make(map[string]interface{}, len(def))
+		for key, v := range def {
+			value, err := convertManifestValue(flagTypes, key, v)
+			if err != nil {
+				result = multierror.Append(result, errors.Wrapf(err, "trigger #%d", i+1))
+				continue
+			}
+			values[key] = value
+		}
+		inputs = append(inputs, &manifestInput{Input: input, values: values})
+	}
+	return inputs, result.ErrorOrNil()
+}
+
+func convertManifestValue(flagTypes map[string]flag.FlagType, key string, v interface{}) (interface{}, error) {
+	t, ok := flagTypes[key]
+	if !ok {
+		return nil, errors.Errorf("unknown field '%s'", key)
+	}
+	switch t {
+	case flag.String:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case flag.Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case flag.Int:
+		if n, ok := v.(float64); ok && n == float64(int(n)) {
+			return int(n), nil
+		}
+	case flag.Duration:
+		if s, ok := v.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid duration of field '%s'", key)
+			}
+			return d, nil
+		}
+	case flag.StringSlice:
+		if l, ok := v.([]interface{}); ok {
+			ss := make([]string, 0, len(l))
+			for _, e := range l {
+				s, ok := e.(string)
+				if !ok {
+					return nil, errors.Errorf("field '%s' must be a list of strings", key)
+				}
+				ss = append(ss, s)
+			}
+			return ss, nil
+		}
+	}
+	return nil, errors.Errorf("invalid value %v of field '%s'", v, key)
+}
+
+func (m *manifestInput) IsSet(key string) bool {
+	if _, ok := m.values[key]; ok {
+		return true
+	}
+	return m.Input.IsSet(key)
+}
+
+func (m *manifestInput) Bool(key string) bool {
+	if v, ok := m.values[key].(bool); ok {
+		return v
+	}
+	return m.Input.Bool(key)
+}
+
+func (m *manifestInput) String(key string) string {
+	if v, ok := m.values[key].(string); ok {
+		return v
+	}
+	return m.Input.String(key)
+}
+
+func (m *manifestInput) StringSlice(key string) []string {
+	if v, ok := m.values[key].([]string); ok {
+		return v
+	}
+	return m.Input.StringSlice(key)
+}
+
+func (m *manifestInput) Int(key string) int {
+	if v, ok := m.values[key].(int); ok {
+		return v
+	}
+	return m.Input.Int(key)
+}
+
+func (m *manifestInput) Duration(key string) time.Duration {
+	if v, ok := m.values[key].(time.Duration); ok {
+		return v
+	}
+	return m.Input.Duration(key)
+}
+
+// createFromFile validates all trigger definitions of the manifest before
+// creating any of them. If a creation fails, the triggers created so far are
+// deleted again; with --if-not-exists, triggers that already exist are left
+// unchanged instead. With --output, the results of all triggers are printed
+// as a list once they are created.
+func (opts *CreateSubCommand) createFromFile(input cli.Input) error {
+	file := input.String(flagkey.MqtFromFile)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "error reading manifest '%s'", file)
+	}
+	inputs, err := parseManifest(input, data)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return errors.Errorf("no triggers found in manifest '%s'", file)
+	}
+
+	triggers := make([]*fv1.MessageQueueTrigger, 0, len(inputs))
+	result := &multierror.Error{}
+	for i, in := range inputs {
+		err = opts.complete(in)
+		if err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "trigger #%d", i+1))
+			continue
+		}
+		triggers = append(triggers, opts.trigger)
+	}
+	if result.ErrorOrNil() != nil {
+		return errors.Wrap(result, "error validating manifest, no triggers were created")
+	}
+
+	// spec and diff output don't change the cluster
+	if input.Bool(flagkey.SpecDry) || input.Bool(flagkey.SpecSave) || input.Bool(flagkey.MqtDiff) {
+		for _, t := range triggers {
+			opts.trigger = t
+			err = opts.run(input)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	structured := input.IsSet(flagkey.OutputFormat)
+	created := make([]*fv1.MessageQueueTrigger, 0, len(triggers))
+	results := make([]createResult, 0, len(triggers))
+	for i, t := range triggers {
+		opts.trigger = t
+		result, err := opts.create(input)
+		if err != nil {
+			err = errors.Wrapf(err, "error creating trigger '%s' (%d/%d)", t.ObjectMeta.Name, i+1, len(triggers))
+			return opts.rollback(input, created, err)
+		}
+		results = append(results, result)
+		if result.Existing {
+			if !structured {
+				fmt.Printf("trigger '%s' already exists, leaving it unchanged (%d/%d)\n", t.ObjectMeta.Name, i+1, len(triggers))
+			}
+			continue
+		}
+		created = append(created, t)
+		if !structured {
+			fmt.Printf("trigger '%s' created (%d/%d)\n", t.ObjectMeta.Name, i+1, len(triggers))
+		}
+	}
+	if structured {
+		return util.PrintObject(input.String(flagkey.OutputFormat), results)
+	}
+	return nil
+}
+
+// rollback deletes the created triggers after cause stopped the bulk creation.
+func (opts *CreateSubCommand) rollback(input cli.Input, created []*fv1.MessageQueueTrigger, cause error) error {
+	result := &multierror.Error{}
+	for _, t := range created {
+		err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(t.ObjectMeta.Namespace).Delete(input.Context(), t.ObjectMeta.Name, metav1.DeleteOptions{})
+		if err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "error deleting trigger '%s'", t.ObjectMeta.Name))
+			continue
+		}
+		console.Info(fmt.Sprintf("trigger '%s' deleted", t.ObjectMeta.Name))
+	}
+	if result.ErrorOrNil() != nil {
+		return errors.Errorf("%v; rollback incomplete, triggers left behind: %v", cause, result)
+	}
+	return errors.Wrapf(cause, "rolled back %d created triggers", len(created))
+}
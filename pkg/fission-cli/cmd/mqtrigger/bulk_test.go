@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+func TestParseManifest(t *testing.T) {
+	inputs, err := parseManifest(nil, []byte(`[
+		{"name": "t1", "function": "f", "topic": "in", "maxretries": 3, "retrybackoff": "2s", "metadata": ["a=b"], "force": true},
+		{"name": "t2", "function": "g", "topic": "in2"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, inputs, 2)
+
+	in := inputs[0]
+	assert.Equal(t, "t1", in.String(flagkey.MqtName))
+	assert.Equal(t, 3, in.Int(flagkey.MqtMaxRetries))
+	assert.Equal(t, 2*time.Second, in.Duration(flagkey.MqtRetryBackoff))
+	assert.Equal(t, []string{"a=b"}, in.StringSlice(flagkey.MqtMetadata))
+	assert.True(t, in.Bool(flagkey.MqtForce))
+	assert.True(t, in.IsSet(flagkey.MqtTopic))
+	assert.Equal(t, "g", inputs[1].String(flagkey.MqtFnName))
+
+	_, err = parseManifest(nil, []byte(`[{"name": "t1", "topc": "in"}, {"maxretries": "3"}, {"retrybackoff": "soon"}]`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown field 'topc'")
+	assert.Contains(t, err.Error(), "invalid value 3 of field 'maxretries'")
+	assert.Contains(t, err.Error(), "invalid duration of field 'retrybackoff'")
+
+	_, err = parseManifest(nil, []byte(`{"name": "t1"}`))
+	require.Error(t, err)
+}
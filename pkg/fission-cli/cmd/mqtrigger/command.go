@@ -30,12 +30,11 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(Create),
 	}
 	wrapper.SetFlags(createCmd, flag.FlagSet{
-		Required: []flag.Flag{flag.MqtFnName, flag.MqtTopic},
-		Optional: []flag.Flag{flag.MqtName, flag.MqtMQType, flag.MqtRespTopic,
-			flag.MqtErrorTopic, flag.MqtMaxRetries, flag.MqtMsgContentType,
+		Optional: []flag.Flag{flag.MqtFnName, flag.MqtTopic, flag.MqtTopicPattern, flag.MqtName, flag.MqtMQType, flag.MqtRespTopic,
+			flag.MqtErrorTopic, flag.MqtErrorFormat, flag.MqtMaxRetries, flag.MqtRetryBackoff, flag.MqtMaxInflight, flag.MqtMsgContentType, flag.MqtRespContentType,
 			flag.NamespaceFunction, flag.SpecSave, flag.SpecDry, flag.MqtPollingInterval,
-			flag.MqtCooldownPeriod, flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtSecret,
-			flag.MqtMetadata, flag.MqtKind},
+			flag.MqtCooldownPeriod, flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtSecret, flag.MqtValidateSecret,
+			flag.MqtTriggerAuth, flag.MqtMetadata, flag.MqtLabel, flag.MqtKind, flag.MqtDiff, flag.MqtForce, flag.MqtFromFile, flag.IfNotExists, flag.OutputFormat},
 	})
 
 	updateCmd := &cobra.Command{
@@ -46,10 +45,25 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(updateCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.MqtName},
-		Optional: []flag.Flag{flag.MqtFnName, flag.MqtTopic, flag.MqtRespTopic, flag.MqtErrorTopic,
-			flag.MqtMaxRetries, flag.MqtMsgContentType, flag.NamespaceTrigger, flag.MqtPollingInterval,
+		Optional: []flag.Flag{flag.MqtFnName, flag.MqtTopic, flag.MqtTopicPattern, flag.MqtRespTopic, flag.MqtErrorTopic, flag.MqtErrorFormat,
+			flag.MqtMaxRetries, flag.MqtRetryBackoff, flag.MqtMaxInflight, flag.MqtMsgContentType, flag.MqtRespContentType, flag.NamespaceTrigger, flag.MqtPollingInterval,
 			flag.MqtCooldownPeriod, flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtMetadata,
-			flag.MqtSecret, flag.MqtKind},
+			flag.MqtSecret, flag.MqtValidateSecret, flag.MqtTriggerAuth, flag.MqtKind},
+	})
+
+	setCmd := &cobra.Command{
+		Use:     "set",
+		Aliases: []string{},
+		Short:   "Set fields of a message queue trigger",
+		Long: "Change only the fields of a message queue trigger given by flags with a merge patch, " +
+			"the other fields, including ones changed since, are kept as they are.",
+		RunE: wrapper.Wrapper(Set),
+	}
+	wrapper.SetFlags(setCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.MqtName},
+		Optional: []flag.Flag{flag.MqtFnName, flag.MqtTopic, flag.MqtRespTopic, flag.MqtErrorTopic, flag.MqtErrorFormat, flag.MqtMaxRetries,
+			flag.MqtRetryBackoff, flag.MqtMaxInflight, flag.MqtMsgContentType, flag.MqtRespContentType, flag.MqtPollingInterval, flag.MqtCooldownPeriod,
+			flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtMetadata, flag.MqtSecret, flag.MqtTriggerAuth, flag.MqtForce, flag.NamespaceTrigger},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -74,13 +88,62 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceTrigger, flag.AllNamespaces},
 	})
 
+	exportCmd := &cobra.Command{
+		Use:     "export",
+		Aliases: []string{},
+		Short:   "Export message queue triggers to spec files",
+		Long:    "Save all message queue triggers of a namespace, or of all namespaces, as spec files that can be applied with 'fission spec apply'",
+		RunE:    wrapper.Wrapper(Export),
+	}
+	wrapper.SetFlags(exportCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.AllNamespaces, flag.SpecDir},
+	})
+
+	tailErrorsCmd := &cobra.Command{
+		Use:     "tail-errors",
+		Aliases: []string{},
+		Short:   "Stream the messages of the error topic of a message queue trigger",
+		Long: "Stream the messages published to the error topic of a message queue trigger to stdout until interrupted. " +
+			"The brokers must be reachable from where the command runs. The tls, ca, cert and key settings of the trigger " +
+			"are used to connect; triggers authenticating with SASL are not supported.",
+		RunE: wrapper.Wrapper(TailErrors),
+	}
+	wrapper.SetFlags(tailErrorsCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.MqtName},
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.MqtBrokers},
+	})
+
+	statusCmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{},
+		Short:   "Show whether the connectors of the message queue types are deployed and healthy",
+		Long: "Report, per message queue type, whether the deployment serving its triggers is present in the fission namespace " +
+			"and ready. KEDA triggers are served by the KEDA connector manager, which must also have a connector image for the type.",
+		RunE: wrapper.Wrapper(Status),
+	}
+	wrapper.SetFlags(statusCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.MqtKind},
+	})
+
+	pruneCmd := &cobra.Command{
+		Use:     "prune",
+		Aliases: []string{},
+		Short:   "Delete message queue triggers whose function no longer exists",
+		Long: "List the message queue triggers of a namespace, or of all namespaces, whose function doesn't exist in the namespace of the trigger, " +
+			"and delete them after confirmation. Their scalers keep running until they are deleted.",
+		RunE: wrapper.Wrapper(Prune),
+	}
+	wrapper.SetFlags(pruneCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.AllNamespaces, flag.Yes},
+	})
+
 	command := &cobra.Command{
 		Use:     "mqtrigger",
 		Aliases: []string{"mqt"},
 		Short:   "Create, update and manage message queue triggers",
 	}
 
-	command.AddCommand(createCmd, updateCmd, deleteCmd, listCmd)
+	command.AddCommand(createCmd, updateCmd, setCmd, deleteCmd, listCmd, exportCmd, tailErrorsCmd, statusCmd, pruneCmd)
 
 	return command
 }
@@ -17,10 +17,19 @@ limitations under the License.
 package mqtrigger
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
@@ -33,9 +42,51 @@ import (
 	"github.com/fission/fission/pkg/utils/uuid"
 )
 
+// defaultContentTypeEnv overrides the default content type of messages.
+const defaultContentTypeEnv = "FISSION_MQT_DEFAULT_CONTENT_TYPE"
+
+// maxInflightReplicaRatio is how many times --maxinflight may exceed
+// --maxreplicacount before a warning is shown.
+const maxInflightReplicaRatio = 10
+
+// consumerGroupKey is the metadata key of the consumer group of KEDA scalers.
+// Triggers consumed by the fission consumer each use a group of their own.
+const consumerGroupKey = "consumerGroup"
+
+// triggerAuthGVR is the resource of KEDA TriggerAuthentications.
+var triggerAuthGVR = schema.GroupVersionResource{
+	Group:    "keda.sh",
+	Version:  "v1alpha1",
+	Resource: "triggerauthentications",
+}
+
 type CreateSubCommand struct {
 	cmd.CommandActioner
 	trigger *fv1.MessageQueueTrigger
+	// existing are the triggers of all namespaces, listed once per command
+	// by listExisting
+	existing       []fv1.MessageQueueTrigger
+	listedExisting bool
+}
+
+// createResult is what create prints with --output.
+type createResult struct {
+	Name             string               `json:"name"`
+	Namespace        string               `json:"namespace"`
+	Topic            string               `json:"topic"`
+	MessageQueueType fv1.MessageQueueType `json:"mqtype"`
+	// Existing is set if the trigger already existed and was left unchanged
+	Existing bool `json:"existing,omitempty"`
+}
+
+func makeCreateResult(mqt *fv1.MessageQueueTrigger, existing bool) createResult {
+	return createResult{
+		Name:             mqt.ObjectMeta.Name,
+		Namespace:        mqt.ObjectMeta.Namespace,
+		Topic:            mqt.Spec.Topic,
+		MessageQueueType: mqt.Spec.MessageQueueType,
+		Existing:         existing,
+	}
 }
 
 func Create(input cli.Input) error {
@@ -43,6 +94,9 @@ func Create(input cli.Input) error {
 }
 
 func (opts *CreateSubCommand) do(input cli.Input) error {
+	if input.IsSet(flagkey.MqtFromFile) {
+		return opts.createFromFile(input)
+	}
 	err := opts.complete(input)
 	if err != nil {
 		return err
@@ -57,6 +111,16 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		mqtName = uuid.NewString()
 	}
 	fnName := input.String(flagkey.MqtFnName)
+	if len(fnName) == 0 {
+		return errors.New("need a function name to create a trigger, use --function")
+	}
+
+	if input.IsSet(flagkey.OutputFormat) {
+		err := util.ValidateOutputFormat(input.String(flagkey.OutputFormat))
+		if err != nil {
+			return err
+		}
+	}
 
 	userProvidedNS, fnNamespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
@@ -70,31 +134,78 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return errors.New("Unsupported message queue type")
 	}
 
-	topic := input.String(flagkey.MqtTopic)
-	if len(topic) == 0 {
-		return errors.New("topic cannot be empty")
+	topic, err := expandEnv(flagkey.MqtTopic, input.String(flagkey.MqtTopic))
+	if err != nil {
+		return err
+	}
+	topicPattern, err := expandEnv(flagkey.MqtTopicPattern, input.String(flagkey.MqtTopicPattern))
+	if err != nil {
+		return err
+	}
+	if len(topicPattern) > 0 {
+		if len(topic) > 0 {
+			return errors.Errorf("--%v and --%v are mutually exclusive", flagkey.MqtTopic, flagkey.MqtTopicPattern)
+		}
+		err = checkTopicPattern(mqType, topicPattern)
+		if err != nil {
+			return err
+		}
+		topic = topicPattern
+	} else if len(topic) == 0 {
+		return errors.Errorf("topic cannot be empty, use --%v or --%v", flagkey.MqtTopic, flagkey.MqtTopicPattern)
 	}
 
-	respTopic := input.String(flagkey.MqtRespTopic)
-	if topic == respTopic {
-		// TODO maybe this should just be a warning, perhaps
-		// allow it behind a --force flag
-		return errors.New("listen topic should not equal to response topic")
+	var respTopics []string
+	for _, t := range input.StringSlice(flagkey.MqtRespTopic) {
+		t, err = expandEnv(flagkey.MqtRespTopic, t)
+		if err != nil {
+			return err
+		}
+		respTopics = append(respTopics, t)
 	}
 
-	errorTopic := input.String(flagkey.MqtErrorTopic)
+	errorTopic, err := expandEnv(flagkey.MqtErrorTopic, input.String(flagkey.MqtErrorTopic))
+	if err != nil {
+		return err
+	}
+	err = checkResponseTopics(topic, errorTopic, respTopics)
+	if err != nil {
+		return err
+	}
+	respTopic, extraRespTopics := splitResponseTopics(respTopics)
+	errorFormat, err := checkErrorFormat(input.String(flagkey.MqtErrorFormat))
+	if err != nil {
+		return err
+	}
 	maxRetries := input.Int(flagkey.MqtMaxRetries)
 
 	if maxRetries < 0 {
 		return errors.New("Maximum number of retries must be greater than or equal to 0")
 	}
 
+	var retryBackoff string
+	if input.IsSet(flagkey.MqtRetryBackoff) {
+		backoff := input.Duration(flagkey.MqtRetryBackoff)
+		if backoff < 0 || backoff > fv1.MaxRetryBackoff {
+			return errors.Errorf("Retry backoff must be between 0 and %v", fv1.MaxRetryBackoff)
+		}
+		retryBackoff = backoff.String()
+	}
+
+	// an explicit flag takes precedence over the default from the environment
 	contentType := input.String(flagkey.MqtMsgContentType)
+	if !input.IsSet(flagkey.MqtMsgContentType) {
+		contentType = os.Getenv(defaultContentTypeEnv)
+	}
 	if len(contentType) == 0 {
 		contentType = "application/json"
 	}
 
-	err = checkMQTopicAvailability(mqType, mqtKind, topic, respTopic)
+	if len(topicPattern) > 0 {
+		err = checkMQTopicAvailability(mqType, mqtKind, respTopics...)
+	} else {
+		err = checkMQTopicAvailability(mqType, mqtKind, append([]string{topic}, respTopics...)...)
+	}
 	if err != nil {
 		return err
 	}
@@ -109,6 +220,11 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return errors.New("CooldownPeriod interval is the period to wait after the last trigger reported active before scaling the deployment back to 0, it must be greater than or equal to 0")
 	}
 
+	err = checkCooldownPeriod(cooldownPeriod, pollingInterval, input.Bool(flagkey.MqtForce), "create")
+	if err != nil {
+		return err
+	}
+
 	minReplicaCount := int32(input.Int(flagkey.MqtMinReplicaCount))
 	if minReplicaCount < 0 {
 		return errors.New("MinReplicaCount must be greater than or equal to 0")
@@ -119,11 +235,51 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return errors.New("MaxReplicaCount must be greater than or equal to 0")
 	}
 
+	if input.IsSet(flagkey.MqtMinReplicaCount) && input.IsSet(flagkey.MqtMaxReplicaCount) {
+		err = checkReplicaCounts(minReplicaCount, maxReplicaCount)
+		if err != nil {
+			return err
+		}
+	}
+
+	maxInflight := input.Int(flagkey.MqtMaxInflight)
+	if input.IsSet(flagkey.MqtMaxInflight) {
+		err = checkMaxInflight(maxInflight, maxReplicaCount)
+		if err != nil {
+			return err
+		}
+	}
+
 	metadata := make(map[string]string)
 	metadataParams := input.StringSlice(flagkey.MqtMetadata)
 	_ = util.UpdateMapFromStringSlice(&metadata, metadataParams)
+	for k, v := range metadata {
+		metadata[k], err = expandEnv(fmt.Sprintf("%v %v", flagkey.MqtMetadata, k), v)
+		if err != nil {
+			return err
+		}
+	}
+	err = validator.ValidateMetadata((string)(mqType), metadata)
+	if err != nil {
+		return err
+	}
 
-	secret := input.String(flagkey.MqtSecret)
+	var labels map[string]string
+	labelParams := input.StringSlice(flagkey.MqtLabel)
+	if len(labelParams) > 0 {
+		labels = make(map[string]string)
+		_ = util.UpdateMapFromStringSlice(&labels, labelParams)
+	}
+
+	secret, err := expandEnv(flagkey.MqtSecret, input.String(flagkey.MqtSecret))
+	if err != nil {
+		return err
+	}
+	triggerAuth := input.String(flagkey.MqtTriggerAuth)
+	err = checkAuthFlags(secret, triggerAuth)
+	if err != nil {
+		return err
+	}
 
 	if input.Bool(flagkey.SpecSave) {
 		specDir := util.GetSpecDir(input)
@@ -151,6 +307,18 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		if err != nil {
 			return err
 		}
+		if len(triggerAuth) > 0 {
+			err = checkTriggerAuthExistence(input.Context(), opts.Client(), triggerAuth, fnNamespace)
+			if err != nil {
+				return err
+			}
+		}
+		if len(secret) > 0 {
+			err = checkSecretExistence(input.Context(), opts.Client(), secret, fnNamespace, mqType, input.Bool(flagkey.MqtValidateSecret))
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	m := metav1.ObjectMeta{
@@ -164,6 +332,8 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 			Namespace: userProvidedNS,
 		}
 	}
+	m.Labels = labels
+
 	opts.trigger = &fv1.MessageQueueTrigger{
 		ObjectMeta: m,
 		Spec: fv1.MessageQueueTriggerSpec{
@@ -171,19 +341,26 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 				Type: fv1.FunctionReferenceTypeFunctionName,
 				Name: fnName,
 			},
-			MessageQueueType: mqType,
-			Topic:            topic,
-			ResponseTopic:    respTopic,
-			ErrorTopic:       errorTopic,
-			MaxRetries:       maxRetries,
-			ContentType:      contentType,
-			PollingInterval:  &pollingInterval,
-			CooldownPeriod:   &cooldownPeriod,
-			MinReplicaCount:  &minReplicaCount,
-			MaxReplicaCount:  &maxReplicaCount,
-			Metadata:         metadata,
-			Secret:           secret,
-			MqtKind:          mqtKind,
+			MessageQueueType:    mqType,
+			Topic:               topic,
+			TopicPattern:        len(topicPattern) > 0,
+			ResponseTopic:       respTopic,
+			ResponseTopics:      extraRespTopics,
+			ErrorTopic:          errorTopic,
+			ErrorFormat:         errorFormat,
+			MaxRetries:          maxRetries,
+			RetryBackoff:        retryBackoff,
+			MaxInflight:         maxInflight,
+			ContentType:         contentType,
+			ResponseContentType: input.String(flagkey.MqtRespContentType),
+			PollingInterval:     &pollingInterval,
+			CooldownPeriod:      &cooldownPeriod,
+			MinReplicaCount:     &minReplicaCount,
+			MaxReplicaCount:     &maxReplicaCount,
+			Metadata:            metadata,
+			Secret:              secret,
+			AuthenticationRef:   triggerAuth,
+			MqtKind:             mqtKind,
 		},
 	}
 
@@ -206,12 +383,75 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 		return nil
 	}
 
+	if input.Bool(flagkey.MqtDiff) {
+		return opts.diff(input)
+	}
+
+	result, err := opts.create(input)
+	if err != nil {
+		return err
+	}
+	if input.IsSet(flagkey.OutputFormat) {
+		return util.PrintObject(input.String(flagkey.OutputFormat), result)
+	}
+	if result.Existing {
+		fmt.Printf("trigger '%s' already exists, leaving it unchanged\n", result.Name)
+		return nil
+	}
+	fmt.Printf("trigger '%s' created\n", result.Name)
+	return nil
+}
+
+// create creates the trigger. With --if-not-exists, a trigger that already
+// exists is reported in its place and left unchanged.
+func (opts *CreateSubCommand) create(input cli.Input) (createResult, error) {
+	if len(opts.trigger.Spec.Metadata[consumerGroupKey]) > 0 {
+		warnSharedConsumerGroup(opts.trigger, opts.listExisting(input.Context()))
+	}
+
 	_, err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(opts.trigger.ObjectMeta.Namespace).Create(input.Context(), opts.trigger, metav1.CreateOptions{})
 	if err != nil {
-		return errors.Wrap(err, "create message queue trigger")
+		if input.Bool(flagkey.IfNotExists) && kerrors.IsAlreadyExists(err) {
+			return opts.existingResult(input)
+		}
+		return createResult{}, errors.Wrap(err, "create message queue trigger")
+	}
+	// the next triggers of a manifest are checked against it too, a later
+	// list has it anyway
+	if opts.listedExisting {
+		opts.existing = append(opts.existing, *opts.trigger)
+	}
+	return makeCreateResult(opts.trigger, false), nil
+}
+
+// existingResult returns the trigger that already exists in place of the one
+// that was to be created.
+func (opts *CreateSubCommand) existingResult(input cli.Input) (createResult, error) {
+	existing, err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(opts.trigger.ObjectMeta.Namespace).Get(input.Context(), opts.trigger.ObjectMeta.Name, metav1.GetOptions{})
+	if err != nil {
+		return createResult{}, errors.Wrap(err, "error getting existing message queue trigger")
+	}
+	return makeCreateResult(existing, true), nil
+}
+
+// diff prints a field-level diff between the spec of the trigger in the
+// cluster and the desired one, without changing anything.
+func (opts *CreateSubCommand) diff(input cli.Input) error {
+	existing, err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(opts.trigger.ObjectMeta.Namespace).Get(input.Context(), opts.trigger.ObjectMeta.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			fmt.Printf("trigger '%s' does not exist and would be created\n", opts.trigger.ObjectMeta.Name)
+			return nil
+		}
+		return errors.Wrap(err, "error getting message queue trigger")
 	}
 
-	fmt.Printf("trigger '%s' created\n", opts.trigger.ObjectMeta.Name)
+	d := cmp.Diff(existing.Spec, opts.trigger.Spec, cmpopts.EquateEmpty())
+	if len(d) == 0 {
+		fmt.Printf("trigger '%s' is up to date\n", opts.trigger.ObjectMeta.Name)
+		return nil
+	}
+	fmt.Printf("trigger '%s' spec changes (-cluster +desired):\n%s", opts.trigger.ObjectMeta.Name, d)
 	return nil
 }
 
@@ -223,3 +463,229 @@ func checkMQTopicAvailability(mqType fv1.MessageQueueType, mqtKind string, topic
 	}
 	return nil
 }
+
+// checkResponseTopics checks that the response topics are distinct and differ
+// from the listen topic and the error topic.
+func checkResponseTopics(topic string, errorTopic string, respTopics []string) error {
+	seen := make(map[string]bool)
+	for _, t := range respTopics {
+		if len(t) == 0 {
+			return errors.Errorf("--%v cannot be empty", flagkey.MqtRespTopic)
+		}
+		if seen[t] {
+			return errors.Errorf("response topic '%v' is given more than once", t)
+		}
+		if t == topic {
+			// TODO maybe this should just be a warning, perhaps
+			// allow it behind a --force flag
+			return errors.New("listen topic should not equal to response topic")
+		}
+		if t == errorTopic {
+			return errors.New("error topic should not equal to response topic")
+		}
+		seen[t] = true
+	}
+	return nil
+}
+
+// splitResponseTopics returns the ResponseTopic and ResponseTopics of a
+// trigger sending responses to the topics. A single topic only sets
+// ResponseTopic, so the trigger stays readable by older connectors.
+func splitResponseTopics(respTopics []string) (string, []string) {
+	if len(respTopics) == 1 {
+		return respTopics[0], nil
+	}
+	return "", respTopics
+}
+
+// checkTriggerAuthExistence checks that the KEDA TriggerAuthentication exists in the namespace.
+func checkTriggerAuthExistence(ctx context.Context, client cmd.Client, name string, namespace string) error {
+	dynamicClient, err := dynamic.NewForConfig(client.RestConfig)
+	if err != nil {
+		return errors.Wrap(err, "error creating dynamic client")
+	}
+	_, err = dynamicClient.Resource(triggerAuthGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return errors.Errorf("TriggerAuthentication '%v' not found in namespace '%v'", name, namespace)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error getting TriggerAuthentication '%v'", name)
+	}
+	return nil
+}
+
+// checkAuthFlags returns an error if a trigger is given both a secret and a
+// TriggerAuthentication to authenticate with, only one of them is used.
+func checkAuthFlags(secret, triggerAuth string) error {
+	if len(triggerAuth) > 0 && len(secret) > 0 {
+		return errors.Errorf("--%v and --%v cannot be used together", flagkey.MqtSecret, flagkey.MqtTriggerAuth)
+	}
+	return nil
+}
+
+// checkSecretExistence checks that the secret of a trigger exists in the
+// namespace. The error lists the keys the scaler of the message queue type
+// expects in it, as a misspelled secret otherwise only shows as the scaler
+// failing to authenticate. With validateKeys, it also checks that the secret
+// has the keys the scaler needs.
+func checkSecretExistence(ctx context.Context, client cmd.Client, name string, namespace string, mqType fv1.MessageQueueType, validateKeys bool) error {
+	secret, err := client.KubernetesClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		msg := fmt.Sprintf("secret '%v' not found in namespace '%v'", name, namespace)
+		if keys := validator.SecretKeys(string(mqType)); len(keys) > 0 {
+			msg += fmt.Sprintf(", the %v scaler reads the keys %v from it", mqType, strings.Join(keys, ", "))
+		}
+		return errors.New(msg)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error getting secret '%v'", name)
+	}
+	if !validateKeys {
+		return nil
+	}
+	data := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		data[key] = string(value)
+	}
+	return errors.Wrapf(validator.ValidateSecretKeys(string(mqType), data), "invalid secret '%v'", name)
+}
+
+// checkCooldownPeriod returns an error if the cooldown period is shorter than
+// the polling interval, unless force is set, then it only warns. KEDA only
+// considers scaling to zero once the cooldown period has elapsed, and only
+// checks the source every polling interval. A cooldown shorter than the
+// polling interval makes the consumer flap between zero and nonzero replicas.
+func checkCooldownPeriod(cooldownPeriod, pollingInterval int32, force bool, action string) error {
+	if cooldownPeriod >= pollingInterval {
+		return nil
+	}
+	msg := fmt.Sprintf("CooldownPeriod (%ds) is shorter than PollingInterval (%ds), consumers may flap between zero and nonzero replicas on scale down", cooldownPeriod, pollingInterval)
+	if !force {
+		return errors.Errorf("%s; use --%s to %s the trigger anyway", msg, flagkey.MqtForce, action)
+	}
+	console.Warn(msg)
+	return nil
+}
+
+// checkReplicaCounts returns an error if the minimum replica count of a
+// trigger exceeds the maximum.
+func checkReplicaCounts(minReplicaCount, maxReplicaCount int32) error {
+	if minReplicaCount > maxReplicaCount {
+		return errors.Errorf("--%s (%d) must not exceed --%s (%d)",
+			flagkey.MqtMinReplicaCount, minReplicaCount, flagkey.MqtMaxReplicaCount, maxReplicaCount)
+	}
+	return nil
+}
+
+// checkMaxInflight validates the number of messages a consumer may prefetch
+// and warns if it is far above the number of consumers the trigger scales to.
+func checkMaxInflight(maxInflight int, maxReplicaCount int32) error {
+	if maxInflight < 1 {
+		return errors.New("Maximum number of in-flight messages must be greater than or equal to 1")
+	}
+	if maxReplicaCount > 0 && maxInflight > maxInflightReplicaRatio*int(maxReplicaCount) {
+		console.Warn(fmt.Sprintf("--%v (%d) is much higher than --%v (%d), slow functions may time out before prefetched messages are processed",
+			flagkey.MqtMaxInflight, maxInflight, flagkey.MqtMaxReplicaCount, maxReplicaCount))
+	}
+	return nil
+}
+
+// checkErrorFormat checks that format is a known format of error topic
+// messages. The default raw format is left out of the spec.
+func checkErrorFormat(format string) (fv1.MessageQueueErrorFormat, error) {
+	switch fv1.MessageQueueErrorFormat(format) {
+	case "", fv1.MessageQueueErrorFormatRaw:
+		return "", nil
+	case fv1.MessageQueueErrorFormatEnvelope:
+		return fv1.MessageQueueErrorFormatEnvelope, nil
+	}
+	return "", errors.Errorf("--%v must be one of %v or %v, got %q",
+		flagkey.MqtErrorFormat, fv1.MessageQueueErrorFormatRaw, fv1.MessageQueueErrorFormatEnvelope, format)
+}
+
+// envVarRef matches ${VAR} and ${VAR:-default} references.
+var envVarRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces ${VAR} references in the value of the flag with the
+// environment variable, or with the default of a ${VAR:-default} reference
+// if the variable is unset or empty. Unset variables without a default are
+// an error, so that a missing variable doesn't produce an empty value.
+func expandEnv(flag string, value string) (string, error) {
+	var missing []string
+	expanded := envVarRef.ReplaceAllStringFunc(value, func(ref string) string {
+		m := envVarRef.FindStringSubmatch(ref)
+		if v := os.Getenv(m[1]); len(v) > 0 {
+			return v
+		}
+		if len(m[2]) > 0 {
+			return m[3]
+		}
+		missing = append(missing, m[1])
+		return ref
+	})
+	if len(missing) > 0 {
+		return "", errors.Errorf("--%v references unset environment variables: %v", flag, strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// checkTopicPattern checks that the message queue type supports subscribing
+// by pattern and that the pattern compiles.
+func checkTopicPattern(mqType fv1.MessageQueueType, pattern string) error {
+	if !validator.IsTopicPatternSupported((string)(mqType)) {
+		return errors.Errorf("--%v is not supported for %s", flagkey.MqtTopicPattern, mqType)
+	}
+	_, err := regexp.Compile(pattern)
+	if err != nil {
+		return errors.Wrapf(err, "invalid --%v '%v'", flagkey.MqtTopicPattern, pattern)
+	}
+	return nil
+}
+
+// listExisting returns the triggers of all namespaces, consumer groups aren't
+// scoped to a namespace. They are listed once, for all the triggers a
+// manifest creates; none are returned if they can't be listed.
+func (opts *CreateSubCommand) listExisting(ctx context.Context) []fv1.MessageQueueTrigger {
+	if opts.listedExisting {
+		return opts.existing
+	}
+	opts.listedExisting = true
+	mqts, err := util.ListAll(ctx, opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(metav1.NamespaceAll).List, func(l *fv1.MessageQueueTriggerList) *[]fv1.MessageQueueTrigger { return &l.Items })
+	if err != nil {
+		console.Verbose(2, "error listing message queue triggers, not checking for shared consumer groups: %v", err)
+		return nil
+	}
+	opts.existing = mqts.Items
+	return opts.existing
+}
+
+// warnSharedConsumerGroup warns if one of the existing triggers consumes the
+// topic of trigger with the same consumer group, as the triggers then split
+// the messages.
+func warnSharedConsumerGroup(trigger *fv1.MessageQueueTrigger, existing []fv1.MessageQueueTrigger) {
+	group := trigger.Spec.Metadata[consumerGroupKey]
+	for _, mqt := range sharingConsumerGroup(trigger, existing) {
+		console.Warn(fmt.Sprintf("MessageQueueTrigger '%v/%v' already consumes topic '%v' with consumer group '%v', the triggers will split its messages. Use a separate consumer group for each trigger to deliver every message to both.",
+			mqt.ObjectMeta.Namespace, mqt.ObjectMeta.Name, trigger.Spec.Topic, group))
+	}
+}
+
+// sharingConsumerGroup returns the existing triggers, other than trigger,
+// consuming its topic with its consumer group.
+func sharingConsumerGroup(trigger *fv1.MessageQueueTrigger, existing []fv1.MessageQueueTrigger) []fv1.MessageQueueTrigger {
+	group := trigger.Spec.Metadata[consumerGroupKey]
+	if len(group) == 0 {
+		return nil
+	}
+	var sharing []fv1.MessageQueueTrigger
+	for _, mqt := range existing {
+		if mqt.ObjectMeta.Namespace == trigger.ObjectMeta.Namespace && mqt.ObjectMeta.Name == trigger.ObjectMeta.Name {
+			continue
+		}
+		if mqt.Spec.MessageQueueType == trigger.Spec.MessageQueueType && mqt.Spec.Topic == trigger.Spec.Topic &&
+			mqt.Spec.Metadata[consumerGroupKey] == group {
+			sharing = append(sharing, mqt)
+		}
+	}
+	return sharing
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+)
+
+func TestCheckTopicPattern(t *testing.T) {
+	assert.NoError(t, checkTopicPattern(fv1.MessageQueueTypeKafka, `^orders\..*`))
+	assert.Error(t, checkTopicPattern(fv1.MessageQueueTypeKafka, `orders.(`), "invalid regular expression")
+	assert.Error(t, checkTopicPattern("nats-jetstream", `^orders\..*`), "unsupported message queue type")
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("MQT_TEST_BROKER", "kafka.staging:9092")
+
+	v, err := expandEnv("metadata", "${MQT_TEST_BROKER}")
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka.staging:9092", v)
+
+	v, err = expandEnv("topic", "orders-${MQT_TEST_UNSET:-dev}")
+	assert.NoError(t, err)
+	assert.Equal(t, "orders-dev", v)
+
+	v, err = expandEnv("topic", "orders")
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", v)
+
+	_, err = expandEnv("metadata", "${MQT_TEST_UNSET}")
+	assert.ErrorContains(t, err, "MQT_TEST_UNSET")
+}
+
+func TestCheckSecretExistence(t *testing.T) {
+	client := cmd.Client{KubernetesClient: fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-auth", Namespace: "default"},
+	})}
+
+	assert.NoError(t, checkSecretExistence(context.Background(), client, "kafka-auth", "default", fv1.MessageQueueTypeKafka, false))
+
+	err := checkSecretExistence(context.Background(), client, "kafka-auth", "other", fv1.MessageQueueTypeKafka, false)
+	assert.ErrorContains(t, err, "not found in namespace 'other'")
+	assert.ErrorContains(t, err, "sasl, username, password")
+
+	err = checkSecretExistence(context.Background(), client, "missing", "default", "nats-jetstream", false)
+	assert.EqualError(t, err, "secret 'missing' not found in namespace 'default'")
+
+	client = cmd.Client{KubernetesClient: fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sqs-auth", Namespace: "default"},
+		Data:       map[string][]byte{"awsAccessKeyID": []byte("id")},
+	})}
+	assert.NoError(t, checkSecretExistence(context.Background(), client, "sqs-auth", "default", "aws-sqs-queue", false))
+	err = checkSecretExistence(context.Background(), client, "sqs-auth", "default", "aws-sqs-queue", true)
+	assert.ErrorContains(t, err, "invalid secret 'sqs-auth'")
+	assert.ErrorContains(t, err, "missing the keys awsSecretAccessKey")
+}
+
+func TestCheckResponseTopics(t *testing.T) {
+	assert.NoError(t, checkResponseTopics("orders", "orders-error", []string{"orders-out", "orders-audit"}))
+	assert.Error(t, checkResponseTopics("orders", "orders-error", []string{"orders-out", "orders-out"}), "duplicate")
+	assert.Error(t, checkResponseTopics("orders", "orders-error", []string{"orders-out", "orders"}), "listen topic")
+	assert.Error(t, checkResponseTopics("orders", "orders-error", []string{"orders-error"}), "error topic")
+
+	respTopic, respTopics := splitResponseTopics([]string{"orders-out"})
+	assert.Equal(t, "orders-out", respTopic)
+	assert.Empty(t, respTopics)
+	respTopic, respTopics = splitResponseTopics([]string{"orders-out", "orders-audit"})
+	assert.Empty(t, respTopic)
+	assert.Equal(t, []string{"orders-out", "orders-audit"}, respTopics)
+}
+
+func TestSharingConsumerGroup(t *testing.T) {
+	kafka := func(namespace, name, topic, group string) fv1.MessageQueueTrigger {
+		return fv1.MessageQueueTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: fv1.MessageQueueTriggerSpec{
+				MessageQueueType: fv1.MessageQueueTypeKafka,
+				Topic:            topic,
+				Metadata:         map[string]string{consumerGroupKey: group},
+			},
+		}
+	}
+	trigger := kafka("default", "orders", "orders", "fission")
+	existing := []fv1.MessageQueueTrigger{
+		trigger,
+		// consumer groups aren't scoped to a namespace
+		kafka("other", "orders-audit", "orders", "fission"),
+		kafka("default", "orders-audit", "orders", "audit"),
+		kafka("default", "payments", "payments", "fission"),
+	}
+	sharing := sharingConsumerGroup(&trigger, existing)
+	if len(sharing) != 1 || sharing[0].ObjectMeta.Namespace != "other" {
+		t.Errorf("expected only the trigger of the other namespace to share the consumer group, got %v", sharing)
+	}
+
+	delete(trigger.Spec.Metadata, consumerGroupKey)
+	assert.Empty(t, sharingConsumerGroup(&trigger, existing))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type ExportSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Export saves the message queue triggers of a namespace as spec files.
+func Export(input cli.Input) error {
+	return (&ExportSubCommand{}).do(input)
+}
+
+func (opts *ExportSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *ExportSubCommand) run(input cli.Input) error {
+	userProvidedNS, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
+	if err != nil {
+		return errors.Wrap(err, "error exporting message queue triggers")
+	}
+	// Like specs saved by create, the namespace is only kept if it was given,
+	// otherwise spec apply creates the triggers in its current namespace.
+	keepNamespace := len(userProvidedNS) > 0
+	if input.Bool(flagkey.AllNamespaces) {
+		namespace = metav1.NamespaceAll
+		keepNamespace = true
+	}
+
+	mqts, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(namespace).List, func(l *fv1.MessageQueueTriggerList) *[]fv1.MessageQueueTrigger { return &l.Items })
+	if err != nil {
+		return errors.Wrap(err, "error listing message queue triggers")
+	}
+
+	specDir := util.GetSpecDir(input)
+	for _, mqt := range mqts.Items {
+		// drop the metadata set by the cluster so the spec applies anywhere
+		trigger := fv1.MessageQueueTrigger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        mqt.ObjectMeta.Name,
+				Labels:      mqt.ObjectMeta.Labels,
+				Annotations: mqt.ObjectMeta.Annotations,
+			},
+			Spec: mqt.Spec,
+		}
+		if keepNamespace {
+			trigger.ObjectMeta.Namespace = mqt.ObjectMeta.Namespace
+		}
+		specFile := fmt.Sprintf("mqtrigger-%v.yaml", trigger.ObjectMeta.Name)
+		err = spec.SpecSaveTo(trigger, specDir, specFile, false)
+		if err != nil {
+			return errors.Wrapf(err, "error saving message queue trigger '%v/%v' spec", mqt.ObjectMeta.Namespace, mqt.ObjectMeta.Name)
+		}
+	}
+
+	fmt.Printf("exported %d message queue triggers to '%v'\n", len(mqts.Items), specDir)
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type PruneSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Prune deletes the message queue triggers whose function no longer exists.
+func Prune(input cli.Input) error {
+	return (&PruneSubCommand{}).do(input)
+}
+
+func (opts *PruneSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *PruneSubCommand) run(input cli.Input) error {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
+	if err != nil {
+		return errors.Wrap(err, "error pruning message queue triggers")
+	}
+	if input.Bool(flagkey.AllNamespaces) {
+		namespace = metav1.NamespaceAll
+	}
+
+	mqts, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(namespace).List, func(l *fv1.MessageQueueTriggerList) *[]fv1.MessageQueueTrigger { return &l.Items })
+	if err != nil {
+		return errors.Wrap(err, "error listing message queue triggers")
+	}
+	fns, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().Functions(namespace).List, func(l *fv1.FunctionList) *[]fv1.Function { return &l.Items })
+	if err != nil {
+		return errors.Wrap(err, "error listing functions")
+	}
+
+	orphaned := orphanedTriggers(mqts.Items, fns.Items)
+	if len(orphaned) == 0 {
+		fmt.Println("no message queue triggers of missing functions found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\n", "NAME", "FUNCTION_NAME", "NAMESPACE")
+	for _, mqt := range orphaned {
+		fmt.Fprintf(w, "%v\t%v\t%v\n", mqt.ObjectMeta.Name, mqt.Spec.FunctionReference.Name, mqt.ObjectMeta.Namespace)
+	}
+	w.Flush()
+
+	if !input.Bool(flagkey.Yes) &&
+		!confirm(os.Stdin, os.Stdout, fmt.Sprintf("Delete these %d message queue triggers?", len(orphaned))) {
+		fmt.Println("no message queue triggers pruned")
+		return nil
+	}
+
+	pruned := 0
+	for _, mqt := range orphaned {
+		err = opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(mqt.ObjectMeta.Namespace).Delete(input.Context(), mqt.ObjectMeta.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			fmt.Printf("error deleting message queue trigger '%v/%v': %v\n", mqt.ObjectMeta.Namespace, mqt.ObjectMeta.Name, err)
+			continue
+		}
+		fmt.Printf("trigger '%v/%v' pruned\n", mqt.ObjectMeta.Namespace, mqt.ObjectMeta.Name)
+		pruned++
+	}
+	if pruned < len(orphaned) {
+		return errors.Errorf("pruned %d of %d message queue triggers", pruned, len(orphaned))
+	}
+	fmt.Printf("pruned %d message queue triggers\n", pruned)
+	return nil
+}
+
+// orphanedTriggers returns the triggers whose function isn't one of fns in
+// the namespace of the trigger.
+func orphanedTriggers(mqts []fv1.MessageQueueTrigger, fns []fv1.Function) []fv1.MessageQueueTrigger {
+	exists := make(map[string]bool, len(fns))
+	for _, fn := range fns {
+		exists[fn.ObjectMeta.Namespace+"/"+fn.ObjectMeta.Name] = true
+	}
+	var orphaned []fv1.MessageQueueTrigger
+	for _, mqt := range mqts {
+		if !exists[mqt.ObjectMeta.Namespace+"/"+mqt.Spec.FunctionReference.Name] {
+			orphaned = append(orphaned, mqt)
+		}
+	}
+	return orphaned
+}
+
+// confirm asks the question on out and returns true if the answer read from
+// in is yes.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%v [y/N]: ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
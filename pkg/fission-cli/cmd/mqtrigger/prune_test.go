@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestOrphanedTriggers(t *testing.T) {
+	trigger := func(name, namespace, fn string) fv1.MessageQueueTrigger {
+		return fv1.MessageQueueTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       fv1.MessageQueueTriggerSpec{FunctionReference: fv1.FunctionReference{Name: fn}},
+		}
+	}
+	mqts := []fv1.MessageQueueTrigger{
+		trigger("orders", "default", "process-orders"),
+		trigger("refunds", "default", "process-refunds"),
+		// the function exists, but in another namespace
+		trigger("orders", "staging", "process-orders"),
+	}
+	fns := []fv1.Function{{ObjectMeta: metav1.ObjectMeta{Name: "process-orders", Namespace: "default"}}}
+
+	orphaned := orphanedTriggers(mqts, fns)
+	assert.Len(t, orphaned, 2)
+	assert.Equal(t, "refunds", orphaned[0].ObjectMeta.Name)
+	assert.Equal(t, "staging", orphaned[1].ObjectMeta.Namespace)
+}
+
+func TestConfirm(t *testing.T) {
+	assert.True(t, confirm(strings.NewReader("y\n"), io.Discard, "Delete?"))
+	assert.True(t, confirm(strings.NewReader("Yes\n"), io.Discard, "Delete?"))
+	assert.False(t, confirm(strings.NewReader("\n"), io.Discard, "Delete?"))
+	assert.False(t, confirm(strings.NewReader(""), io.Discard, "Delete?"))
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// SetSubCommand patches the fields of a trigger given on the command line,
+// unlike UpdateSubCommand it doesn't send the rest of the trigger back, so
+// concurrent changes to other fields are kept.
+type SetSubCommand struct {
+	cmd.CommandActioner
+	name      string
+	namespace string
+	patch     []byte
+}
+
+func Set(input cli.Input) error {
+	return (&SetSubCommand{}).do(input)
+}
+
+func (opts *SetSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *SetSubCommand) complete(input cli.Input) (err error) {
+	_, opts.namespace, err = opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
+	if err != nil {
+		return errors.Wrap(err, "error retrieving namespace information")
+	}
+	opts.name = input.String(flagkey.MqtName)
+
+	spec, err := setSpecPatch(input)
+	if err != nil {
+		return err
+	}
+	if len(spec) == 0 {
+		return errors.New("Nothing to set, see 'help' for more details")
+	}
+
+	if needsTrigger(input) {
+		mqt, err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(opts.namespace).Get(input.Context(), opts.name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrap(err, "error getting message queue trigger")
+		}
+		err = checkSetSpec(input, mqt)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.IsSet(flagkey.MqtFnName) {
+		err = util.CheckFunctionExistence(input.Context(), opts.Client(), []string{input.String(flagkey.MqtFnName)}, opts.namespace)
+		if err != nil {
+			console.Warn(err.Error())
+		}
+	}
+	if triggerAuth := input.String(flagkey.MqtTriggerAuth); len(triggerAuth) > 0 {
+		err = checkTriggerAuthExistence(input.Context(), opts.Client(), triggerAuth, opts.namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	opts.patch, err = json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		return errors.Wrap(err, "error encoding patch")
+	}
+	return nil
+}
+
+func (opts *SetSubCommand) run(input cli.Input) error {
+	console.Verbose(2, "patching message queue trigger %v with %s", opts.name, opts.patch)
+	_, err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(opts.namespace).Patch(input.Context(), opts.name, types.MergePatchType, opts.patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error patching message queue trigger")
+	}
+
+	fmt.Printf("message queue trigger '%v' updated\n", opts.name)
+	return nil
+}
+
+// needsTrigger tells whether the flags that are set are checked against the
+// rest of the trigger, see checkSetSpec.
+func needsTrigger(input cli.Input) bool {
+	for _, key := range []string{flagkey.MqtTopic, flagkey.MqtErrorTopic, flagkey.MqtRespTopic, flagkey.MqtMaxInflight,
+		flagkey.MqtPollingInterval, flagkey.MqtCooldownPeriod, flagkey.MqtMinReplicaCount, flagkey.MqtMaxReplicaCount} {
+		if input.IsSet(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSetSpec runs the checks create runs across fields, with the fields
+// that aren't set taken from the trigger.
+func checkSetSpec(input cli.Input, mqt *fv1.MessageQueueTrigger) error {
+	if input.IsSet(flagkey.MqtTopic) || input.IsSet(flagkey.MqtErrorTopic) || input.IsSet(flagkey.MqtRespTopic) {
+		topic := mqt.Spec.Topic
+		if input.IsSet(flagkey.MqtTopic) {
+			var err error
+			topic, err = expandEnv(flagkey.MqtTopic, input.String(flagkey.MqtTopic))
+			if err != nil {
+				return err
+			}
+			err = checkMQTopicAvailability(mqt.Spec.MessageQueueType, mqt.Spec.MqtKind, topic)
+			if err != nil {
+				return err
+			}
+		}
+		errorTopic := mqt.Spec.ErrorTopic
+		if input.IsSet(flagkey.MqtErrorTopic) {
+			errorTopic = input.String(flagkey.MqtErrorTopic)
+		}
+		respTopics := mqt.Spec.GetResponseTopics()
+		if input.IsSet(flagkey.MqtRespTopic) {
+			respTopics = input.StringSlice(flagkey.MqtRespTopic)
+		}
+		err := checkResponseTopics(topic, errorTopic, respTopics)
+		if err != nil {
+			return err
+		}
+	}
+
+	int32Value := func(key string, current *int32) *int32 {
+		if input.IsSet(key) {
+			v := int32(input.Int(key))
+			return &v
+		}
+		return current
+	}
+	pollingInterval := int32Value(flagkey.MqtPollingInterval, mqt.Spec.PollingInterval)
+	cooldownPeriod := int32Value(flagkey.MqtCooldownPeriod, mqt.Spec.CooldownPeriod)
+	if (input.IsSet(flagkey.MqtPollingInterval) || input.IsSet(flagkey.MqtCooldownPeriod)) && pollingInterval != nil && cooldownPeriod != nil {
+		err := checkCooldownPeriod(*cooldownPeriod, *pollingInterval, input.Bool(flagkey.MqtForce), "set")
+		if err != nil {
+			return err
+		}
+	}
+	minReplicaCount := int32Value(flagkey.MqtMinReplicaCount, mqt.Spec.MinReplicaCount)
+	maxReplicaCount := int32Value(flagkey.MqtMaxReplicaCount, mqt.Spec.MaxReplicaCount)
+	if (input.IsSet(flagkey.MqtMinReplicaCount) || input.IsSet(flagkey.MqtMaxReplicaCount)) && minReplicaCount != nil && maxReplicaCount != nil {
+		err := checkReplicaCounts(*minReplicaCount, *maxReplicaCount)
+		if err != nil {
+			return err
+		}
+	}
+	if input.IsSet(flagkey.MqtMaxInflight) && maxReplicaCount != nil {
+		err := checkMaxInflight(input.Int(flagkey.MqtMaxInflight), *maxReplicaCount)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSpecPatch returns the fields of the trigger spec to merge patch, keyed by
+// their JSON names, for the flags that are set. The values are checked the
+// way update checks them; checkSetSpec checks them against the rest of the
+// trigger.
+func setSpecPatch(input cli.Input) (map[string]interface{}, error) {
+	spec := make(map[string]interface{})
+
+	if input.IsSet(flagkey.MqtTopic) {
+		topic, err := expandEnv(flagkey.MqtTopic, input.String(flagkey.MqtTopic))
+		if err != nil {
+			return nil, err
+		}
+		if len(topic) == 0 {
+			return nil, errors.Errorf("topic cannot be empty")
+		}
+		// a null removes the pattern flag, the topic is matched exactly
+		spec["topic"] = topic
+		spec["topicPattern"] = nil
+	}
+	if input.IsSet(flagkey.MqtMaxInflight) {
+		maxInflight := input.Int(flagkey.MqtMaxInflight)
+		err := checkMaxInflight(maxInflight, 0)
+		if err != nil {
+			return nil, err
+		}
+		spec["maxInflight"] = maxInflight
+	}
+
+	if input.IsSet(flagkey.MqtFnName) {
+		spec["functionref"] = map[string]interface{}{"name": input.String(flagkey.MqtFnName)}
+	}
+	if input.IsSet(flagkey.MqtRespTopic) {
+		respTopics := input.StringSlice(flagkey.MqtRespTopic)
+		err := checkResponseTopics("", "", respTopics)
+		if err != nil {
+			return nil, err
+		}
+		// a null removes the field the other topics were set on before
+		respTopic, extraRespTopics := splitResponseTopics(respTopics)
+		if len(extraRespTopics) > 0 {
+			spec["respTopic"] = nil
+			spec["respTopics"] = extraRespTopics
+		} else {
+			spec["respTopic"] = respTopic
+			spec["respTopics"] = nil
+		}
+	}
+	if input.IsSet(flagkey.MqtErrorTopic) {
+		spec["errorTopic"] = input.String(flagkey.MqtErrorTopic)
+	}
+	if input.IsSet(flagkey.MqtErrorFormat) {
+		format, err := checkErrorFormat(input.String(flagkey.MqtErrorFormat))
+		if err != nil {
+			return nil, err
+		}
+		spec["errorFormat"] = format
+	}
+	if input.IsSet(flagkey.MqtMaxRetries) {
+		spec["maxRetries"] = input.Int(flagkey.MqtMaxRetries)
+	}
+	if input.IsSet(flagkey.MqtRetryBackoff) {
+		backoff := input.Duration(flagkey.MqtRetryBackoff)
+		if backoff < 0 || backoff > fv1.MaxRetryBackoff {
+			return nil, errors.Errorf("Retry backoff must be between 0 and %v", fv1.MaxRetryBackoff)
+		}
+		spec["retryBackoff"] = backoff.String()
+	}
+	if input.IsSet(flagkey.MqtMsgContentType) {
+		spec["contentType"] = input.String(flagkey.MqtMsgContentType)
+	}
+	if input.IsSet(flagkey.MqtRespContentType) {
+		spec["respContentType"] = input.String(flagkey.MqtRespContentType)
+	}
+	for key, field := range map[string]string{
+		flagkey.MqtPollingInterval: "pollingInterval",
+		flagkey.MqtCooldownPeriod:  "cooldownPeriod",
+		flagkey.MqtMinReplicaCount: "minReplicaCount",
+		flagkey.MqtMaxReplicaCount: "maxReplicaCount",
+	} {
+		if !input.IsSet(key) {
+			continue
+		}
+		v := int32(input.Int(key))
+		if v < 0 {
+			return nil, errors.Errorf("--%v must be greater than or equal to 0", key)
+		}
+		spec[field] = v
+	}
+	if input.IsSet(flagkey.MqtMetadata) {
+		// a merge patch only touches the given keys of the metadata
+		metadata := make(map[string]interface{})
+		for _, m := range input.StringSlice(flagkey.MqtMetadata) {
+			key, value, ok := strings.Cut(m, "=")
+			if !ok || len(key) == 0 {
+				return nil, errors.Errorf("invalid --%v '%v', expected key=value", flagkey.MqtMetadata, m)
+			}
+			value, err := expandEnv(flagkey.MqtMetadata, value)
+			if err != nil {
+				return nil, err
+			}
+			metadata[key] = value
+		}
+		spec["metadata"] = metadata
+	}
+	// a secret replaces the TriggerAuthentication the trigger had, and the
+	// other way around, a null removes the field
+	secret, triggerAuth := input.String(flagkey.MqtSecret), input.String(flagkey.MqtTriggerAuth)
+	err := checkAuthFlags(secret, triggerAuth)
+	if err != nil {
+		return nil, err
+	}
+	if input.IsSet(flagkey.MqtSecret) {
+		spec["secret"] = secret
+		if len(secret) > 0 {
+			spec["authenticationRef"] = nil
+		}
+	}
+	if input.IsSet(flagkey.MqtTriggerAuth) {
+		spec["authenticationRef"] = triggerAuth
+		if len(triggerAuth) > 0 {
+			spec["secret"] = nil
+		}
+	}
+
+	return spec, nil
+}
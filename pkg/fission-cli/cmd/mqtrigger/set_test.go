@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/dummy"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+func TestSetSpecPatch(t *testing.T) {
+	flags := dummy.TestFlagSet()
+	flags.Set(flagkey.MqtMaxReplicaCount, 20)
+	flags.Set(flagkey.MqtMetadata, []string{"queueLength=10"})
+	spec, err := setSpecPatch(flags)
+	assert.NoError(t, err)
+	// only the flags that are set are patched
+	assert.Equal(t, map[string]interface{}{
+		"maxReplicaCount": int32(20),
+		"metadata":        map[string]interface{}{"queueLength": "10"},
+	}, spec)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtRetryBackoff, time.Hour)
+	_, err = setSpecPatch(flags)
+	assert.Error(t, err)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtRespTopic, []string{"orders-out", "orders-audit"})
+	spec, err = setSpecPatch(flags)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"respTopic":  nil,
+		"respTopics": []string{"orders-out", "orders-audit"},
+	}, spec)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtSecret, "kafka-secrets")
+	spec, err = setSpecPatch(flags)
+	assert.NoError(t, err)
+	// the secret replaces the TriggerAuthentication of the trigger
+	assert.Equal(t, map[string]interface{}{
+		"secret":            "kafka-secrets",
+		"authenticationRef": nil,
+	}, spec)
+
+	flags.Set(flagkey.MqtTriggerAuth, "kafka-auth")
+	_, err = setSpecPatch(flags)
+	assert.Error(t, err)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtTopic, "orders")
+	flags.Set(flagkey.MqtMaxInflight, 50)
+	spec, err = setSpecPatch(flags)
+	assert.NoError(t, err)
+	// the topic replaces the pattern of the trigger
+	assert.Equal(t, map[string]interface{}{
+		"topic":        "orders",
+		"topicPattern": nil,
+		"maxInflight":  50,
+	}, spec)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtMaxInflight, 0)
+	_, err = setSpecPatch(flags)
+	assert.Error(t, err)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtCooldownPeriod, -1)
+	_, err = setSpecPatch(flags)
+	assert.Error(t, err)
+
+	spec, err = setSpecPatch(dummy.TestFlagSet())
+	assert.NoError(t, err)
+	assert.Empty(t, spec)
+}
+
+func TestCheckSetSpec(t *testing.T) {
+	pollingInterval, cooldownPeriod := int32(30), int32(300)
+	minReplicaCount, maxReplicaCount := int32(0), int32(10)
+	mqt := &fv1.MessageQueueTrigger{
+		Spec: fv1.MessageQueueTriggerSpec{
+			MessageQueueType: fv1.MessageQueueTypeKafka,
+			MqtKind:          "keda",
+			Topic:            "orders",
+			ErrorTopic:       "orders-errors",
+			PollingInterval:  &pollingInterval,
+			CooldownPeriod:   &cooldownPeriod,
+			MinReplicaCount:  &minReplicaCount,
+			MaxReplicaCount:  &maxReplicaCount,
+		},
+	}
+
+	// the cooldown period is checked against the polling interval of the trigger
+	flags := dummy.TestFlagSet()
+	flags.Set(flagkey.MqtCooldownPeriod, 10)
+	assert.Error(t, checkSetSpec(flags, mqt))
+	flags.Set(flagkey.MqtForce, true)
+	assert.NoError(t, checkSetSpec(flags, mqt))
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtPollingInterval, 600)
+	assert.Error(t, checkSetSpec(flags, mqt))
+	flags.Set(flagkey.MqtCooldownPeriod, 600)
+	assert.NoError(t, checkSetSpec(flags, mqt))
+
+	// the minimum replica count is checked against the maximum of the trigger
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtMinReplicaCount, 20)
+	assert.Error(t, checkSetSpec(flags, mqt))
+	flags.Set(flagkey.MqtMaxReplicaCount, 20)
+	assert.NoError(t, checkSetSpec(flags, mqt))
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtMaxReplicaCount, 0)
+	assert.NoError(t, checkSetSpec(flags, mqt))
+
+	// the response topics must differ from the topics of the trigger
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.MqtRespTopic, []string{"orders-errors"})
+	assert.Error(t, checkSetSpec(flags, mqt))
+	flags.Set(flagkey.MqtErrorTopic, "orders-dlq")
+	assert.NoError(t, checkSetSpec(flags, mqt))
+	flags.Set(flagkey.MqtTopic, "orders-errors")
+	assert.Error(t, checkSetSpec(flags, mqt))
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/mqtrigger/validator"
+)
+
+const (
+	// messageQueueLabel is the label of the connector deployments naming
+	// the message queue type they serve, or keda for the KEDA connector manager.
+	messageQueueLabel = "messagequeue"
+
+	connectorHealthy     = "healthy"
+	connectorUnhealthy   = "unhealthy"
+	connectorNotDeployed = "not deployed"
+	connectorNoImage     = "no connector image"
+)
+
+type StatusSubCommand struct {
+	cmd.CommandActioner
+	namespace string
+}
+
+// connectorStatus is the availability of the connector of a message queue type.
+type connectorStatus struct {
+	mqType     string
+	mqtKind    string
+	deployment string
+	ready      string
+	status     string
+}
+
+func Status(input cli.Input) error {
+	return (&StatusSubCommand{}).do(input)
+}
+
+func (opts *StatusSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *StatusSubCommand) complete(input cli.Input) (err error) {
+	// like fission check, the namespace is the one fission is installed in
+	opts.namespace, _, err = opts.GetResourceNamespace(input, flagkey.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "error retrieving user provided namespace information")
+	}
+	if len(opts.namespace) == 0 {
+		opts.namespace = util.FISSION_DEFAULT_NAMESPACE
+	}
+	return nil
+}
+
+func (opts *StatusSubCommand) run(input cli.Input) error {
+	kinds := []string{"keda", "fission"}
+	if input.IsSet(flagkey.MqtKind) {
+		kinds = []string{input.String(flagkey.MqtKind)}
+	}
+
+	var statuses []connectorStatus
+	for _, kind := range kinds {
+		s, err := connectorStatuses(input.Context(), opts.Client().KubernetesClient, opts.namespace, kind)
+		if err != nil {
+			return errors.Wrapf(err, "error getting the status of %v connectors in namespace %v", kind, opts.namespace)
+		}
+		statuses = append(statuses, s...)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "TYPE", "KIND", "DEPLOYMENT", "READY", "STATUS")
+	for _, s := range statuses {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", s.mqType, s.mqtKind, s.deployment, s.ready, s.status)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// connectorStatuses reports, for each message queue type of the kind, whether
+// the deployment serving its triggers is present in the namespace and ready.
+// All KEDA types are served by the KEDA connector manager, which must also be
+// configured with a connector image for the type.
+func connectorStatuses(ctx context.Context, client kubernetes.Interface, namespace string, mqtKind string) ([]connectorStatus, error) {
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: messageQueueLabel})
+	if err != nil {
+		return nil, err
+	}
+	byLabel := make(map[string]*appsv1.Deployment, len(deployments.Items))
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		byLabel[d.ObjectMeta.Labels[messageQueueLabel]] = d
+	}
+
+	var statuses []connectorStatus
+	for _, mqType := range validator.MessageQueueTypes(mqtKind) {
+		s := connectorStatus{mqType: mqType, mqtKind: mqtKind, deployment: "-", ready: "-", status: connectorNotDeployed}
+		label := mqType
+		if mqtKind == "keda" {
+			label = "keda"
+		}
+		if d, ok := byLabel[label]; ok {
+			s.deployment = d.ObjectMeta.Name
+			s.ready = fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.Status.Replicas)
+			switch {
+			case d.Status.Replicas == 0 || d.Status.UnavailableReplicas > 0:
+				s.status = connectorUnhealthy
+			case mqtKind == "keda" && !hasConnectorImage(d, mqType):
+				s.status = connectorNoImage
+			default:
+				s.status = connectorHealthy
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// hasConnectorImage returns true if the KEDA connector manager deployment sets
+// the image of the connectors of the message queue type.
+func hasConnectorImage(d *appsv1.Deployment, mqType string) bool {
+	name := strings.ToUpper(mqType + "_image")
+	for _, c := range d.Spec.Template.Spec.Containers {
+		for _, env := range c.Env {
+			// the chart renders image:tag, a leading : means no image
+			if env.Name == name && len(env.Value) > 0 && !strings.HasPrefix(env.Value, ":") {
+				return true
+			}
+		}
+	}
+	return false
+}
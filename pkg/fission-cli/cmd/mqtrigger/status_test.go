@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConnectorStatuses(t *testing.T) {
+	client := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mqtrigger-keda", Namespace: "fission", Labels: map[string]string{messageQueueLabel: "keda"}},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{
+						Name: "mqtrigger-keda",
+						Env: []apiv1.EnvVar{
+							{Name: "KAFKA_IMAGE", Value: "fission/keda-kafka-http-connector:v0.1"},
+							{Name: "REDIS_IMAGE", Value: ":"},
+						},
+					}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1},
+	})
+
+	statuses, err := connectorStatuses(context.Background(), client, "fission", "keda")
+	assert.NoError(t, err)
+	byType := make(map[string]connectorStatus)
+	for _, s := range statuses {
+		byType[s.mqType] = s
+	}
+	assert.Equal(t, connectorHealthy, byType["kafka"].status)
+	assert.Equal(t, "1/1", byType["kafka"].ready)
+	assert.Equal(t, connectorNoImage, byType["redis"].status)
+
+	// nothing is deployed in other namespaces
+	statuses, err = connectorStatuses(context.Background(), client, "other", "keda")
+	assert.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, connectorNotDeployed, s.status)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/mqtrigger/factory"
+	"github.com/fission/fission/pkg/mqtrigger/messageQueue"
+)
+
+// bootstrapServersKey is the metadata key of the kafka brokers of a trigger.
+const bootstrapServersKey = "bootstrapServers"
+
+// kafkaAuthKeys maps the keys of the KEDA secret of a kafka trigger to the
+// ones the kafka connector reads.
+var kafkaAuthKeys = map[string]string{
+	"ca":   "caCert",
+	"cert": "userCert",
+	"key":  "userKey",
+}
+
+type TailErrorsSubCommand struct {
+	cmd.CommandActioner
+}
+
+func TailErrors(input cli.Input) error {
+	return (&TailErrorsSubCommand{}).do(input)
+}
+
+func (opts *TailErrorsSubCommand) do(input cli.Input) error {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
+	if err != nil {
+		return errors.Wrap(err, "error tailing error topic")
+	}
+
+	name := input.String(flagkey.MqtName)
+	mqt, err := opts.Client().FissionClientSet.CoreV1().MessageQueueTriggers(namespace).Get(input.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error getting message queue trigger")
+	}
+	topic := mqt.Spec.ErrorTopic
+	if len(topic) == 0 {
+		return errors.Errorf("message queue trigger '%v' has no error topic", name)
+	}
+
+	brokers := input.String(flagkey.MqtBrokers)
+	if len(brokers) == 0 {
+		brokers = mqt.Spec.Metadata[bootstrapServersKey]
+	}
+	if len(brokers) == 0 {
+		return errors.Errorf("message queue trigger '%v' has no %v metadata, use --%v", name, bootstrapServersKey, flagkey.MqtBrokers)
+	}
+
+	var data map[string][]byte
+	if len(mqt.Spec.Secret) > 0 {
+		secret, err := opts.Client().KubernetesClient.CoreV1().Secrets(namespace).Get(input.Context(), mqt.Spec.Secret, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "error getting secret '%v' of the trigger", mqt.Spec.Secret)
+		}
+		data = secret.Data
+	}
+	mqCfg, err := kafkaConfig(mqt.Spec.Metadata, data)
+	if err != nil {
+		return err
+	}
+	mqCfg.MQType = string(mqt.Spec.MessageQueueType)
+	mqCfg.Url = brokers
+
+	mq, err := factory.Create(zap.NewNop(), mqt.Spec.MessageQueueType, mqCfg, "")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to the brokers %v, they must be reachable from where this command runs", brokers)
+	}
+	tailer, ok := mq.(messageQueue.Tailer)
+	if !ok {
+		return errors.Errorf("message queue type '%v' does not support tailing topics", mqt.Spec.MessageQueueType)
+	}
+
+	ctx, stop := signal.NotifyContext(input.Context(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(os.Stderr, "tailing error topic '%v', press Ctrl-C to stop\n", topic)
+	return tailer.Tail(ctx, topic, func(msg messageQueue.Message) {
+		fmt.Printf("%v %s\n", msg.Timestamp.Format(time.RFC3339), msg.Value)
+	})
+}
+
+// kafkaConfig translates the tls and sasl settings of a kafka trigger, read
+// by KEDA from its secret or metadata, to the config of the kafka connector.
+// SASL is not supported by the connector, so triggers using it are rejected
+// rather than failing to connect.
+func kafkaConfig(metadata map[string]string, secret map[string][]byte) (messageQueue.Config, error) {
+	setting := func(key string) string {
+		if v, ok := secret[key]; ok {
+			return string(v)
+		}
+		return metadata[key]
+	}
+
+	mqCfg := messageQueue.Config{}
+	if sasl := setting("sasl"); len(sasl) > 0 && sasl != "none" {
+		return mqCfg, errors.Errorf("the trigger authenticates with SASL (%v), which tail-errors does not support", sasl)
+	}
+	switch tls := setting("tls"); tls {
+	case "", "disable":
+	case "enable":
+		mqCfg.TLS = true
+		mqCfg.Secrets = make(map[string][]byte)
+		for kedaKey, key := range kafkaAuthKeys {
+			if v, ok := secret[kedaKey]; ok {
+				mqCfg.Secrets[key] = v
+			}
+		}
+	default:
+		return mqCfg, errors.Errorf("invalid tls setting '%v' of the trigger, expected enable or disable", tls)
+	}
+	return mqCfg, nil
+}
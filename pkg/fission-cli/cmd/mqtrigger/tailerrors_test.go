@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fission/fission/pkg/mqtrigger/messageQueue"
+)
+
+func TestKafkaConfig(t *testing.T) {
+	mqCfg, err := kafkaConfig(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, messageQueue.Config{}, mqCfg)
+
+	// the KEDA keys are mapped to the ones of the connector
+	mqCfg, err = kafkaConfig(nil, map[string][]byte{
+		"tls":  []byte("enable"),
+		"ca":   []byte("ca-pem"),
+		"cert": []byte("cert-pem"),
+		"key":  []byte("key-pem"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, messageQueue.Config{
+		TLS: true,
+		Secrets: map[string][]byte{
+			"caCert":   []byte("ca-pem"),
+			"userCert": []byte("cert-pem"),
+			"userKey":  []byte("key-pem"),
+		},
+	}, mqCfg)
+
+	// tls may be set in the metadata instead of the secret
+	mqCfg, err = kafkaConfig(map[string]string{"tls": "enable"}, map[string][]byte{"ca": []byte("ca-pem")})
+	assert.NoError(t, err)
+	assert.True(t, mqCfg.TLS)
+	assert.Equal(t, map[string][]byte{"caCert": []byte("ca-pem")}, mqCfg.Secrets)
+
+	_, err = kafkaConfig(nil, map[string][]byte{"tls": []byte("yes")})
+	assert.Error(t, err)
+
+	_, err = kafkaConfig(nil, map[string][]byte{"sasl": []byte("none")})
+	assert.NoError(t, err)
+
+	_, err = kafkaConfig(map[string]string{"sasl": "plaintext"}, map[string][]byte{
+		"username": []byte("user"),
+		"password": []byte("pass"),
+	})
+	assert.ErrorContains(t, err, "SASL")
+}
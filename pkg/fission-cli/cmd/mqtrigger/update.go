@@ -29,6 +29,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/mqtrigger/validator"
 )
 
 type UpdateSubCommand struct {
@@ -60,7 +61,7 @@ func (opts *UpdateSubCommand) complete(input cli.Input) (err error) {
 	}
 
 	topic := input.String(flagkey.MqtTopic)
-	respTopic := input.String(flagkey.MqtRespTopic)
+	respTopics := input.StringSlice(flagkey.MqtRespTopic)
 	errorTopic := input.String(flagkey.MqtErrorTopic)
 	maxRetries := input.Int(flagkey.MqtMaxRetries)
 	fnName := input.String(flagkey.MqtFnName)
@@ -74,28 +75,75 @@ func (opts *UpdateSubCommand) complete(input cli.Input) (err error) {
 	mqtKind := input.String(flagkey.MqtKind)
 	// TODO : Find out if we can make a call to checkIfFunctionExists, in the same ns more importantly.
 
-	err = checkMQTopicAvailability(mqt.Spec.MessageQueueType, topic, respTopic)
+	err = checkMQTopicAvailability(mqt.Spec.MessageQueueType, mqt.Spec.MqtKind, append([]string{topic}, respTopics...)...)
 	if err != nil {
 		return err
 	}
 
 	updated := false
+	topicPattern := input.String(flagkey.MqtTopicPattern)
+	if len(topicPattern) > 0 {
+		if len(topic) > 0 {
+			return errors.Errorf("--%v and --%v are mutually exclusive", flagkey.MqtTopic, flagkey.MqtTopicPattern)
+		}
+		err = checkTopicPattern(mqt.Spec.MessageQueueType, topicPattern)
+		if err != nil {
+			return err
+		}
+		mqt.Spec.Topic = topicPattern
+		mqt.Spec.TopicPattern = true
+		updated = true
+	}
 	if len(topic) > 0 {
 		mqt.Spec.Topic = topic
+		mqt.Spec.TopicPattern = false
 		updated = true
 	}
-	if len(respTopic) > 0 {
-		mqt.Spec.ResponseTopic = respTopic
+	if len(respTopics) > 0 {
+		mqt.Spec.ResponseTopic, mqt.Spec.ResponseTopics = splitResponseTopics(respTopics)
 		updated = true
 	}
 	if len(errorTopic) > 0 {
 		mqt.Spec.ErrorTopic = errorTopic
 		updated = true
 	}
+	if len(respTopics) > 0 || len(errorTopic) > 0 || len(topic) > 0 {
+		err = checkResponseTopics(mqt.Spec.Topic, mqt.Spec.ErrorTopic, mqt.Spec.GetResponseTopics())
+		if err != nil {
+			return err
+		}
+	}
+	if input.IsSet(flagkey.MqtErrorFormat) {
+		mqt.Spec.ErrorFormat, err = checkErrorFormat(input.String(flagkey.MqtErrorFormat))
+		if err != nil {
+			return err
+		}
+		updated = true
+	}
 	if input.IsSet(flagkey.MqtMaxRetries) {
 		mqt.Spec.MaxRetries = maxRetries
 		updated = true
 	}
+	if input.IsSet(flagkey.MqtRetryBackoff) {
+		backoff := input.Duration(flagkey.MqtRetryBackoff)
+		if backoff < 0 || backoff > fv1.MaxRetryBackoff {
+			return errors.Errorf("Retry backoff must be between 0 and %v", fv1.MaxRetryBackoff)
+		}
+		mqt.Spec.RetryBackoff = backoff.String()
+		updated = true
+	}
+	if input.IsSet(flagkey.MqtMaxInflight) {
+		replicas := maxReplicaCount
+		if !input.IsSet(flagkey.MqtMaxReplicaCount) && mqt.Spec.MaxReplicaCount != nil {
+			replicas = *mqt.Spec.MaxReplicaCount
+		}
+		err = checkMaxInflight(input.Int(flagkey.MqtMaxInflight), replicas)
+		if err != nil {
+			return err
+		}
+		mqt.Spec.MaxInflight = input.Int(flagkey.MqtMaxInflight)
+		updated = true
+	}
 	if len(fnName) > 0 {
 		functionList := []string{fnName}
 		err := util.CheckFunctionExistence(input.Context(), opts.Client(), functionList, namespace)
@@ -110,6 +158,10 @@ func (opts *UpdateSubCommand) complete(input cli.Input) (err error) {
 		mqt.Spec.ContentType = contentType
 		updated = true
 	}
+	if input.IsSet(flagkey.MqtRespContentType) {
+		mqt.Spec.ResponseContentType = input.String(flagkey.MqtRespContentType)
+		updated = true
+	}
 	if input.IsSet(flagkey.MqtPollingInterval) {
 		mqt.Spec.PollingInterval = &pollingInterval
 		updated = true
@@ -129,9 +181,41 @@ func (opts *UpdateSubCommand) complete(input cli.Input) (err error) {
 
 	if input.IsSet(flagkey.MqtMetadata) {
 		updated = updated || util.UpdateMapFromStringSlice(&mqt.Spec.Metadata, metadataParams)
+		err = validator.ValidateMetadata((string)(mqt.Spec.MessageQueueType), mqt.Spec.Metadata)
+		if err != nil {
+			return err
+		}
+	}
+	triggerAuth := input.String(flagkey.MqtTriggerAuth)
+	err = checkAuthFlags(secret, triggerAuth)
+	if err != nil {
+		return err
 	}
+	// a secret replaces the TriggerAuthentication the trigger had, and the
+	// other way around
 	if input.IsSet(flagkey.MqtSecret) {
+		if len(secret) > 0 && input.Bool(flagkey.MqtValidateSecret) {
+			err = checkSecretExistence(input.Context(), opts.Client(), secret, mqt.ObjectMeta.Namespace, mqt.Spec.MessageQueueType, true)
+			if err != nil {
+				return err
+			}
+		}
 		mqt.Spec.Secret = secret
+		if len(secret) > 0 {
+			mqt.Spec.AuthenticationRef = ""
+		}
+		updated = true
+	}
+
+	if input.IsSet(flagkey.MqtTriggerAuth) {
+		if len(triggerAuth) > 0 {
+			err = checkTriggerAuthExistence(input.Context(), opts.Client(), triggerAuth, namespace)
+			if err != nil {
+				return err
+			}
+			mqt.Spec.Secret = ""
+		}
+		mqt.Spec.AuthenticationRef = triggerAuth
 		updated = true
 	}
 
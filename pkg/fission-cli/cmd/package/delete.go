@@ -28,6 +28,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type DeleteSubCommand struct {
@@ -105,7 +106,7 @@ func (opts *DeleteSubCommand) run(input cli.Input) error {
 }
 
 func deleteOrphanPkgs(ctx context.Context, client cmd.Client, pkgNamespace string) error {
-	pkgList, err := client.FissionClientSet.CoreV1().Packages(pkgNamespace).List(ctx, metav1.ListOptions{})
+	pkgList, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().Packages(pkgNamespace).List, func(l *fv1.PackageList) *[]fv1.Package { return &l.Items })
 	if err != nil {
 		return err
 	}
@@ -30,6 +30,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -67,7 +68,8 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 	if input.Bool(flagkey.AllNamespaces) {
 		opts.pkgNamespace = v1.NamespaceAll
 	}
-	pkgList, err := opts.Client().FissionClientSet.CoreV1().Packages(opts.pkgNamespace).List(input.Context(), v1.ListOptions{})
+	// packages are sorted below, so all pages are needed
+	pkgList, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().Packages(opts.pkgNamespace).List, func(l *fv1.PackageList) *[]fv1.Package { return &l.Items })
 
 	if err != nil {
 		return err
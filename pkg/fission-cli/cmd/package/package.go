@@ -27,7 +27,6 @@ import (
 	"github.com/dchest/uniuri"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
@@ -250,7 +249,7 @@ func archiveName(givenNameHint string, includedFiles []string) string {
 }
 
 func GetFunctionsByPackage(ctx context.Context, client cmd.Client, pkgName, pkgNamespace string) ([]fv1.Function, error) {
-	fnList, err := client.FissionClientSet.CoreV1().Functions(pkgNamespace).List(ctx, v1.ListOptions{})
+	fnList, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().Functions(pkgNamespace).List, func(l *fv1.FunctionList) *[]fv1.Function { return &l.Items })
 	if err != nil {
 		return nil, err
 	}
@@ -368,7 +368,7 @@ func applyArchives(input cli.Input, fclient cmd.Client, specDir string, fr *Fiss
 
 	// get list of packages, make content-indexed map of available archives
 	availableArchives := make(map[string]string) // (sha256 -> url)
-	pkgs, err := fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(input.Context(), metav1.ListOptions{})
+	pkgs, err := util.ListAll(input.Context(), fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List, func(l *fv1.PackageList) *[]fv1.Package { return &l.Items })
 	if err != nil {
 		return err
 	}
@@ -648,7 +648,7 @@ func waitForPackageBuild(ctx context.Context, fclient cmd.Client, pkg *fv1.Packa
 
 func applyPackages(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List, func(l *fv1.PackageList) *[]fv1.Package { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -764,7 +764,7 @@ func applyPackages(ctx context.Context, fclient cmd.Client, fr *FissionResources
 
 func applyFunctions(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().Functions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().Functions(metav1.NamespaceAll).List, func(l *fv1.FunctionList) *[]fv1.Function { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -851,7 +851,7 @@ func applyFunctions(ctx context.Context, fclient cmd.Client, fr *FissionResource
 
 func applyEnvironments(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().Environments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().Environments(metav1.NamespaceAll).List, func(l *fv1.EnvironmentList) *[]fv1.Environment { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -938,7 +938,7 @@ func applyEnvironments(ctx context.Context, fclient cmd.Client, fr *FissionResou
 
 func applyHTTPTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1035,7 +1035,7 @@ func applyHTTPTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResou
 
 func applyKubernetesWatchTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().KubernetesWatchTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().KubernetesWatchTriggers(metav1.NamespaceAll).List, func(l *fv1.KubernetesWatchTriggerList) *[]fv1.KubernetesWatchTrigger { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1122,7 +1122,7 @@ func applyKubernetesWatchTriggers(ctx context.Context, fclient cmd.Client, fr *F
 
 func applyTimeTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().TimeTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().TimeTriggers(metav1.NamespaceAll).List, func(l *fv1.TimeTriggerList) *[]fv1.TimeTrigger { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1209,7 +1209,7 @@ func applyTimeTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResou
 
 func applyMessageQueueTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
 	// get list
-	allObjs, err := fclient.FissionClientSet.CoreV1().MessageQueueTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	allObjs, err := util.ListAll(ctx, fclient.FissionClientSet.CoreV1().MessageQueueTriggers(metav1.NamespaceAll).List, func(l *fv1.MessageQueueTriggerList) *[]fv1.MessageQueueTrigger { return &l.Items })
 	if err != nil {
 		return nil, nil, err
 	}
@@ -26,7 +26,8 @@ import (
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
-	"github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type (
@@ -66,7 +67,7 @@ func (w *packageBuildWatcher) watch(ctx context.Context) {
 		}
 
 		// pull list of packages (TODO: convert to watch)
-		pkgs, err := w.fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		pkgs, err := util.ListAll(ctx, w.fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List, func(l *fv1.PackageList) *[]fv1.Package { return &l.Items })
 		if err != nil {
 			fmt.Printf("Getting list of packages: %v", err)
 			os.Exit(1)
@@ -101,7 +102,7 @@ func (w *packageBuildWatcher) watch(ctx context.Context) {
 				pkg.Status.BuildStatus == fv1.BuildStatusSucceeded {
 				w.finished[k] = true
 				fmt.Printf("------\n")
-				util.PrintPackageSummary(os.Stdout, &pkg)
+				pkgutil.PrintPackageSummary(os.Stdout, &pkg)
 				fmt.Printf("------\n")
 			}
 			if pkg.Status.BuildStatus == fv1.BuildStatusFailed {
@@ -381,7 +381,7 @@ func ShowMQTriggers(mqts []fv1.MessageQueueTrigger) {
 		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "FUNCTION_NAME", "MESSAGE_QUEUE_TYPE", "TOPIC", "RESPONSE_TOPIC", "ERROR_TOPIC", "MAX_RETRIES", "PUB_MSG_CONTENT_TYPE")
 		for _, mqt := range mqts {
 			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-				mqt.ObjectMeta.Name, mqt.Spec.FunctionReference.Name, mqt.Spec.MessageQueueType, mqt.Spec.Topic, mqt.Spec.ResponseTopic, mqt.Spec.ErrorTopic, mqt.Spec.MaxRetries, mqt.Spec.ContentType)
+				mqt.ObjectMeta.Name, mqt.Spec.FunctionReference.Name, mqt.Spec.MessageQueueType, mqt.Spec.Topic, strings.Join(mqt.Spec.GetResponseTopics(), ","), mqt.Spec.ErrorTopic, mqt.Spec.MaxRetries, mqt.Spec.ContentType)
 		}
 		fmt.Fprintf(w, "\n")
 		w.Flush()
@@ -422,7 +422,7 @@ func ShowAppliedKubeWatchers(ws []fv1.KubernetesWatchTrigger) {
 
 // getAllFunctions get lists of functions in provided namespaces
 func getAllFunctions(ctx context.Context, client cmd.Client, namespace string) ([]fv1.Function, error) {
-	fns, err := client.FissionClientSet.CoreV1().Functions(namespace).List(ctx, metav1.ListOptions{})
+	fns, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().Functions(namespace).List, func(l *fv1.FunctionList) *[]fv1.Function { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get Functions %v", err.Error())
 	}
@@ -431,7 +431,7 @@ func getAllFunctions(ctx context.Context, client cmd.Client, namespace string) (
 
 // getAllEnvironments get lists of environments in all namespaces
 func getAllEnvironments(ctx context.Context, client cmd.Client, namespace string) ([]fv1.Environment, error) {
-	envs, err := client.FissionClientSet.CoreV1().Environments(namespace).List(ctx, metav1.ListOptions{})
+	envs, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().Environments(namespace).List, func(l *fv1.EnvironmentList) *[]fv1.Environment { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get Environments %v", err.Error())
 	}
@@ -440,7 +440,7 @@ func getAllEnvironments(ctx context.Context, client cmd.Client, namespace string
 
 // getAllPackages get lists of packages in all namespaces
 func getAllPackages(ctx context.Context, client cmd.Client, namespace string) ([]fv1.Package, error) {
-	pkgList, err := client.FissionClientSet.CoreV1().Packages(namespace).List(ctx, metav1.ListOptions{})
+	pkgList, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().Packages(namespace).List, func(l *fv1.PackageList) *[]fv1.Package { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get Packages %v", err.Error())
 	}
@@ -449,7 +449,7 @@ func getAllPackages(ctx context.Context, client cmd.Client, namespace string) ([
 
 // getAllCanaryConfigs get lists of canary configs in all namespaces
 func getAllCanaryConfigs(ctx context.Context, client cmd.Client, namespace string) ([]fv1.CanaryConfig, error) {
-	canaryCfgs, err := client.FissionClientSet.CoreV1().CanaryConfigs(namespace).List(ctx, metav1.ListOptions{})
+	canaryCfgs, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().CanaryConfigs(namespace).List, func(l *fv1.CanaryConfigList) *[]fv1.CanaryConfig { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get Canary Configs %v", err.Error())
 	}
@@ -458,7 +458,7 @@ func getAllCanaryConfigs(ctx context.Context, client cmd.Client, namespace strin
 
 // getAllHTTPTriggers get lists of HTTP Triggers in all namespaces
 func getAllHTTPTriggers(ctx context.Context, client cmd.Client, namespace string) ([]fv1.HTTPTrigger, error) {
-	hts, err := client.FissionClientSet.CoreV1().HTTPTriggers(namespace).List(ctx, metav1.ListOptions{})
+	hts, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().HTTPTriggers(namespace).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get HTTP Triggers %v", err.Error())
 	}
@@ -467,7 +467,7 @@ func getAllHTTPTriggers(ctx context.Context, client cmd.Client, namespace string
 
 // getAllMessageQueueTriggers get lists of MessageQueue Triggers in all namespaces
 func getAllMessageQueueTriggers(ctx context.Context, client cmd.Client, mqttype string, namespace string) ([]fv1.MessageQueueTrigger, error) {
-	mqts, err := client.FissionClientSet.CoreV1().MessageQueueTriggers(namespace).List(ctx, metav1.ListOptions{})
+	mqts, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().MessageQueueTriggers(namespace).List, func(l *fv1.MessageQueueTriggerList) *[]fv1.MessageQueueTrigger { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get MessageQueue Triggers %v", err.Error())
 	}
@@ -476,7 +476,7 @@ func getAllMessageQueueTriggers(ctx context.Context, client cmd.Client, mqttype
 
 // getAllTimeTriggers get lists of Time Triggers in all namespaces
 func getAllTimeTriggers(ctx context.Context, client cmd.Client, namespace string) ([]fv1.TimeTrigger, error) {
-	tts, err := client.FissionClientSet.CoreV1().TimeTriggers(namespace).List(ctx, metav1.ListOptions{})
+	tts, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().TimeTriggers(namespace).List, func(l *fv1.TimeTriggerList) *[]fv1.TimeTrigger { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get Time Triggers %v", err.Error())
 	}
@@ -485,7 +485,7 @@ func getAllTimeTriggers(ctx context.Context, client cmd.Client, namespace string
 
 // getAllKubeWatchTriggers get lists of Kube Watchers in all namespaces
 func getAllKubeWatchTriggers(ctx context.Context, client cmd.Client, namespace string) ([]fv1.KubernetesWatchTrigger, error) {
-	ws, err := client.FissionClientSet.CoreV1().KubernetesWatchTriggers(namespace).List(ctx, metav1.ListOptions{})
+	ws, err := util.ListAll(ctx, client.FissionClientSet.CoreV1().KubernetesWatchTriggers(namespace).List, func(l *fv1.KubernetesWatchTriggerList) *[]fv1.KubernetesWatchTrigger { return &l.Items })
 	if err != nil {
 		return nil, errors.Errorf("Unable to get Kube Watchers %v", err.Error())
 	}
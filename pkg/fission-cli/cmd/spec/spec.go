@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	multierror "github.com/hashicorp/go-multierror"
@@ -176,8 +177,11 @@ func save(data []byte, specDir string, specFile string, truncate bool) error {
 
 // called from `fission * create --spec`
 func SpecSave(resource interface{}, specFile string, update bool) error {
-	var specDir = "specs"
+	return SpecSaveTo(resource, "specs", specFile, update)
+}
 
+// SpecSaveTo is like SpecSave, but saves the resource to specDir.
+func SpecSaveTo(resource interface{}, specDir string, specFile string, update bool) error {
 	meta, kind, data, err := crdToYaml(resource)
 	if err != nil {
 		return err
@@ -291,25 +295,35 @@ func crdToYaml(resource interface{}) (metav1.ObjectMeta, string, []byte, error)
 
 // validateFunctionReference checks a function reference
 func (fr *FissionResources) validateFunctionReference(functions map[string]bool, kind string, meta *metav1.ObjectMeta, funcRef fv1.FunctionReference) error {
-	if funcRef.Type == fv1.FunctionReferenceTypeFunctionName {
+	var names []string
+	switch funcRef.Type {
+	case fv1.FunctionReferenceTypeFunctionName:
+		names = []string{funcRef.Name}
+	case fv1.FunctionReferenceTypeFunctionWeights:
+		for name := range funcRef.FunctionWeights {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	var result *multierror.Error
+	for _, name := range names {
 		// triggers only reference functions in their own namespace
-		namespace := meta.Namespace
-		name := funcRef.Name
 		m := &metav1.ObjectMeta{
-			Namespace: namespace,
+			Namespace: meta.Namespace,
 			Name:      name,
 		}
 		if _, ok := functions[MapKey(m)]; !ok {
-			return fmt.Errorf("%v: %v '%v' references unknown function '%v'",
+			result = multierror.Append(result, fmt.Errorf("%v: %v '%v' references unknown function '%v'",
 				fr.SourceMap.Locations[kind][meta.Namespace][meta.Name],
 				kind,
 				meta.Name,
-				name)
+				name))
 		} else {
 			functions[MapKey(m)] = true
 		}
 	}
-	return nil
+	return result.ErrorOrNil()
 }
 
 // Validate validates the spec file for irregular references
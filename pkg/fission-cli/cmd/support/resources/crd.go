@@ -25,6 +25,7 @@ import (
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 const (
@@ -50,110 +51,84 @@ func NewCrdDumper(client cmd.Client, crdType string) Resource {
 }
 
 func (res CrdDumper) Dump(ctx context.Context, dumpDir string) {
+	// the resources are written page by page, so that a large cluster is
+	// never held in memory at once
+	var err error
+	client := res.client.FissionClientSet.CoreV1()
 
 	switch res.crdType {
 	case CrdEnvironment:
-		items, err := res.client.FissionClientSet.CoreV1().Environments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.Environments(metav1.NamespaceAll).List, func(page *fv1.EnvironmentList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdFunction:
-		items, err := res.client.FissionClientSet.CoreV1().Functions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.Functions(metav1.NamespaceAll).List, func(page *fv1.FunctionList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdPackage:
-		items, err := res.client.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			item = pkgClean(item)
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.Packages(metav1.NamespaceAll).List, func(page *fv1.PackageList) error {
+			for _, item := range page.Items {
+				item = pkgClean(item)
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdHttpTrigger:
-		items, err := res.client.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.HTTPTriggers(metav1.NamespaceAll).List, func(page *fv1.HTTPTriggerList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdKubeWatcher:
-		items, err := res.client.FissionClientSet.CoreV1().KubernetesWatchTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.KubernetesWatchTriggers(metav1.NamespaceAll).List, func(page *fv1.KubernetesWatchTriggerList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdMessageQueueTrigger:
-		var triggers []fv1.MessageQueueTrigger
-
-		l, err := res.client.FissionClientSet.CoreV1().MessageQueueTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			break
-		}
-		triggers = append(triggers, l.Items...)
-
-		for _, item := range triggers {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.MessageQueueTriggers(metav1.NamespaceAll).List, func(page *fv1.MessageQueueTriggerList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdTimeTrigger:
-		items, err := res.client.FissionClientSet.CoreV1().TimeTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.TimeTriggers(metav1.NamespaceAll).List, func(page *fv1.TimeTriggerList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	case CrdCanaryConfig:
-		items, err := res.client.FissionClientSet.CoreV1().CanaryConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
-			return
-		}
-
-		for _, item := range items.Items {
-			f := getFileName(dumpDir, item.ObjectMeta)
-			writeToFile(f, item)
-		}
+		err = util.EachPage(ctx, client.CanaryConfigs(metav1.NamespaceAll).List, func(page *fv1.CanaryConfigList) error {
+			for _, item := range page.Items {
+				writeToFile(getFileName(dumpDir, item.ObjectMeta), item)
+			}
+			return nil
+		})
 
 	default:
 		console.Warn(fmt.Sprintf("Unknown type: %v", res.crdType))
+		return
+	}
+
+	if err != nil {
+		console.Warn(fmt.Sprintf("Error getting %v list: %v", res.crdType, err))
 	}
 }
 
@@ -24,9 +24,11 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -46,7 +48,7 @@ func (opts *ListSubCommand) do(input cli.Input) (err error) {
 	if input.Bool(flagkey.AllNamespaces) {
 		ttNs = metav1.NamespaceAll
 	}
-	tts, err := opts.Client().FissionClientSet.CoreV1().TimeTriggers(ttNs).List(input.Context(), metav1.ListOptions{})
+	tts, err := util.ListAll(input.Context(), opts.Client().FissionClientSet.CoreV1().TimeTriggers(ttNs).List, func(l *fv1.TimeTriggerList) *[]fv1.TimeTrigger { return &l.Items })
 
 	if err != nil {
 		return errors.Wrap(err, "list Time triggers")
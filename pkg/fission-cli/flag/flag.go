@@ -75,7 +75,13 @@ var (
 
 	KubeContext = Flag{Type: String, Name: flagkey.KubeContext, Usage: "Kubernetes context to be used for the execution of Fission commands", DefaultValue: ""}
 
+	ShowNamespace = Flag{Type: Bool, Name: flagkey.ShowNamespace, Usage: "Print the namespace resolved for the request and where it came from (flag, environment or kubeconfig)", DefaultValue: false}
+
+	OutputFormat = Flag{Type: String, Name: flagkey.OutputFormat, Short: "o", Usage: "Output format, one of: json, yaml. Human-readable output is printed if unspecified"}
+
 	IgnoreNotFound = Flag{Type: Bool, Name: flagkey.IgnoreNotFound, Usage: "Treat \"resource not found\" as a successful delete.", DefaultValue: false}
+	IfNotExists    = Flag{Type: Bool, Name: flagkey.IfNotExists, Usage: "Treat \"resource already exists\" as a successful create and keep the existing resource.", DefaultValue: false}
+	Yes            = Flag{Type: Bool, Name: flagkey.Yes, Usage: "Skip the confirmation prompt and proceed", DefaultValue: false}
 
 	Labels     = Flag{Type: String, Name: flagkey.Labels, Usage: "Comma separated labels to apply to the function. E.g. --labels=\"environment=dev,application=analytics\""}
 	Annotation = Flag{Type: StringSlice, Name: flagkey.Annotation, Usage: "Annotation to apply to the function. To mention multiple annotations --annotation=\"abc.com/team=dev\" --annotation=\"foo=bar\""}
@@ -131,6 +137,9 @@ var (
 	FnOnceOnly              = Flag{Type: Bool, Name: flagkey.FnOnceOnly, Aliases: []string{"yolo"}, Usage: "Specifies if specialized pod will serve exactly one request in its lifetime"}
 	FnSubPath               = Flag{Type: String, Name: flagkey.FnSubPath, Usage: "Sub Path to check if function internally supports routing"}
 	FnLogAllPods            = Flag{Type: Bool, Name: flagkey.FnLogAllPods, Usage: "Get all pod's logs in the function."}
+	FnApply                 = Flag{Type: Bool, Name: flagkey.FnApply, Usage: "Use server-side apply, the CLI then only owns the fields it sets"}
+	FnFieldManager          = Flag{Type: String, Name: flagkey.FnFieldManager, Usage: "Field manager owning the fields set with --apply, use the same name everywhere the function is applied from to avoid conflicts", DefaultValue: "fission-cli"}
+	FnForceConflicts        = Flag{Type: Bool, Name: flagkey.FnForceConflicts, Usage: "Take over the fields set with --apply even if another field manager owns them"}
 	FnRetainPods            = Flag{Type: Int, Name: flagkey.FnRetainPods, Usage: "Number of pods to retain after pods specialization.", DefaultValue: 0}
 	// Termination Grace Period configurable at function creation/update only for container functions
 	FnTerminationGracePeriod = Flag{Type: Int64, Name: flagkey.FnGracePeriod, Usage: "Grace time (in seconds) for pod to perform connection draining before termination (only non-negative values considered)", DefaultValue: 360}
@@ -148,6 +157,9 @@ var (
 	HtFnFilter          = Flag{Type: String, Name: flagkey.HtFilter, Usage: "Name of the function for trigger(s)"}
 	HtPrefix            = Flag{Type: String, Name: flagkey.HtPrefix, Usage: "Prefix with which functions are exposed. NOTE: Prefix takes precedence over URL/RelativeURL [DEPRECATED for 'fn create', use 'route create' instead]"}
 	HtKeepPrefix        = Flag{Type: Bool, Name: flagkey.HtKeepPrefix, Usage: "Keep the prefix in the URL while forwarding request to the function"}
+	HtTimeout           = Flag{Type: Int, Name: flagkey.HtTimeout, Usage: "Seconds the router waits for the function to respond to requests of the trigger (function timeout if unspecified)"}
+	HtSkipUnhealthy     = Flag{Type: Bool, Name: flagkey.HtSkipUnhealthy, Usage: "Resolve like a router with ROUTER_SKIP_UNHEALTHY_FUNCTIONS set, which gives functions annotated as unhealthy no weight"}
+	HtCoalesceWeights   = Flag{Type: Bool, Name: flagkey.HtCoalesceWeights, Usage: "Resolve like a router with ROUTER_COALESCE_FUNCTION_WEIGHTS set, which merges the function weights of triggers sharing a route"}
 
 	TokUsername = Flag{Type: String, Name: flagkey.TokUsername, Usage: "Username to generate token for function invocation"}
 	TokPassword = Flag{Type: String, Name: flagkey.TokPassword, Usage: "Password to generate token for function invocation"}
@@ -162,17 +174,29 @@ var (
 	MqtFnName          = Flag{Type: String, Name: flagkey.MqtFnName, Usage: "Function name"}
 	MqtMQType          = Flag{Type: String, Name: flagkey.MqtMQType, Usage: "For mqtype \"fission\" => kafka\n\t\t\t\t\t For mqtype \"keda\" => kafka, aws-sqs-queue, aws-kinesis-stream, gcp-pubsub, stan, nats-jetstream, rabbitmq, redis", DefaultValue: "kafka"}
 	MqtTopic           = Flag{Type: String, Name: flagkey.MqtTopic, Usage: "Message queue Topic the trigger listens on"}
-	MqtRespTopic       = Flag{Type: String, Name: flagkey.MqtRespTopic, Usage: "Topic that the function response is sent on (response discarded if unspecified)"}
+	MqtTopicPattern    = Flag{Type: String, Name: flagkey.MqtTopicPattern, Usage: "Regular expression of the topics the trigger listens on instead of --topic, only supported by kafka"}
+	MqtRespTopic       = Flag{Type: StringSlice, Name: flagkey.MqtRespTopic, Usage: "Topic that the function response is sent on (response discarded if unspecified), repeat to send it on several topics"}
 	MqtErrorTopic      = Flag{Type: String, Name: flagkey.MqtErrorTopic, Usage: "Topic that the function error messages are sent to (errors discarded if unspecified"}
+	MqtErrorFormat     = Flag{Type: String, Name: flagkey.MqtErrorFormat, Usage: "Format of the messages sent to the error topic, 'raw' (the error only) or 'envelope' (JSON with the original message, function, error, retry count and time)", DefaultValue: string(fv1.MessageQueueErrorFormatRaw)}
 	MqtMaxRetries      = Flag{Type: Int, Name: flagkey.MqtMaxRetries, Usage: "Maximum number of times the function will be retried upon failure", DefaultValue: 0}
-	MqtMsgContentType  = Flag{Type: String, Name: flagkey.MqtMsgContentType, Short: "c", Usage: "Content type of messages that publish to the topic", DefaultValue: "application/json"}
+	MqtRetryBackoff    = Flag{Type: Duration, Name: flagkey.MqtRetryBackoff, Usage: "Delay before the first retry of a failed function invocation, doubled on every further retry, e.g. 500ms, 2s (no delay if unspecified)"}
+	MqtMaxInflight     = Flag{Type: Int, Name: flagkey.MqtMaxInflight, Usage: "Maximum number of messages a consumer fetches ahead of processing them, lower it for slow functions (consumer default if unspecified)"}
+	MqtMsgContentType  = Flag{Type: String, Name: flagkey.MqtMsgContentType, Short: "c", Usage: "Content type of messages that publish to the topic, FISSION_MQT_DEFAULT_CONTENT_TYPE overrides the default", DefaultValue: "application/json"}
+	MqtRespContentType = Flag{Type: String, Name: flagkey.MqtRespContentType, Usage: "Content type of the function response published to the response topic (same as --contenttype if unspecified)"}
 	MqtPollingInterval = Flag{Type: Int, Name: flagkey.MqtPollingInterval, Usage: "Interval to check the message source for up/down scaling operation of consumers", DefaultValue: 30}
 	MqtCooldownPeriod  = Flag{Type: Int, Name: flagkey.MqtCooldownPeriod, Usage: "The period to wait after the last trigger reported active before scaling the consumer back to 0", DefaultValue: 300}
 	MqtMinReplicaCount = Flag{Type: Int, Name: flagkey.MqtMinReplicaCount, Usage: "Minimum number of replicas of consumers to scale down to", DefaultValue: 0}
 	MqtMaxReplicaCount = Flag{Type: Int, Name: flagkey.MqtMaxReplicaCount, Usage: "Maximum number of replicas of consumers to scale up to", DefaultValue: 100}
-	MqtMetadata        = Flag{Type: StringSlice, Name: flagkey.MqtMetadata, Usage: "Metadata needed for connecting to source system in format: --metadata key1=value1 --metadata key2=value2"}
+	MqtMetadata        = Flag{Type: StringSlice, Name: flagkey.MqtMetadata, Usage: "Metadata needed for connecting to source system in format: --metadata key1=value1 --metadata key2=value2, values may reference environment variables as ${VAR} or ${VAR:-default}"}
+	MqtLabel           = Flag{Type: StringSlice, Name: flagkey.MqtLabel, Usage: "Label to apply to the trigger and the resources created for it in format: --label key1=value1 --label key2=value2"}
 	MqtSecret          = Flag{Type: String, Name: flagkey.MqtSecret, Usage: "Name of secret object", DefaultValue: ""}
+	MqtValidateSecret  = Flag{Type: Bool, Name: flagkey.MqtValidateSecret, Usage: "Check that the --secret has the keys the scaler of the message queue type needs, e.g. awsRoleArn or awsAccessKeyID and awsSecretAccessKey for aws-sqs-queue"}
+	MqtTriggerAuth     = Flag{Type: String, Name: flagkey.MqtTriggerAuth, Usage: "Name of an existing KEDA TriggerAuthentication in the function namespace, instead of --secret"}
 	MqtKind            = Flag{Type: String, Name: flagkey.MqtKind, Usage: "Kind of Message Queue Trigger, e.g. fission, keda", DefaultValue: "keda"}
+	MqtDiff            = Flag{Type: Bool, Name: flagkey.MqtDiff, Usage: "Show the difference between the trigger spec and the one in the cluster instead of creating it"}
+	MqtFromFile        = Flag{Type: String, Name: flagkey.MqtFromFile, Usage: "Create the triggers defined in a JSON file, an array of objects keyed by the flag names of this command, e.g. [{\"name\": \"t1\", \"function\": \"f\", \"topic\": \"in\"}]"}
+	MqtForce           = Flag{Type: Bool, Name: flagkey.MqtForce, Usage: "Create or set the trigger even if the cooldown period is shorter than the polling interval"}
+	MqtBrokers         = Flag{Type: String, Name: flagkey.MqtBrokers, Usage: "Comma separated broker addresses to connect to, the bootstrapServers metadata of the trigger if unspecified"}
 
 	EnvName                   = Flag{Type: String, Name: flagkey.EnvName, Usage: "Environment name"}
 	EnvPoolsize               = Flag{Type: Int, Name: flagkey.EnvPoolsize, Usage: "Size of the pool", DefaultValue: 3}
@@ -189,11 +213,38 @@ var (
 	EnvBuilder                = Flag{Type: StringSlice, Name: flagkey.EnvBuilder, Usage: "Environment variable to be set in the builder container"}
 	EnvRuntime                = Flag{Type: StringSlice, Name: flagkey.EnvRuntime, Usage: "Environment variable to be set in the runtime container"}
 
-	KwName      = Flag{Type: String, Name: flagkey.KwName, Usage: "Watch name"}
-	KwFnName    = Flag{Type: String, Name: flagkey.KwFnName, Usage: "Function name"}
-	KwNamespace = Flag{Type: String, Name: flagkey.KwNamespace, Aliases: []string{"ns"}, Usage: "Namespace of resource to watch"}
-	KwObjType   = Flag{Type: String, Name: flagkey.KwObjType, Usage: "Type of resource to watch (Pod, Service, etc.)", DefaultValue: "pod"}
-	KwLabels    = Flag{Type: String, Name: flagkey.KwLabels, Usage: "Label selector of the form a=b,c=d"}
+	KwName           = Flag{Type: String, Name: flagkey.KwName, Usage: "Watch name"}
+	KwFnName         = Flag{Type: String, Name: flagkey.KwFnName, Usage: "Function name"}
+	KwNamespace      = Flag{Type: String, Name: flagkey.KwNamespace, Aliases: []string{"ns"}, Usage: "Namespace of resource to watch"}
+	KwObjType        = Flag{Type: String, Name: flagkey.KwObjType, Usage: "Type of resource to watch (Pod, Service, ReplicationController, Job, CronJob, Event, Endpoints), a comma separated list watches several types", DefaultValue: "pod"}
+	KwLabels         = Flag{Type: String, Name: flagkey.KwLabels, Usage: "Label selector of the form a=b,c=d"}
+	KwFilter         = Flag{Type: String, Name: flagkey.KwFilter, Usage: "JSONPath template evaluated against the event object, e.g. '{.status.phase}'; events with an empty result are skipped"}
+	KwGzip           = Flag{Type: Bool, Name: flagkey.KwGzip, Usage: "Gzip the event bodies sent to the function"}
+	KwGzipMin        = Flag{Type: Int, Name: flagkey.KwGzipMin, Usage: "Minimum event body size in bytes to gzip, 1024 if unset"}
+	KwAllEvents      = Flag{Type: Bool, Name: flagkey.KwAllEvents, Usage: "Also deliver Normal events when watching Events, by default only the other types, e.g. Warning, are delivered"}
+	KwEventKind      = Flag{Type: String, Name: flagkey.KwEventKind, Usage: "Only deliver the Events about objects of this kind, e.g. Pod, when watching Events"}
+	KwEventReason    = Flag{Type: String, Name: flagkey.KwEventReason, Usage: "Only deliver the Events with this reason, e.g. FailedScheduling, when watching Events"}
+	KwDryRun         = Flag{Type: Bool, Name: flagkey.KwDryRun, Usage: "Log the events that would be sent to the function instead of invoking it"}
+	KwFilterVal      = Flag{Type: String, Name: flagkey.KwFilterVal, Usage: "Only invoke the function when --filter yields this value"}
+	KwSink           = Flag{Type: String, Name: flagkey.KwSink, Usage: "Where to deliver events, one of 'function' or 'mqtopic' (the message queue configured for kubewatcher)", DefaultValue: string(fv1.KubernetesWatchSinkFunction)}
+	KwOwnedJobs      = Flag{Type: Bool, Name: flagkey.KwOwnedJobs, Usage: "When watching CronJobs, also deliver the events of the Jobs they spawn, with the X-Kubernetes-Owner-CronJob header naming their CronJob"}
+	KwOldObject      = Flag{Type: Bool, Name: flagkey.KwOldObject, Usage: "Send the previous state of the object in the X-Kubernetes-Old-Object header of Modified events"}
+	KwPath           = Flag{Type: String, Name: flagkey.KwPath, Usage: "Path appended to the function URL, e.g. /events/pod, for functions that serve several watches"}
+	KwAllNamespaces  = Flag{Type: Bool, Name: flagkey.KwAllNamespaces, Usage: "Watch resources in all namespaces instead of the trigger namespace, requires cluster wide watch permissions for the kubewatcher"}
+	KwPrune          = Flag{Type: StringSlice, Name: flagkey.KwPrune, Usage: "Dot separated path of a field removed from the object before it is sent, e.g. metadata.managedFields or status. Use multiple --prune flags for several fields"}
+	KwMaxEventAge    = Flag{Type: Duration, Name: flagkey.KwMaxEventAge, Usage: "Skip Added events of objects created longer ago than this, e.g. 5m. Modified and Deleted events are always sent"}
+	KwPublishTimeout = Flag{Type: Duration, Name: flagkey.KwPublishTimeout, Usage: "How long the function may take to respond to an event, e.g. 500ms or 10m (kubewatcher default if unspecified)"}
+	KwPublishMethod  = Flag{Type: String, Name: flagkey.KwPublishMethod, Usage: "HTTP method of the requests sending events to the function, one of POST, PUT or PATCH", DefaultValue: http.MethodPost}
+	KwTransform      = Flag{Type: StringSlice, Name: flagkey.KwTransform, Usage: "Field of the body sent to the function and the JSONPath template filling it, e.g. phase={.status.phase}. Use multiple --transform flags for several fields"}
+	KwSince          = Flag{Type: String, Name: flagkey.KwSince, Usage: "Where the watch starts: 'now' for only the changes from now on, or a resource version to replay the changes made since (the current state of all objects if unspecified)"}
+	KwHeader         = Flag{Type: StringSlice, Name: flagkey.KwHeader, Usage: "Header added to the requests sending events to the function, e.g. X-Source=kubewatcher. Use multiple --header flags for several headers"}
+	KwHeaderSecret   = Flag{Type: StringSlice, Name: flagkey.KwHeaderSecret, Usage: "Header taking its value from a key of a secret in the trigger namespace, e.g. X-Token=mysecret:token. Use multiple --headerfromsecret flags for several headers"}
+	KwBreakerFails   = Flag{Type: Int, Name: flagkey.KwBreakerFails, Usage: "Consecutive failed deliveries after which events are dropped for --circuitbreakercooldown, 0 to never stop sending them"}
+	KwBreakerCool    = Flag{Type: Duration, Name: flagkey.KwBreakerCool, Usage: "How long events are dropped once --circuitbreakerthreshold is reached, e.g. 1m (30s if unspecified)"}
+	KwSkipReplay     = Flag{Type: Bool, Name: flagkey.KwSkipReplay, Usage: "After a watch error, resume from the current state instead of replaying all existing objects, at the risk of missing the changes made meanwhile"}
+	KwConcurrency    = Flag{Type: String, Name: flagkey.KwConcurrency, Usage: "How events are delivered, one of 'Serial' (one at a time, in order) or 'Parallel' (concurrently, possibly out of order)", DefaultValue: string(fv1.DefaultKubernetesWatchConcurrencyPolicy)}
+	KwLogLevel       = Flag{Type: String, Name: flagkey.KwLogLevel, Usage: "Log level of the watch in the kubewatcher, one of 'debug', 'info', 'warn' or 'error', defaults to the level of the kubewatcher"}
+	KwTopic          = Flag{Type: String, Name: flagkey.KwTopic, Usage: "Message queue topic to publish events to, required with --sink mqtopic"}
 
 	PkgName           = Flag{Type: String, Name: flagkey.PkgName, Usage: "Package name"}
 	PkgForce          = Flag{Type: Bool, Name: flagkey.PkgForce, Short: "f", Usage: "Force update a package even if it is used by one or more functions"}
@@ -229,9 +280,16 @@ var (
 	CanaryTriggerName       = Flag{Type: String, Name: flagkey.CanaryHTTPTriggerName, Usage: "Http trigger that this config references"}
 	CanaryNewFunc           = Flag{Type: String, Name: flagkey.CanaryNewFunc, Aliases: []string{"newfn"}, Usage: "New version of the function"}
 	CanaryOldFunc           = Flag{Type: String, Name: flagkey.CanaryOldFunc, Aliases: []string{"oldfn"}, Usage: "Old stable version of the function"}
+	CanaryOtherFuncs        = Flag{Type: StringSlice, Name: flagkey.CanaryOtherFuncs, Aliases: []string{"otherfn"}, Usage: "Other stable version of the function sharing traffic with the old one, their weights are decreased proportionally. To mention multiple functions --otherfunction fn-v2 --otherfunction fn-v3"}
 	CanaryWeightIncrement   = Flag{Type: Int, Name: flagkey.CanaryWeightIncrement, Aliases: []string{"step"}, Usage: "Weight increment step for function", DefaultValue: 20}
 	CanaryIncrementInterval = Flag{Type: String, Name: flagkey.CanaryIncrementInterval, Aliases: []string{"internal"}, Usage: "Weight increment interval, string representation of time.Duration, ex : 1m, 2h, 2d", DefaultValue: "2m"}
 	CanaryFailureThreshold  = Flag{Type: Int, Name: flagkey.CanaryFailureThreshold, Aliases: []string{"threshold"}, Usage: "Threshold in percentage beyond which the new version of the function is considered unstable", DefaultValue: 10}
+	CanaryWait              = Flag{Type: Bool, Name: flagkey.CanaryWait, Usage: "Wait until the function weights of the referenced HTTP trigger are finalized"}
+	CanaryWaitTimeout       = Flag{Type: Duration, Name: flagkey.CanaryWaitTimeout, Usage: "Length of time to wait for the function weights to be finalized, used with --wait", DefaultValue: 60 * time.Second}
+	CanaryCloneSource       = Flag{Type: String, Name: flagkey.CanaryCloneSource, Usage: "Name of the canary config to clone"}
+	CanaryCloneDest         = Flag{Type: String, Name: flagkey.CanaryCloneDest, Usage: "Name of the new canary config"}
+	CanaryCloneFunc         = Flag{Type: String, Name: flagkey.CanaryCloneFunc, Usage: "New version of the function the cloned canary config rolls out"}
+	CanaryRollback          = Flag{Type: Bool, Name: flagkey.CanaryRollback, Usage: "If the rollout is still in progress, move the weight of the new function back to the old ones"}
 
 	ArchiveName   = Flag{Type: String, Name: flagkey.ArchiveName, Usage: "Name of the archive file"}
 	ArchiveID     = Flag{Type: String, Name: flagkey.ArchiveID, Usage: "Id for the archive file"}
@@ -17,21 +17,25 @@ limitations under the License.
 package flagkey
 
 const (
-	Verbosity   = "verbosity"
-	Server      = "server"
-	ClientOnly  = "client-only"
-	KubeContext = "kube-context"
+	Verbosity     = "verbosity"
+	Server        = "server"
+	ClientOnly    = "client-only"
+	KubeContext   = "kube-context"
+	ShowNamespace = "show-namespace"
 
 	PreCheckOnly = "pre"
 
 	resourceName = "name"
 	force        = "force"
 	Output       = "output"
+	OutputFormat = Output
 
 	Labels     = "labels"
 	Annotation = "annotation"
 
 	IgnoreNotFound = "ignorenotfound"
+	IfNotExists    = "if-not-exists"
+	Yes            = "yes"
 
 	NamespaceFunction    = "fnNamespace"
 	NamespaceEnvironment = "envNamespace"
@@ -87,6 +91,9 @@ const (
 	FnGracePeriod           = "graceperiod"
 	FnLogAllPods            = "all-pods"
 	FnRetainPods            = "retainpods"
+	FnApply                 = "apply"
+	FnFieldManager          = "field-manager"
+	FnForceConflicts        = "force-conflicts"
 
 	HtName              = resourceName
 	HtMethod            = "method"
@@ -101,6 +108,9 @@ const (
 	HtFilter            = HtFnName
 	HtPrefix            = "prefix"
 	HtKeepPrefix        = "keepprefix"
+	HtTimeout           = "timeout"
+	HtSkipUnhealthy     = "skip-unhealthy"
+	HtCoalesceWeights   = "coalesce-weights"
 
 	TokUsername = "username"
 	TokPassword = "password"
@@ -115,8 +125,10 @@ const (
 	MqtFnName          = "function"
 	MqtMQType          = "mqtype"
 	MqtTopic           = "topic"
+	MqtTopicPattern    = "topicpattern"
 	MqtRespTopic       = "resptopic"
 	MqtErrorTopic      = "errortopic"
+	MqtErrorFormat     = "errorformat"
 	MqtMaxRetries      = "maxretries"
 	MqtMsgContentType  = "contenttype"
 	MqtPollingInterval = "pollinginterval"
@@ -125,7 +137,17 @@ const (
 	MqtMaxReplicaCount = "maxreplicacount"
 	MqtMetadata        = "metadata"
 	MqtSecret          = "secret"
+	MqtValidateSecret  = "validate-secret"
+	MqtTriggerAuth     = "triggerauth"
 	MqtKind            = "mqtkind"
+	MqtDiff            = "diff"
+	MqtRetryBackoff    = "retrybackoff"
+	MqtMaxInflight     = "maxinflight"
+	MqtRespContentType = "respcontenttype"
+	MqtLabel           = "label"
+	MqtForce           = force
+	MqtFromFile        = "from-file"
+	MqtBrokers         = "brokers"
 
 	EnvName            = resourceName
 	EnvPoolsize        = "poolsize"
@@ -142,11 +164,38 @@ const (
 	EnvBuilder         = "builder-env"
 	EnvRuntime         = "runtime-env"
 
-	KwName      = resourceName
-	KwFnName    = "function"
-	KwNamespace = "namespace"
-	KwObjType   = "type"
-	KwLabels    = "labels"
+	KwName           = resourceName
+	KwFnName         = "function"
+	KwNamespace      = "namespace"
+	KwObjType        = "type"
+	KwLabels         = "labels"
+	KwFilter         = "filter"
+	KwFilterVal      = "filtervalue"
+	KwGzip           = "gzip"
+	KwGzipMin        = "gzipminsize"
+	KwDryRun         = "dryrun"
+	KwAllEvents      = "allevents"
+	KwEventKind      = "eventkind"
+	KwEventReason    = "eventreason"
+	KwSink           = "sink"
+	KwOldObject      = "includeoldobject"
+	KwOwnedJobs      = "includeownedjobs"
+	KwTopic          = "topic"
+	KwPath           = "path"
+	KwAllNamespaces  = "watchallnamespaces"
+	KwPrune          = "prune"
+	KwMaxEventAge    = "maxeventage"
+	KwPublishTimeout = "publishtimeout"
+	KwPublishMethod  = "method"
+	KwTransform      = "transform"
+	KwSince          = "since"
+	KwHeader         = "header"
+	KwHeaderSecret   = "headerfromsecret"
+	KwBreakerFails   = "circuitbreakerthreshold"
+	KwBreakerCool    = "circuitbreakercooldown"
+	KwSkipReplay     = "skipreplayonerror"
+	KwConcurrency    = "concurrency"
+	KwLogLevel       = "loglevel"
 
 	PkgName           = resourceName
 	PkgForce          = force
@@ -182,9 +231,16 @@ const (
 	CanaryHTTPTriggerName   = "httptrigger"
 	CanaryNewFunc           = "newfunction"
 	CanaryOldFunc           = "oldfunction"
+	CanaryOtherFuncs        = "otherfunction"
 	CanaryWeightIncrement   = "increment-step"
 	CanaryIncrementInterval = "increment-interval"
 	CanaryFailureThreshold  = "failure-threshold"
+	CanaryWait              = "wait"
+	CanaryWaitTimeout       = "timeout"
+	CanaryRollback          = "rollback"
+	CanaryCloneSource       = "source"
+	CanaryCloneDest         = "dest"
+	CanaryCloneFunc         = "function"
 
 	ArchiveName   = resourceName
 	ArchiveID     = "id"
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListPageSize is the number of resources requested per list call. Large
+// result sets are fetched in chunks of this size instead of in one response.
+const ListPageSize = 500
+
+// maxListRestarts is how many times ListAll starts over once the continue
+// token of a list expired.
+const maxListRestarts = 3
+
+// EachPage calls list with Limit/Continue set until the server reports no
+// more pages, passing every page to handle. It stops at the first error.
+// Callers that don't need all resources at once should use it instead of
+// ListAll, which keeps every page in memory. As the pages already handled
+// can't be taken back, it fails if the continue token expires (410 Gone)
+// while paging instead of starting over.
+func EachPage[L metav1.ListInterface](ctx context.Context, list func(context.Context, metav1.ListOptions) (L, error), handle func(L) error) error {
+	opts := metav1.ListOptions{Limit: ListPageSize}
+	for {
+		page, err := list(ctx, opts)
+		if len(opts.Continue) > 0 && kerrors.IsResourceExpired(err) {
+			return errors.Wrap(err, "the continue token expired while listing page by page, retry")
+		}
+		if err != nil {
+			return err
+		}
+		if err := handle(page); err != nil {
+			return err
+		}
+		opts.Continue = page.GetContinue()
+		if len(opts.Continue) == 0 {
+			return nil
+		}
+	}
+}
+
+// ListAll returns all resources of list, page by page, in the list of the
+// first page. items returns the items of a list. If the continue token
+// expires while paging, the list is started over up to maxListRestarts times.
+func ListAll[L metav1.ListInterface, T any](ctx context.Context, list func(context.Context, metav1.ListOptions) (L, error), items func(L) *[]T) (L, error) {
+	for restarts := 0; ; restarts++ {
+		var result L
+		var all []T
+		pages := 0
+		err := EachPage(ctx, list, func(page L) error {
+			if pages == 0 {
+				result = page
+			}
+			pages++
+			all = append(all, *items(page)...)
+			return nil
+		})
+		if kerrors.IsResourceExpired(err) && restarts < maxListRestarts {
+			continue
+		}
+		if err != nil {
+			var none L
+			return none, err
+		}
+		*items(result) = all
+		return result, nil
+	}
+}
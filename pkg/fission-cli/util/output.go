@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Supported values of the --output flag.
+const (
+	OutputFormatJSON = "json"
+	OutputFormatYAML = "yaml"
+)
+
+// ValidateOutputFormat checks that format is a supported machine-readable format.
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case OutputFormatJSON, OutputFormatYAML:
+		return nil
+	}
+	return errors.Errorf("unsupported output format '%v', must be one of: %v, %v", format, OutputFormatJSON, OutputFormatYAML)
+}
+
+// PrintObject prints obj to stdout in the given machine-readable format.
+func PrintObject(format string, obj interface{}) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case OutputFormatJSON:
+		data, err = json.MarshalIndent(obj, "", "  ")
+		data = append(data, '\n')
+	case OutputFormatYAML:
+		data, err = yaml.Marshal(obj)
+	default:
+		return ValidateOutputFormat(format)
+	}
+	if err != nil {
+		return errors.Wrap(err, "error serializing output")
+	}
+
+	fmt.Print(string(data))
+	return nil
+}
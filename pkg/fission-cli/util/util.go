@@ -467,7 +467,7 @@ func GetStorageURL(ctx context.Context, client cmd.Client) (*url.URL, error) {
 
 // CheckHTTPTriggerDuplicates checks whether the tuple (Method, Host, URL) is duplicate or not.
 func CheckHTTPTriggerDuplicates(ctx context.Context, client cmd.Client, t *fv1.HTTPTrigger) error {
-	triggers, err := client.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	triggers, err := ListAll(ctx, client.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List, func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items })
 	if err != nil {
 		return err
 	}
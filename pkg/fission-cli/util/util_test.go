@@ -17,10 +17,15 @@ limitations under the License.
 package util
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 )
 
 func TestGetEnvVarFromStringSlice(t *testing.T) {
@@ -72,3 +77,78 @@ func TestGetEnvVarFromStringSlice(t *testing.T) {
 // 	}
 // 	t.Log("Current NS: ", response)
 // }
+
+func TestEachPage(t *testing.T) {
+	pages := map[string]*fv1.HTTPTriggerList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items:    []fv1.HTTPTrigger{{ObjectMeta: metav1.ObjectMeta{Name: "a"}}},
+		},
+		"page-2": {
+			Items: []fv1.HTTPTrigger{{ObjectMeta: metav1.ObjectMeta{Name: "b"}}},
+		},
+	}
+	list := func(_ context.Context, opts metav1.ListOptions) (*fv1.HTTPTriggerList, error) {
+		if opts.Limit != ListPageSize {
+			t.Errorf("expected limit %d, got %d", ListPageSize, opts.Limit)
+		}
+		return pages[opts.Continue], nil
+	}
+
+	var names []string
+	err := EachPage(context.Background(), list, func(page *fv1.HTTPTriggerList) error {
+		for _, ht := range page.Items {
+			names = append(names, ht.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("expected items of both pages, got %v", names)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	expired := kerrors.NewResourceExpired("continue token expired")
+	calls := 0
+	list := func(_ context.Context, opts metav1.ListOptions) (*fv1.HTTPTriggerList, error) {
+		calls++
+		switch {
+		case opts.Continue == "":
+			return &fv1.HTTPTriggerList{
+				ListMeta: metav1.ListMeta{Continue: "page-2"},
+				Items:    []fv1.HTTPTrigger{{ObjectMeta: metav1.ObjectMeta{Name: "a"}}},
+			}, nil
+		case calls == 2:
+			// the first attempt to get the second page finds the token expired
+			return nil, expired
+		default:
+			return &fv1.HTTPTriggerList{
+				Items: []fv1.HTTPTrigger{{ObjectMeta: metav1.ObjectMeta{Name: "b"}}},
+			}, nil
+		}
+	}
+	items := func(l *fv1.HTTPTriggerList) *[]fv1.HTTPTrigger { return &l.Items }
+
+	hts, err := ListAll(context.Background(), list, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, ht := range hts.Items {
+		names = append(names, ht.Name)
+	}
+	// the list started over, without the items of the first attempt
+	if !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("expected items of both pages once, got %v", names)
+	}
+
+	// EachPage can't start over and reports the expired token
+	calls = 0
+	err = EachPage(context.Background(), list, func(*fv1.HTTPTriggerList) error { return nil })
+	if !kerrors.IsResourceExpired(err) {
+		t.Errorf("expected an expired continue token error, got %v", err)
+	}
+}
@@ -28,6 +28,7 @@ type CanaryConfigSpecApplyConfiguration struct {
 	Trigger                 *string         `json:"trigger,omitempty"`
 	NewFunction             *string         `json:"newfunction,omitempty"`
 	OldFunction             *string         `json:"oldfunction,omitempty"`
+	OtherFunctions          []string        `json:"otherfunctions,omitempty"`
 	WeightIncrement         *int            `json:"weightincrement,omitempty"`
 	WeightIncrementDuration *string         `json:"duration,omitempty"`
 	FailureThreshold        *int            `json:"failurethreshold,omitempty"`
@@ -64,6 +65,16 @@ func (b *CanaryConfigSpecApplyConfiguration) WithOldFunction(value string) *Cana
 	return b
 }
 
+// WithOtherFunctions adds the given value to the OtherFunctions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OtherFunctions field.
+func (b *CanaryConfigSpecApplyConfiguration) WithOtherFunctions(values ...string) *CanaryConfigSpecApplyConfiguration {
+	for i := range values {
+		b.OtherFunctions = append(b.OtherFunctions, values[i])
+	}
+	return b
+}
+
 // WithWeightIncrement sets the WeightIncrement field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the WeightIncrement field is set to the value of the last call.
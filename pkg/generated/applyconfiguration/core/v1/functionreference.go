@@ -25,9 +25,11 @@ import (
 // FunctionReferenceApplyConfiguration represents an declarative configuration of the FunctionReference type for use
 // with apply.
 type FunctionReferenceApplyConfiguration struct {
-	Type            *v1.FunctionReferenceType `json:"type,omitempty"`
-	Name            *string                   `json:"name,omitempty"`
-	FunctionWeights map[string]int            `json:"functionweights,omitempty"`
+	Type            *v1.FunctionReferenceType                  `json:"type,omitempty"`
+	Name            *string                                    `json:"name,omitempty"`
+	FunctionWeights map[string]int                             `json:"functionweights,omitempty"`
+	HeaderOverrides []FunctionHeaderOverrideApplyConfiguration `json:"headeroverrides,omitempty"`
+	PackageVersion  *string                                    `json:"packageversion,omitempty"`
 }
 
 // FunctionReferenceApplyConfiguration constructs an declarative configuration of the FunctionReference type for use with
@@ -65,3 +67,24 @@ func (b *FunctionReferenceApplyConfiguration) WithFunctionWeights(entries map[st
 	}
 	return b
 }
+
+// WithHeaderOverrides adds the given value to the HeaderOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the HeaderOverrides field.
+func (b *FunctionReferenceApplyConfiguration) WithHeaderOverrides(values ...*FunctionHeaderOverrideApplyConfiguration) *FunctionReferenceApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHeaderOverrides")
+		}
+		b.HeaderOverrides = append(b.HeaderOverrides, *values[i])
+	}
+	return b
+}
+
+// WithPackageVersion sets the PackageVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PackageVersion field is set to the value of the last call.
+func (b *FunctionReferenceApplyConfiguration) WithPackageVersion(value string) *FunctionReferenceApplyConfiguration {
+	b.PackageVersion = &value
+	return b
+}
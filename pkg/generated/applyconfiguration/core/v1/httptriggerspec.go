@@ -28,6 +28,7 @@ type HTTPTriggerSpecApplyConfiguration struct {
 	Method            *string                              `json:"method,omitempty"`
 	Methods           []string                             `json:"methods,omitempty"`
 	FunctionReference *FunctionReferenceApplyConfiguration `json:"functionref,omitempty"`
+	Timeout           *int                                 `json:"timeout,omitempty"`
 	CreateIngress     *bool                                `json:"createingress,omitempty"`
 	IngressConfig     *IngressConfigApplyConfiguration     `json:"ingressconfig,omitempty"`
 }
@@ -96,6 +97,14 @@ func (b *HTTPTriggerSpecApplyConfiguration) WithFunctionReference(value *Functio
 	return b
 }
 
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *HTTPTriggerSpecApplyConfiguration) WithTimeout(value int) *HTTPTriggerSpecApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
 // WithCreateIngress sets the CreateIngress field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the CreateIngress field is set to the value of the last call.
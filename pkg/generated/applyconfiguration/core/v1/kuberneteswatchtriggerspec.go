@@ -18,13 +18,47 @@ limitations under the License.
 
 package v1
 
+import (
+	corev1 "github.com/fission/fission/pkg/apis/core/v1"
+	apicorev1 "k8s.io/api/core/v1"
+)
+
 // KubernetesWatchTriggerSpecApplyConfiguration represents an declarative configuration of the KubernetesWatchTriggerSpec type for use
 // with apply.
 type KubernetesWatchTriggerSpecApplyConfiguration struct {
-	Namespace         *string                              `json:"namespace,omitempty"`
-	Type              *string                              `json:"type,omitempty"`
-	LabelSelector     map[string]string                    `json:"labelselector,omitempty"`
-	FunctionReference *FunctionReferenceApplyConfiguration `json:"functionref,omitempty"`
+	Namespace               *string                                  `json:"namespace,omitempty"`
+	Type                    *string                                  `json:"type,omitempty"`
+	Types                   []string                                 `json:"types,omitempty"`
+	IncludeOwnedJobs        *bool                                    `json:"includeOwnedJobs,omitempty"`
+	LabelSelector           map[string]string                        `json:"labelselector,omitempty"`
+	FunctionReference       *FunctionReferenceApplyConfiguration     `json:"functionref,omitempty"`
+	Paused                  *bool                                    `json:"paused,omitempty"`
+	Filter                  *string                                  `json:"filter,omitempty"`
+	FilterValue             *string                                  `json:"filterValue,omitempty"`
+	Gzip                    *bool                                    `json:"gzip,omitempty"`
+	GzipMinSize             *int                                     `json:"gzipMinSize,omitempty"`
+	DryRun                  *bool                                    `json:"dryRun,omitempty"`
+	IncludeNormalEvents     *bool                                    `json:"includeNormalEvents,omitempty"`
+	EventInvolvedObjectKind *string                                  `json:"eventInvolvedObjectKind,omitempty"`
+	EventReason             *string                                  `json:"eventReason,omitempty"`
+	IncludeOldObject        *bool                                    `json:"includeOldObject,omitempty"`
+	Sink                    *corev1.KubernetesWatchSinkType          `json:"sink,omitempty"`
+	Topic                   *string                                  `json:"topic,omitempty"`
+	AllNamespaces           *bool                                    `json:"allNamespaces,omitempty"`
+	FunctionPath            *string                                  `json:"functionPath,omitempty"`
+	PruneFields             []string                                 `json:"pruneFields,omitempty"`
+	MaxEventAge             *string                                  `json:"maxEventAge,omitempty"`
+	PublishTimeout          *string                                  `json:"publishTimeout,omitempty"`
+	PublishMethod           *string                                  `json:"publishMethod,omitempty"`
+	Transform               map[string]string                        `json:"transform,omitempty"`
+	ResourceVersion         *string                                  `json:"resourceVersion,omitempty"`
+	Headers                 map[string]string                        `json:"headers,omitempty"`
+	HeadersFromSecrets      map[string]apicorev1.SecretKeySelector   `json:"headersFromSecrets,omitempty"`
+	CircuitBreakerThreshold *int                                     `json:"circuitBreakerThreshold,omitempty"`
+	CircuitBreakerCooldown  *string                                  `json:"circuitBreakerCooldown,omitempty"`
+	SkipReplayOnError       *bool                                    `json:"skipReplayOnError,omitempty"`
+	ConcurrencyPolicy       *corev1.KubernetesWatchConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	LogLevel                *string                                  `json:"logLevel,omitempty"`
 }
 
 // KubernetesWatchTriggerSpecApplyConfiguration constructs an declarative configuration of the KubernetesWatchTriggerSpec type for use with
@@ -49,6 +83,24 @@ func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithType(value string) *K
 	return b
 }
 
+// WithTypes adds the given value to the Types field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Types field.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithTypes(values ...string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	for i := range values {
+		b.Types = append(b.Types, values[i])
+	}
+	return b
+}
+
+// WithIncludeOwnedJobs sets the IncludeOwnedJobs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IncludeOwnedJobs field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithIncludeOwnedJobs(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.IncludeOwnedJobs = &value
+	return b
+}
+
 // WithLabelSelector puts the entries into the LabelSelector field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the LabelSelector field,
@@ -70,3 +122,239 @@ func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithFunctionReference(val
 	b.FunctionReference = value
 	return b
 }
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithPaused(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
+// WithFilter sets the Filter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Filter field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithFilter(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.Filter = &value
+	return b
+}
+
+// WithFilterValue sets the FilterValue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FilterValue field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithFilterValue(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.FilterValue = &value
+	return b
+}
+
+// WithGzip sets the Gzip field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Gzip field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithGzip(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.Gzip = &value
+	return b
+}
+
+// WithGzipMinSize sets the GzipMinSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GzipMinSize field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithGzipMinSize(value int) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.GzipMinSize = &value
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithDryRun(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
+
+// WithIncludeNormalEvents sets the IncludeNormalEvents field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IncludeNormalEvents field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithIncludeNormalEvents(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.IncludeNormalEvents = &value
+	return b
+}
+
+// WithEventInvolvedObjectKind sets the EventInvolvedObjectKind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EventInvolvedObjectKind field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithEventInvolvedObjectKind(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.EventInvolvedObjectKind = &value
+	return b
+}
+
+// WithEventReason sets the EventReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EventReason field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithEventReason(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.EventReason = &value
+	return b
+}
+
+// WithIncludeOldObject sets the IncludeOldObject field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IncludeOldObject field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithIncludeOldObject(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.IncludeOldObject = &value
+	return b
+}
+
+// WithSink sets the Sink field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sink field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithSink(value corev1.KubernetesWatchSinkType) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.Sink = &value
+	return b
+}
+
+// WithTopic sets the Topic field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Topic field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithTopic(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.Topic = &value
+	return b
+}
+
+// WithAllNamespaces sets the AllNamespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AllNamespaces field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithAllNamespaces(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.AllNamespaces = &value
+	return b
+}
+
+// WithFunctionPath sets the FunctionPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FunctionPath field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithFunctionPath(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.FunctionPath = &value
+	return b
+}
+
+// WithPruneFields adds the given value to the PruneFields field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PruneFields field.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithPruneFields(values ...string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	for i := range values {
+		b.PruneFields = append(b.PruneFields, values[i])
+	}
+	return b
+}
+
+// WithMaxEventAge sets the MaxEventAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxEventAge field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithMaxEventAge(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.MaxEventAge = &value
+	return b
+}
+
+// WithPublishTimeout sets the PublishTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PublishTimeout field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithPublishTimeout(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.PublishTimeout = &value
+	return b
+}
+
+// WithPublishMethod sets the PublishMethod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PublishMethod field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithPublishMethod(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.PublishMethod = &value
+	return b
+}
+
+// WithTransform puts the entries into the Transform field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Transform field,
+// overwriting an existing map entries in Transform field with the same key.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithTransform(entries map[string]string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	if b.Transform == nil && len(entries) > 0 {
+		b.Transform = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Transform[k] = v
+	}
+	return b
+}
+
+// WithResourceVersion sets the ResourceVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceVersion field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithResourceVersion(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.ResourceVersion = &value
+	return b
+}
+
+// WithHeaders puts the entries into the Headers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Headers field,
+// overwriting an existing map entries in Headers field with the same key.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithHeaders(entries map[string]string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	if b.Headers == nil && len(entries) > 0 {
+		b.Headers = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Headers[k] = v
+	}
+	return b
+}
+
+// WithHeadersFromSecrets puts the entries into the HeadersFromSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the HeadersFromSecrets field,
+// overwriting an existing map entries in HeadersFromSecrets field with the same key.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithHeadersFromSecrets(entries map[string]apicorev1.SecretKeySelector) *KubernetesWatchTriggerSpecApplyConfiguration {
+	if b.HeadersFromSecrets == nil && len(entries) > 0 {
+		b.HeadersFromSecrets = make(map[string]apicorev1.SecretKeySelector, len(entries))
+	}
+	for k, v := range entries {
+		b.HeadersFromSecrets[k] = v
+	}
+	return b
+}
+
+// WithCircuitBreakerThreshold sets the CircuitBreakerThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CircuitBreakerThreshold field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithCircuitBreakerThreshold(value int) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.CircuitBreakerThreshold = &value
+	return b
+}
+
+// WithCircuitBreakerCooldown sets the CircuitBreakerCooldown field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CircuitBreakerCooldown field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithCircuitBreakerCooldown(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.CircuitBreakerCooldown = &value
+	return b
+}
+
+// WithSkipReplayOnError sets the SkipReplayOnError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SkipReplayOnError field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithSkipReplayOnError(value bool) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.SkipReplayOnError = &value
+	return b
+}
+
+// WithConcurrencyPolicy sets the ConcurrencyPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConcurrencyPolicy field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithConcurrencyPolicy(value corev1.KubernetesWatchConcurrencyPolicy) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.ConcurrencyPolicy = &value
+	return b
+}
+
+// WithLogLevel sets the LogLevel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogLevel field is set to the value of the last call.
+func (b *KubernetesWatchTriggerSpecApplyConfiguration) WithLogLevel(value string) *KubernetesWatchTriggerSpecApplyConfiguration {
+	b.LogLevel = &value
+	return b
+}
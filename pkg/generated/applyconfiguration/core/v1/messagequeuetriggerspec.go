@@ -26,21 +26,28 @@ import (
 // MessageQueueTriggerSpecApplyConfiguration represents an declarative configuration of the MessageQueueTriggerSpec type for use
 // with apply.
 type MessageQueueTriggerSpecApplyConfiguration struct {
-	FunctionReference *FunctionReferenceApplyConfiguration `json:"functionref,omitempty"`
-	MessageQueueType  *corev1.MessageQueueType             `json:"messageQueueType,omitempty"`
-	Topic             *string                              `json:"topic,omitempty"`
-	ResponseTopic     *string                              `json:"respTopic,omitempty"`
-	ErrorTopic        *string                              `json:"errorTopic,omitempty"`
-	MaxRetries        *int                                 `json:"maxRetries,omitempty"`
-	ContentType       *string                              `json:"contentType,omitempty"`
-	PollingInterval   *int32                               `json:"pollingInterval,omitempty"`
-	CooldownPeriod    *int32                               `json:"cooldownPeriod,omitempty"`
-	MinReplicaCount   *int32                               `json:"minReplicaCount,omitempty"`
-	MaxReplicaCount   *int32                               `json:"maxReplicaCount,omitempty"`
-	Metadata          map[string]string                    `json:"metadata,omitempty"`
-	Secret            *string                              `json:"secret,omitempty"`
-	MqtKind           *string                              `json:"mqtkind,omitempty"`
-	PodSpec           *apicorev1.PodSpec                   `json:"podspec,omitempty"`
+	FunctionReference   *FunctionReferenceApplyConfiguration `json:"functionref,omitempty"`
+	MessageQueueType    *corev1.MessageQueueType             `json:"messageQueueType,omitempty"`
+	Topic               *string                              `json:"topic,omitempty"`
+	TopicPattern        *bool                                `json:"topicPattern,omitempty"`
+	ResponseTopic       *string                              `json:"respTopic,omitempty"`
+	ResponseTopics      []string                             `json:"respTopics,omitempty"`
+	ErrorTopic          *string                              `json:"errorTopic,omitempty"`
+	ErrorFormat         *corev1.MessageQueueErrorFormat      `json:"errorFormat,omitempty"`
+	MaxRetries          *int                                 `json:"maxRetries,omitempty"`
+	RetryBackoff        *string                              `json:"retryBackoff,omitempty"`
+	MaxInflight         *int                                 `json:"maxInflight,omitempty"`
+	ContentType         *string                              `json:"contentType,omitempty"`
+	ResponseContentType *string                              `json:"respContentType,omitempty"`
+	PollingInterval     *int32                               `json:"pollingInterval,omitempty"`
+	CooldownPeriod      *int32                               `json:"cooldownPeriod,omitempty"`
+	MinReplicaCount     *int32                               `json:"minReplicaCount,omitempty"`
+	MaxReplicaCount     *int32                               `json:"maxReplicaCount,omitempty"`
+	Metadata            map[string]string                    `json:"metadata,omitempty"`
+	Secret              *string                              `json:"secret,omitempty"`
+	AuthenticationRef   *string                              `json:"authenticationRef,omitempty"`
+	MqtKind             *string                              `json:"mqtkind,omitempty"`
+	PodSpec             *apicorev1.PodSpec                   `json:"podspec,omitempty"`
 }
 
 // MessageQueueTriggerSpecApplyConfiguration constructs an declarative configuration of the MessageQueueTriggerSpec type for use with
@@ -73,6 +80,14 @@ func (b *MessageQueueTriggerSpecApplyConfiguration) WithTopic(value string) *Mes
 	return b
 }
 
+// WithTopicPattern sets the TopicPattern field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopicPattern field is set to the value of the last call.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithTopicPattern(value bool) *MessageQueueTriggerSpecApplyConfiguration {
+	b.TopicPattern = &value
+	return b
+}
+
 // WithResponseTopic sets the ResponseTopic field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ResponseTopic field is set to the value of the last call.
@@ -81,6 +96,16 @@ func (b *MessageQueueTriggerSpecApplyConfiguration) WithResponseTopic(value stri
 	return b
 }
 
+// WithResponseTopics adds the given value to the ResponseTopics field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResponseTopics field.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithResponseTopics(values ...string) *MessageQueueTriggerSpecApplyConfiguration {
+	for i := range values {
+		b.ResponseTopics = append(b.ResponseTopics, values[i])
+	}
+	return b
+}
+
 // WithErrorTopic sets the ErrorTopic field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ErrorTopic field is set to the value of the last call.
@@ -89,6 +114,14 @@ func (b *MessageQueueTriggerSpecApplyConfiguration) WithErrorTopic(value string)
 	return b
 }
 
+// WithErrorFormat sets the ErrorFormat field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ErrorFormat field is set to the value of the last call.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithErrorFormat(value corev1.MessageQueueErrorFormat) *MessageQueueTriggerSpecApplyConfiguration {
+	b.ErrorFormat = &value
+	return b
+}
+
 // WithMaxRetries sets the MaxRetries field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MaxRetries field is set to the value of the last call.
@@ -97,6 +130,22 @@ func (b *MessageQueueTriggerSpecApplyConfiguration) WithMaxRetries(value int) *M
 	return b
 }
 
+// WithRetryBackoff sets the RetryBackoff field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryBackoff field is set to the value of the last call.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithRetryBackoff(value string) *MessageQueueTriggerSpecApplyConfiguration {
+	b.RetryBackoff = &value
+	return b
+}
+
+// WithMaxInflight sets the MaxInflight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxInflight field is set to the value of the last call.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithMaxInflight(value int) *MessageQueueTriggerSpecApplyConfiguration {
+	b.MaxInflight = &value
+	return b
+}
+
 // WithContentType sets the ContentType field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ContentType field is set to the value of the last call.
@@ -105,6 +154,14 @@ func (b *MessageQueueTriggerSpecApplyConfiguration) WithContentType(value string
 	return b
 }
 
+// WithResponseContentType sets the ResponseContentType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResponseContentType field is set to the value of the last call.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithResponseContentType(value string) *MessageQueueTriggerSpecApplyConfiguration {
+	b.ResponseContentType = &value
+	return b
+}
+
 // WithPollingInterval sets the PollingInterval field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PollingInterval field is set to the value of the last call.
@@ -159,6 +216,14 @@ func (b *MessageQueueTriggerSpecApplyConfiguration) WithSecret(value string) *Me
 	return b
 }
 
+// WithAuthenticationRef sets the AuthenticationRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AuthenticationRef field is set to the value of the last call.
+func (b *MessageQueueTriggerSpecApplyConfiguration) WithAuthenticationRef(value string) *MessageQueueTriggerSpecApplyConfiguration {
+	b.AuthenticationRef = &value
+	return b
+}
+
 // WithMqtKind sets the MqtKind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MqtKind field is set to the value of the last call.
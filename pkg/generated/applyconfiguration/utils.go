@@ -53,6 +53,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1.ExecutionStrategyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Function"):
 		return &corev1.FunctionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("FunctionHeaderOverride"):
+		return &corev1.FunctionHeaderOverrideApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FunctionPackageRef"):
 		return &corev1.FunctionPackageRefApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FunctionReference"):
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"sync"
+	"time"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	// circuitHalfOpen lets a single trial event through after the cooldown
+	circuitHalfOpen
+)
+
+// circuitBreaker stops the events of a watch from being sent to a function
+// that keeps failing. After threshold consecutive failures it opens and
+// rejects events for cooldown, then half-opens to let one trial event
+// through: its success closes the breaker, its failure opens it again. If
+// the outcome of the trial is never reported, another one is let through
+// after the next cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	lock     sync.Mutex // guards the fields below
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+func makeCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns true if an event may be sent.
+func (b *circuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.state == circuitClosed {
+		return true
+	}
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.state = circuitHalfOpen
+	b.openedAt = b.now()
+	return true
+}
+
+// record takes the outcome of sending an event, it returns true if a failure
+// opened the breaker.
+func (b *circuitBreaker) record(err error) (opened bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err == nil {
+		b.state = circuitClosed
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= b.threshold) {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := makeCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+	failed := errors.New("request returned status code 503")
+
+	if b.record(failed) {
+		t.Fatal("expected the breaker to stay closed below the threshold")
+	}
+	if !b.record(failed) {
+		t.Fatal("expected the breaker to open at the threshold")
+	}
+	if b.allow() {
+		t.Error("expected events to be rejected while open")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("expected a trial event after the cooldown")
+	}
+	if b.allow() {
+		t.Error("expected a single trial event while half-open")
+	}
+	if !b.record(failed) {
+		t.Fatal("expected a failed trial to open the breaker again")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("expected a trial event after the cooldown")
+	}
+	b.record(nil)
+	if !b.allow() || !b.allow() {
+		t.Error("expected a successful trial to close the breaker")
+	}
+}
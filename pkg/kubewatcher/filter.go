@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// eventFilter decides whether an event is published based on the
+// Filter and FilterValue of the watch trigger spec.
+type eventFilter struct {
+	path  *jsonpath.JSONPath
+	value string
+}
+
+// makeEventFilter returns nil if expr is empty, so that every event matches.
+func makeEventFilter(expr, value string) (*eventFilter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	path := jsonpath.New("filter").AllowMissingKeys(true)
+	if err := path.Parse(expr); err != nil {
+		return nil, fmt.Errorf("error parsing filter %q: %w", expr, err)
+	}
+	return &eventFilter{path: path, value: value}, nil
+}
+
+// match evaluates the filter against the serialized event object. Without a
+// value the event matches if the expression yields a non-empty result,
+// otherwise the result must equal the value.
+func (f *eventFilter) match(obj []byte) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	var data interface{}
+	if err := json.Unmarshal(obj, &data); err != nil {
+		return false, err
+	}
+	var buf bytes.Buffer
+	if err := f.path.Execute(&buf, data); err != nil {
+		return false, err
+	}
+	result := strings.TrimSpace(buf.String())
+	if f.value == "" {
+		return result != "", nil
+	}
+	return result == f.value, nil
+}
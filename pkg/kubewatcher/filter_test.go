@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import "testing"
+
+func TestEventFilter(t *testing.T) {
+	pod := []byte(`{"metadata": {"name": "foo"}, "status": {"phase": "Running", "conditions": [{"type": "Ready", "status": "True"}]}}`)
+
+	tests := []struct {
+		name  string
+		expr  string
+		value string
+		want  bool
+	}{
+		{name: "no filter", want: true},
+		{name: "value matches", expr: "{.status.phase}", value: "Running", want: true},
+		{name: "value differs", expr: "{.status.phase}", value: "Pending", want: false},
+		{name: "field present", expr: "{.status.phase}", want: true},
+		{name: "field missing", expr: "{.status.reason}", want: false},
+		{name: "array filter", expr: `{.status.conditions[?(@.type=="Ready")].status}`, value: "True", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := makeEventFilter(test.expr, test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := f.match(pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	if _, err := makeEventFilter("{.status[", ""); err == nil {
+		t.Error("expected error for invalid expression")
+	}
+}
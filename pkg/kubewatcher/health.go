@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultUnhealthyWatchRatio reports the kubewatcher unhealthy only once all
+// of its watches failed to restart.
+const DefaultUnhealthyWatchRatio = 1.0
+
+// failedWatches returns the number of watches whose last restart failed, and
+// the number of all watches.
+func (kw *KubeWatcher) failedWatches() (failed, total int) {
+	kw.watchesLock.RLock()
+	defer kw.watchesLock.RUnlock()
+	for _, ws := range kw.watches {
+		if !ws.isHealthy() {
+			failed++
+		}
+	}
+	return failed, len(kw.watches)
+}
+
+// HealthHandler reports the kubewatcher unhealthy once the fraction of
+// watches that failed their last restart reaches unhealthyRatio.
+func (kw *KubeWatcher) HealthHandler(unhealthyRatio float64) http.HandlerFunc {
+	if unhealthyRatio <= 0 || unhealthyRatio > 1 {
+		unhealthyRatio = DefaultUnhealthyWatchRatio
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		failed, total := kw.failedWatches()
+		if total > 0 && float64(failed)/float64(total) >= unhealthyRatio {
+			http.Error(w, fmt.Sprintf("%d of %d watches failed to restart", failed, total), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHealthHandler(t *testing.T) {
+	kw := &KubeWatcher{watches: make(map[types.UID]*watchSubscription)}
+	check := func(ratio float64, want int) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		kw.HealthHandler(ratio)(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if rec.Code != want {
+			t.Errorf("ratio %v: expected status %d, got %d", ratio, want, rec.Code)
+		}
+	}
+
+	// no watches
+	check(0.5, http.StatusOK)
+
+	kw.watches["a"] = &watchSubscription{healthy: 1}
+	kw.watches["b"] = &watchSubscription{healthy: 0}
+	check(0.5, http.StatusServiceUnavailable)
+	check(DefaultUnhealthyWatchRatio, http.StatusOK)
+
+	kw.watches["a"].healthy = 0
+	check(DefaultUnhealthyWatchRatio, http.StatusServiceUnavailable)
+}
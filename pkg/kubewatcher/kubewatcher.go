@@ -18,23 +18,34 @@ package kubewatcher
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	ferror "github.com/fission/fission/pkg/error"
@@ -42,35 +53,210 @@ import (
 	"github.com/fission/fission/pkg/utils"
 )
 
+const (
+	// DefaultWatchTimeout is how long (re)starting a watch is retried for
+	// before giving up.
+	DefaultWatchTimeout = 30 * time.Second
+
+	// DefaultWatchAttemptTimeout is how long a single attempt to start a watch
+	// waits on the API server before it is abandoned and retried.
+	DefaultWatchAttemptTimeout = 5 * time.Second
+
+	// DefaultPublishConcurrency is the default number of in-flight publishes
+	// allowed per namespace.
+	DefaultPublishConcurrency = 16
+
+	// DefaultEventBufferSize is the default number of events of a watch
+	// buffered while they wait to be published.
+	DefaultEventBufferSize = 128
+
+	watchRetryInterval = 500 * time.Millisecond
+
+	// maxPublishWait bounds how long a trigger with the Serial concurrency
+	// policy waits for the outcome of a publish before the next one.
+	maxPublishWait = 10 * time.Minute
+
+	// dryRunBodyLimit is how much of the body of an event is logged in dry run mode.
+	dryRunBodyLimit = 1024
+
+	// maxWatchFailures is how many times in a row a watch may end with an error
+	// or fail to restart without delivering an event before it is given up.
+	maxWatchFailures = 10
+)
+
+// EventBufferPolicy is what a watch does with a new event when its buffer of
+// events waiting to be published is full.
+type EventBufferPolicy string
+
+const (
+	// EventBufferBlock stops reading from the watch until the buffer has room.
+	// No event is lost, but a watch blocked for long may be closed by the API server.
+	EventBufferBlock EventBufferPolicy = "block"
+	// EventBufferDropOldest drops the oldest buffered event to make room for
+	// the new one and counts it in fission_kubewatcher_events_dropped_total.
+	EventBufferDropOldest EventBufferPolicy = "drop-oldest"
+)
+
+// Validate returns an error unless p is a known policy.
+func (p EventBufferPolicy) Validate() error {
+	switch p {
+	case EventBufferBlock, EventBufferDropOldest:
+		return nil
+	}
+	return fmt.Errorf("unknown event buffer policy %q, expected %q or %q", p, EventBufferBlock, EventBufferDropOldest)
+}
+
 type (
 	KubeWatcher struct {
-		logger           *zap.Logger
-		watches          map[types.UID]watchSubscription
+		logger      *zap.Logger
+		watches     map[types.UID]*watchSubscription
+		watchesLock sync.RWMutex // guards watches and dead
+		// dead are the triggers whose watch failed to start or gave up, for
+		// the watchdog to recreate, see RunWatchdog
+		dead             map[types.UID]*deadWatch
 		kubernetesClient kubernetes.Interface
 		publisher        publisher.Publisher
+		// topicPublisher publishes the events of watches with sink mqtopic,
+		// it is nil if no message queue is configured.
+		topicPublisher publisher.Publisher
+		// recorder emits Kubernetes events about watch triggers, it may be nil
+		recorder            record.EventRecorder
+		watchTimeout        time.Duration
+		watchAttemptTimeout time.Duration
+
+		publishConcurrency int
+		publishSems        map[string]chan struct{} // namespace -> semaphore
+		publishSemsLock    sync.Mutex               // guards publishSems
+
+		bufferSize   int
+		bufferPolicy EventBufferPolicy
 	}
 
 	watchSubscription struct {
-		logger              *zap.Logger
-		watch               fv1.KubernetesWatchTrigger
-		kubeWatch           watch.Interface
-		lastResourceVersion string
-		stopped             *int32
-		kubernetesClient    kubernetes.Interface
-		publisher           publisher.Publisher
+		logger           *zap.Logger
+		watch            fv1.KubernetesWatchTrigger
+		kubeWatch        watch.Interface
+		stopped          *int32
+		kubernetesClient kubernetes.Interface
+		publisher        publisher.Publisher
+		recorder         record.EventRecorder
+		watchTimeout     time.Duration
+		// watchAttemptTimeout bounds each attempt to start a watch of types
+		watchAttemptTimeout time.Duration
+		filter              *eventFilter
+		// types are the upper-cased resource types watched, see watchTypes
+		types []string
+		// lastResourceVersions maps each of types to the resource version its
+		// watch resumes from
+		lastResourceVersions map[string]string
+		// tracker remembers the objects for IncludeOldObject, nil if it is not set
+		tracker *objectTracker
+		// pruneFields are the paths of the PruneFields of the trigger
+		pruneFields [][]string
+		// transform reshapes the object for the Transform of the trigger, nil if it is not set
+		transform *eventTransform
+		// maxEventAge skips Added events of older objects, 0 if it is not set
+		maxEventAge time.Duration
+		// ownedJobsOnly skips the Jobs not spawned by a CronJob, when Jobs are
+		// only watched for the IncludeOwnedJobs of the trigger
+		ownedJobsOnly bool
+		// headers are the Headers and HeadersFromSecrets of the trigger, added
+		// to every event before the kubewatcher's own headers
+		headers map[string]string
+		// breaker rejects events while the function keeps failing, nil if the
+		// trigger has no CircuitBreakerThreshold
+		breaker *circuitBreaker
+		// publishOptions carry the PublishTimeout and PublishMethod of the trigger
+		publishOptions publisher.PublishOptions
+		// healthy is 1 if the last restartWatch succeeded, 0 otherwise
+		healthy int32
+		// failures counts the watch failures since an event was last delivered,
+		// it is reset by the publishes that succeed.
+		failures int32
+
+		// publishSem limits the in-flight publishes of all watches in the
+		// namespace of the trigger, events wait in publishQueue meanwhile.
+		publishSem   chan struct{}
+		publishQueue chan publishEvent
+		// bufferSize is the capacity of publishQueue, bufferPolicy says what
+		// happens to events once it is full.
+		bufferSize   int
+		bufferPolicy EventBufferPolicy
+
+		// paused is set by pauseWatch, and resuming while resumeWatch restarts
+		// the watch. They are guarded by the watchesLock of the KubeWatcher.
+		paused   bool
+		resuming bool
+		// done is closed when the dispatch loop returns.
+		done chan struct{}
+	}
+
+	publishEvent struct {
+		body    []byte
+		headers map[string]string
+		target  string
 	}
 )
 
-func MakeKubeWatcher(ctx context.Context, logger *zap.Logger, kubernetesClient kubernetes.Interface, publisher publisher.Publisher) *KubeWatcher {
+// MakeKubeWatcher returns a KubeWatcher configured by the watch, publish and
+// event buffer settings of opts. The timeouts, PublishConcurrency and
+// EventBufferSize default to DefaultWatchTimeout, DefaultWatchAttemptTimeout,
+// DefaultPublishConcurrency and DefaultEventBufferSize if they are not
+// positive, EventBufferPolicy to EventBufferBlock if it is empty.
+// topicPublisher may be nil, watches with sink mqtopic then fail to start.
+// If recorder is not nil, it emits an event on triggers whose watch is forbidden.
+func MakeKubeWatcher(ctx context.Context, logger *zap.Logger, kubernetesClient kubernetes.Interface, publisher publisher.Publisher,
+	topicPublisher publisher.Publisher, recorder record.EventRecorder, opts Options) *KubeWatcher {
+	watchTimeout := opts.WatchTimeout
+	if watchTimeout <= 0 {
+		watchTimeout = DefaultWatchTimeout
+	}
+	watchAttemptTimeout := opts.WatchAttemptTimeout
+	if watchAttemptTimeout <= 0 {
+		watchAttemptTimeout = DefaultWatchAttemptTimeout
+	}
+	publishConcurrency := opts.PublishConcurrency
+	if publishConcurrency <= 0 {
+		publishConcurrency = DefaultPublishConcurrency
+	}
+	bufferSize := opts.EventBufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultEventBufferSize
+	}
+	bufferPolicy := opts.EventBufferPolicy
+	if len(bufferPolicy) == 0 {
+		bufferPolicy = EventBufferBlock
+	}
 	kw := &KubeWatcher{
-		logger:           logger.Named("kube_watcher"),
-		watches:          make(map[types.UID]watchSubscription),
-		kubernetesClient: kubernetesClient,
-		publisher:        publisher,
+		logger:              logger.Named("kube_watcher"),
+		watches:             make(map[types.UID]*watchSubscription),
+		dead:                make(map[types.UID]*deadWatch),
+		kubernetesClient:    kubernetesClient,
+		publisher:           publisher,
+		topicPublisher:      topicPublisher,
+		recorder:            recorder,
+		watchTimeout:        watchTimeout,
+		watchAttemptTimeout: watchAttemptTimeout,
+		publishConcurrency:  publishConcurrency,
+		publishSems:         make(map[string]chan struct{}),
+		bufferSize:          bufferSize,
+		bufferPolicy:        bufferPolicy,
 	}
 	return kw
 }
 
+// getPublishSem returns the semaphore shared by the watches in the namespace.
+func (kw *KubeWatcher) getPublishSem(namespace string) chan struct{} {
+	kw.publishSemsLock.Lock()
+	defer kw.publishSemsLock.Unlock()
+	sem, ok := kw.publishSems[namespace]
+	if !ok {
+		sem = make(chan struct{}, kw.publishConcurrency)
+		kw.publishSems[namespace] = sem
+	}
+	return sem
+}
+
 // TODO lifted from kubernetes/pkg/kubectl/resource_printer.go.
 func printKubernetesObject(obj runtime.Object, w io.Writer) error {
 	switch obj := obj.(type) {
@@ -94,9 +280,182 @@ func printKubernetesObject(obj runtime.Object, w io.Writer) error {
 	return err
 }
 
-func createKubernetesWatch(ctx context.Context, kubeClient kubernetes.Interface, w *fv1.KubernetesWatchTrigger, resourceVersion string) (watch.Interface, error) {
-	var wi watch.Interface
-	var err error
+// watchNamespace returns the namespace whose resources the trigger watches,
+// metav1.NamespaceAll for a trigger with AllNamespaces.
+func watchNamespace(w *fv1.KubernetesWatchTrigger) string {
+	if w.Spec.AllNamespaces {
+		return metav1.NamespaceAll
+	}
+	return w.Spec.Namespace
+}
+
+// watchScope describes the namespaces a trigger watches, for messages.
+func watchScope(w *fv1.KubernetesWatchTrigger) string {
+	if w.Spec.AllNamespaces {
+		return "in all namespaces"
+	}
+	return fmt.Sprintf("in namespace %s", w.Spec.Namespace)
+}
+
+// watchTypes returns the upper-cased resource types a trigger watches, Type
+// followed by the Types not already seen, and JOB for the Jobs of the
+// CronJobs of a trigger with IncludeOwnedJobs.
+func watchTypes(w *fv1.KubernetesWatchTrigger) []string {
+	types := []string{strings.ToUpper(w.Spec.Type)}
+	for _, t := range w.Spec.Types {
+		t = strings.ToUpper(t)
+		seen := false
+		for _, known := range types {
+			if known == t {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			types = append(types, t)
+		}
+	}
+	if w.Spec.IncludeOwnedJobs && slices.Contains(types, "CRONJOB") && !slices.Contains(types, "JOB") {
+		types = append(types, "JOB")
+	}
+	return types
+}
+
+// subscriptionLogger returns the named logger of a subscription, logging at
+// level instead of the level of the kubewatcher if it is set.
+func subscriptionLogger(logger *zap.Logger, level string) (*zap.Logger, error) {
+	logger = logger.Named("watch_subscription")
+	if len(level) == 0 {
+		return logger, nil
+	}
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, level: lvl}
+	})), nil
+}
+
+// levelCore replaces the level of a core. Unlike zap.IncreaseLevel, it can
+// also lower it, e.g. to debug a single watch while the kubewatcher logs at
+// info. Entries are written to the core directly, skipping its own level
+// check and sampling.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelCore) Level() zapcore.Level {
+	return c.level
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// watchesOwnedJobsOnly tells whether a trigger watches Jobs only for the Jobs
+// of its CronJobs, rather than because it has the Job type.
+func watchesOwnedJobsOnly(w *fv1.KubernetesWatchTrigger) bool {
+	if !w.Spec.IncludeOwnedJobs || strings.EqualFold(w.Spec.Type, "JOB") {
+		return false
+	}
+	for _, t := range w.Spec.Types {
+		if strings.EqualFold(t, "JOB") {
+			return false
+		}
+	}
+	return true
+}
+
+// ownerCronJob returns the name of the CronJob controlling a Job, from the
+// owner references in its metadata. ok is false for any other object.
+func ownerCronJob(obj runtime.Object) (name string, ok bool) {
+	if _, isJob := obj.(*batchv1.Job); !isJob {
+		return "", false
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return "", false
+	}
+	owner := metav1.GetControllerOfNoCopy(m)
+	if owner == nil || owner.Kind != "CronJob" {
+		return "", false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil || gv.Group != batchv1.GroupName {
+		return "", false
+	}
+	return owner.Name, true
+}
+
+// checkAllNamespacesAccess returns a forbidden error unless the kubewatcher
+// may watch every resource type of the trigger in all namespaces.
+func checkAllNamespacesAccess(ctx context.Context, kubeClient kubernetes.Interface, w *fv1.KubernetesWatchTrigger) error {
+	for _, objType := range watchTypes(w) {
+		err := checkTypeAccess(ctx, kubeClient, objType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkTypeAccess(ctx context.Context, kubeClient kubernetes.Interface, objType string) error {
+	group, resource := "", ""
+	switch objType {
+	case "POD":
+		resource = "pods"
+	case "SERVICE":
+		resource = "services"
+	case "REPLICATIONCONTROLLER":
+		resource = "replicationcontrollers"
+	case "JOB":
+		group, resource = "batch", "jobs"
+	case "CRONJOB":
+		group, resource = "batch", "cronjobs"
+	case "EVENT":
+		resource = "events"
+	case "ENDPOINTS":
+		resource = "endpoints"
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("Error: unknown obj type '%v'", objType))
+	}
+	review, err := kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: metav1.NamespaceAll,
+				Verb:      "watch",
+				Group:     group,
+				Resource:  resource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error checking access to %s in all namespaces: %w", resource, err)
+	}
+	if !review.Status.Allowed {
+		return errors.NewForbidden(schema.GroupResource{Group: group, Resource: resource}, "",
+			fmt.Errorf("kubewatcher is not allowed to watch %s in all namespaces", resource))
+	}
+	return nil
+}
+
+// createKubernetesWatch starts a watch of objType. An attempt the API server
+// doesn't answer within attemptTimeout is abandoned, so that it can be retried.
+func createKubernetesWatch(ctx context.Context, kubeClient kubernetes.Interface, w *fv1.KubernetesWatchTrigger, objType string, resourceVersion string,
+	attemptTimeout time.Duration) (watch.Interface, error) {
 	var watchTimeoutSec int64 = 120
 
 	// TODO populate labelselector and fieldselector
@@ -105,88 +464,689 @@ func createKubernetesWatch(ctx context.Context, kubeClient kubernetes.Interface,
 		TimeoutSeconds:  &watchTimeoutSec,
 	}
 
-	// TODO handle the full list of types
-	switch strings.ToUpper(w.Spec.Type) {
+	namespace := watchNamespace(w)
+	return watchWithTimeout(ctx, attemptTimeout, objType, func(ctx context.Context) (watch.Interface, error) {
+		// TODO handle the full list of types
+		switch strings.ToUpper(objType) {
+		case "POD":
+			return kubeClient.CoreV1().Pods(namespace).Watch(ctx, listOptions)
+		case "SERVICE":
+			return kubeClient.CoreV1().Services(namespace).Watch(ctx, listOptions)
+		case "REPLICATIONCONTROLLER":
+			return kubeClient.CoreV1().ReplicationControllers(namespace).Watch(ctx, listOptions)
+		case "JOB":
+			return kubeClient.BatchV1().Jobs(namespace).Watch(ctx, listOptions)
+		case "CRONJOB":
+			return kubeClient.BatchV1().CronJobs(namespace).Watch(ctx, listOptions)
+		case "EVENT":
+			listOptions.FieldSelector = eventFieldSelector(w)
+			return kubeClient.CoreV1().Events(namespace).Watch(ctx, listOptions)
+		case "ENDPOINTS":
+			return kubeClient.CoreV1().Endpoints(namespace).Watch(ctx, listOptions)
+		default:
+			return nil, errors.NewBadRequest(fmt.Sprintf("Error: unknown obj type '%v'", objType))
+		}
+	})
+}
+
+// watchWithTimeout starts a watch with start, giving up on it with a timeout
+// error if it isn't started within timeout. The context of a watch also ends
+// its result stream, so only starting it is bounded: the watch returned runs
+// until it is stopped or ctx is done.
+func watchWithTimeout(ctx context.Context, timeout time.Duration, objType string,
+	start func(ctx context.Context) (watch.Interface, error)) (watch.Interface, error) {
+	type result struct {
+		wi  watch.Interface
+		err error
+	}
+	watchCtx, cancel := context.WithCancel(ctx)
+	started := make(chan result, 1)
+	go func() {
+		wi, err := start(watchCtx)
+		started <- result{wi, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-started:
+		if r.err != nil {
+			cancel()
+			return nil, r.err
+		}
+		return &cancelOnStopWatch{Interface: r.wi, cancel: cancel}, nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	cancel()
+	go func() {
+		// the abandoned attempt may still start a watch
+		if r := <-started; r.wi != nil {
+			r.wi.Stop()
+		}
+	}()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, errors.NewTimeoutError(fmt.Sprintf("starting the watch of %s took longer than %v", objType, timeout), 0)
+}
+
+// cancelOnStopWatch releases the context of the watch once it is stopped.
+type cancelOnStopWatch struct {
+	watch.Interface
+	cancel context.CancelFunc
+}
+
+func (w *cancelOnStopWatch) Stop() {
+	w.Interface.Stop()
+	w.cancel()
+}
+
+// eventFieldSelector skips the high volume of Normal events unless the
+// trigger opts in to them, and selects the events of the
+// EventInvolvedObjectKind and EventReason of the trigger.
+func eventFieldSelector(w *fv1.KubernetesWatchTrigger) string {
+	var selectors []fields.Selector
+	if !w.Spec.IncludeNormalEvents {
+		selectors = append(selectors, fields.OneTermNotEqualSelector("type", apiv1.EventTypeNormal))
+	}
+	if len(w.Spec.EventInvolvedObjectKind) > 0 {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.kind", w.Spec.EventInvolvedObjectKind))
+	}
+	if len(w.Spec.EventReason) > 0 {
+		selectors = append(selectors, fields.OneTermEqualSelector("reason", w.Spec.EventReason))
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+// getCurrentResourceVersion lists the watched resource type to find the
+// latest resource version, so that a watch can be resumed from "now"
+// without replaying every existing object as an Added event.
+func getCurrentResourceVersion(ctx context.Context, kubeClient kubernetes.Interface, w *fv1.KubernetesWatchTrigger, objType string) (string, error) {
+	var list metav1.ListInterface
+	var err error
+
+	// only the list metadata is needed here
+	listOptions := metav1.ListOptions{
+		Limit: 1,
+	}
+
+	namespace := watchNamespace(w)
+	switch strings.ToUpper(objType) {
 	case "POD":
-		wi, err = kubeClient.CoreV1().Pods(w.Spec.Namespace).Watch(ctx, listOptions)
+		list, err = kubeClient.CoreV1().Pods(namespace).List(ctx, listOptions)
 	case "SERVICE":
-		wi, err = kubeClient.CoreV1().Services(w.Spec.Namespace).Watch(ctx, listOptions)
+		list, err = kubeClient.CoreV1().Services(namespace).List(ctx, listOptions)
 	case "REPLICATIONCONTROLLER":
-		wi, err = kubeClient.CoreV1().ReplicationControllers(w.Spec.Namespace).Watch(ctx, listOptions)
+		list, err = kubeClient.CoreV1().ReplicationControllers(namespace).List(ctx, listOptions)
 	case "JOB":
-		wi, err = kubeClient.BatchV1().Jobs(w.Spec.Namespace).Watch(ctx, listOptions)
+		list, err = kubeClient.BatchV1().Jobs(namespace).List(ctx, listOptions)
+	case "CRONJOB":
+		list, err = kubeClient.BatchV1().CronJobs(namespace).List(ctx, listOptions)
+	case "EVENT":
+		listOptions.FieldSelector = eventFieldSelector(w)
+		list, err = kubeClient.CoreV1().Events(namespace).List(ctx, listOptions)
+	case "ENDPOINTS":
+		list, err = kubeClient.CoreV1().Endpoints(namespace).List(ctx, listOptions)
 	default:
-		err = errors.NewBadRequest(fmt.Sprintf("Error: unknown obj type '%v'", w.Spec.Type))
+		err = errors.NewBadRequest(fmt.Sprintf("Error: unknown obj type '%v'", objType))
+	}
+	if err != nil {
+		return "", err
 	}
-	return wi, err
+	return list.GetResourceVersion(), nil
 }
 
 func (kw *KubeWatcher) addWatch(ctx context.Context, w *fv1.KubernetesWatchTrigger) error {
+	if w.Spec.Paused {
+		kw.logger.Info("watch is paused, not starting it", zap.String("name", w.ObjectMeta.Name), zap.Any("function", w.Spec.FunctionReference))
+		return nil
+	}
 	kw.logger.Info("adding watch", zap.String("name", w.ObjectMeta.Name), zap.Any("function", w.Spec.FunctionReference))
-	ws, err := MakeWatchSubscription(ctx, kw.logger.Named("watchsubscription"), w, kw.kubernetesClient, kw.publisher)
+	ws, err := kw.makeSubscription(ctx, w)
+	kw.watchesLock.Lock()
+	defer kw.watchesLock.Unlock()
 	if err != nil {
+		kw.markDead(w)
 		return err
 	}
-	kw.watches[w.ObjectMeta.UID] = *ws
+	delete(kw.dead, w.ObjectMeta.UID)
+	if old, ok := kw.watches[w.ObjectMeta.UID]; ok {
+		// a reload and the informer may both add the same trigger
+		old.stop()
+	}
+	kw.watches[w.ObjectMeta.UID] = ws
 	return nil
 }
 
+// makeSubscription starts a subscription for the trigger, publishing to its sink.
+func (kw *KubeWatcher) makeSubscription(ctx context.Context, w *fv1.KubernetesWatchTrigger) (*watchSubscription, error) {
+	pub := kw.publisher
+	if w.Spec.Sink == fv1.KubernetesWatchSinkMQTopic {
+		if kw.topicPublisher == nil {
+			return nil, fmt.Errorf("watch %s has sink %s but no message queue is configured", w.ObjectMeta.Name, w.Spec.Sink)
+		}
+		pub = kw.topicPublisher
+	}
+	return MakeWatchSubscription(ctx, kw.logger.Named("watchsubscription"), w, kw.kubernetesClient, pub, kw.recorder,
+		kw.watchTimeout, kw.watchAttemptTimeout, kw.getPublishSem(w.ObjectMeta.Namespace), kw.bufferSize, kw.bufferPolicy)
+}
+
 func (kw *KubeWatcher) removeWatch(w *fv1.KubernetesWatchTrigger) error {
 	kw.logger.Info("removing watch", zap.String("name", w.ObjectMeta.Name), zap.Any("function", w.Spec.FunctionReference))
+	kw.watchesLock.Lock()
+	_, dead := kw.dead[w.ObjectMeta.UID]
+	delete(kw.dead, w.ObjectMeta.UID)
 	ws, ok := kw.watches[w.ObjectMeta.UID]
 	if !ok {
+		kw.watchesLock.Unlock()
+		if dead {
+			return nil
+		}
 		return ferror.MakeError(ferror.ErrorNotFound,
 			fmt.Sprintf("watch doesn't exist: %v", w.ObjectMeta))
 	}
 	delete(kw.watches, w.ObjectMeta.UID)
+	kw.watchesLock.Unlock()
 	ws.stop()
 	return nil
 }
 
-func MakeWatchSubscription(ctx context.Context, logger *zap.Logger, w *fv1.KubernetesWatchTrigger, kubeClient kubernetes.Interface, publisher publisher.Publisher) (*watchSubscription, error) {
+// pauseWatch stops the dispatch loop of a subscription without removing it,
+// so that resumeWatch continues from its last resource version.
+func (kw *KubeWatcher) pauseWatch(uid types.UID) error {
+	kw.watchesLock.Lock()
+	defer kw.watchesLock.Unlock()
+	ws, ok := kw.watches[uid]
+	if !ok {
+		return ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("watch doesn't exist: %v", uid))
+	}
+	if ws.paused {
+		return nil
+	}
+	kw.logger.Info("pausing watch", zap.String("name", ws.watch.ObjectMeta.Name))
+	ws.paused = true
+	ws.stop()
+	return nil
+}
+
+// resumeWatch restarts the watch of a subscription paused by pauseWatch from
+// its last resource version and relaunches its dispatch loop. The
+// watchesLock is not held while the old dispatch loop winds down and the
+// watch restarts, which may take a while.
+func (kw *KubeWatcher) resumeWatch(ctx context.Context, uid types.UID) error {
+	kw.watchesLock.Lock()
+	ws, ok := kw.watches[uid]
+	if !ok {
+		kw.watchesLock.Unlock()
+		return ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("watch doesn't exist: %v", uid))
+	}
+	if !ws.paused || ws.resuming {
+		kw.watchesLock.Unlock()
+		return nil
+	}
+	ws.resuming = true
+	kw.watchesLock.Unlock()
+
+	kw.logger.Info("resuming watch", zap.String("name", ws.watch.ObjectMeta.Name))
+	// the old dispatch loop must be gone before the subscription is reused
+	<-ws.done
+	atomic.StoreInt32(ws.stopped, 0)
+	atomic.StoreInt32(&ws.failures, 0)
+	err := ws.restartWatch(ctx, restartReasonResume)
+
+	kw.watchesLock.Lock()
+	defer kw.watchesLock.Unlock()
+	ws.resuming = false
+	if kw.watches[uid] != ws {
+		// the watch was removed or replaced meanwhile
+		ws.stop()
+		return nil
+	}
+	if err != nil {
+		atomic.StoreInt32(ws.stopped, 1)
+		if !isRecoverableWatchError(err) {
+			ws.watchUnrecoverable(err)
+		}
+		return err
+	}
+	ws.paused = false
+	ws.start(ctx)
+	return nil
+}
+
+// updateWatch replaces the subscription of a watch trigger whose spec has changed,
+// so that e.g. a new label selector or resource type takes effect.
+func (kw *KubeWatcher) updateWatch(ctx context.Context, oldW *fv1.KubernetesWatchTrigger, newW *fv1.KubernetesWatchTrigger) error {
+	if reflect.DeepEqual(oldW.Spec, newW.Spec) {
+		return nil
+	}
+	kw.logger.Info("updating watch", zap.String("name", newW.ObjectMeta.Name), zap.Any("function", newW.Spec.FunctionReference))
+	err := kw.removeWatch(oldW)
+	if err != nil && !oldW.Spec.Paused {
+		kw.logger.Warn("error removing watch before update", zap.Error(err), zap.String("name", oldW.ObjectMeta.Name))
+	}
+	return kw.addWatch(ctx, newW)
+}
+
+// Reload makes the watches match triggers. Watches of triggers that no longer
+// exist are removed, new triggers are added and triggers whose spec changed
+// are updated. Unchanged watches keep running from their last resource
+// version, so no events are replayed.
+func (kw *KubeWatcher) Reload(ctx context.Context, triggers []fv1.KubernetesWatchTrigger) {
+	kw.watchesLock.RLock()
+	current := make(map[types.UID]fv1.KubernetesWatchTrigger, len(kw.watches)+len(kw.dead))
+	for uid, ws := range kw.watches {
+		current[uid] = ws.watch
+	}
+	// the watchdog keeps recreating the watches that failed to start
+	for uid, d := range kw.dead {
+		current[uid] = d.trigger
+	}
+	kw.watchesLock.RUnlock()
+
+	var added, updated, removed int
+	for i := range triggers {
+		w := &triggers[i]
+		old, ok := current[w.ObjectMeta.UID]
+		delete(current, w.ObjectMeta.UID)
+		if !ok {
+			if w.Spec.Paused {
+				continue
+			}
+			if err := kw.addWatch(ctx, w); err != nil {
+				kw.logger.Error("error adding watch on reload", zap.Error(err), zap.String("name", w.ObjectMeta.Name))
+				continue
+			}
+			added++
+			continue
+		}
+		if reflect.DeepEqual(old.Spec, w.Spec) {
+			continue
+		}
+		if err := kw.updateWatch(ctx, &old, w); err != nil {
+			kw.logger.Error("error updating watch on reload", zap.Error(err), zap.String("name", w.ObjectMeta.Name))
+			continue
+		}
+		updated++
+	}
+	for _, old := range current {
+		if err := kw.removeWatch(&old); err != nil {
+			kw.logger.Error("error removing watch on reload", zap.Error(err), zap.String("name", old.ObjectMeta.Name))
+			continue
+		}
+		removed++
+	}
+	kw.logger.Info("reloaded watches", zap.Int("added", added), zap.Int("updated", updated), zap.Int("removed", removed))
+}
+
+func MakeWatchSubscription(ctx context.Context, logger *zap.Logger, w *fv1.KubernetesWatchTrigger, kubeClient kubernetes.Interface, publisher publisher.Publisher,
+	recorder record.EventRecorder, watchTimeout time.Duration, watchAttemptTimeout time.Duration, publishSem chan struct{}, bufferSize int,
+	bufferPolicy EventBufferPolicy) (*watchSubscription, error) {
+	filter, err := makeEventFilter(w.Spec.Filter, w.Spec.FilterValue)
+	if err != nil {
+		return nil, err
+	}
+
+	logger, err = subscriptionLogger(logger, w.Spec.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
 	var stopped int32 = 0
 	ws := &watchSubscription{
-		logger:              logger.Named("watch_subscription"),
-		watch:               *w,
-		kubeWatch:           nil,
-		stopped:             &stopped,
-		kubernetesClient:    kubeClient,
-		publisher:           publisher,
-		lastResourceVersion: "",
+		logger:               logger,
+		watch:                *w,
+		kubeWatch:            nil,
+		stopped:              &stopped,
+		kubernetesClient:     kubeClient,
+		publisher:            publisher,
+		recorder:             recorder,
+		types:                watchTypes(w),
+		ownedJobsOnly:        watchesOwnedJobsOnly(w),
+		lastResourceVersions: make(map[string]string),
+		watchTimeout:         watchTimeout,
+		watchAttemptTimeout:  watchAttemptTimeout,
+		publishSem:           publishSem,
+		bufferSize:           bufferSize,
+		bufferPolicy:         bufferPolicy,
+		filter:               filter,
+	}
+	if w.Spec.IncludeOldObject {
+		ws.tracker = makeObjectTracker(maxTrackedObjects)
+	}
+	if len(w.Spec.PruneFields) > 0 {
+		ws.pruneFields = parsePruneFields(w.Spec.PruneFields)
+	}
+	ws.transform, err = makeEventTransform(w.Spec.Transform)
+	if err != nil {
+		return nil, err
+	}
+	ws.publishOptions.Method = w.Spec.PublishMethod
+	if len(w.Spec.PublishTimeout) > 0 {
+		ws.publishOptions.Timeout, err = time.ParseDuration(w.Spec.PublishTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publish timeout %q: %w", w.Spec.PublishTimeout, err)
+		}
+	}
+	if len(w.Spec.MaxEventAge) > 0 {
+		ws.maxEventAge, err = time.ParseDuration(w.Spec.MaxEventAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max event age %q: %w", w.Spec.MaxEventAge, err)
+		}
 	}
 
-	err := ws.restartWatch(ctx)
+	ws.headers, err = ws.resolveHeaders(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// events sent to a topic don't reach the function
+	if w.Spec.CircuitBreakerThreshold > 0 && w.Spec.Sink != fv1.KubernetesWatchSinkMQTopic {
+		cooldown := fv1.DefaultCircuitBreakerCooldown
+		if len(w.Spec.CircuitBreakerCooldown) > 0 {
+			cooldown, err = time.ParseDuration(w.Spec.CircuitBreakerCooldown)
+			if err != nil {
+				return nil, fmt.Errorf("invalid circuit breaker cooldown %q: %w", w.Spec.CircuitBreakerCooldown, err)
+			}
+		}
+		ws.breaker = makeCircuitBreaker(w.Spec.CircuitBreakerThreshold, cooldown)
+		ws.publishOptions.Done = ws.publishDone
+	}
 
-	go ws.eventDispatchLoop(ctx)
+	if w.Spec.AllNamespaces {
+		err = checkAllNamespacesAccess(ctx, kubeClient, w)
+		if err != nil {
+			if errors.IsForbidden(err) {
+				ws.watchForbidden(err)
+			}
+			return nil, err
+		}
+	}
+
+	err = ws.seedResourceVersions(ctx)
+	if err != nil {
+		if errors.IsForbidden(err) {
+			ws.watchForbidden(err)
+		}
+		return nil, err
+	}
+
+	err = ws.restartWatch(ctx, restartReasonInitial)
+	if err != nil {
+		if !isRecoverableWatchError(err) {
+			ws.watchUnrecoverable(err)
+		}
+		return nil, err
+	}
+
+	ws.start(ctx)
 	return ws, nil
 }
 
-func (ws *watchSubscription) restartWatch(ctx context.Context) error {
-	retries := 60
-	for {
+// resolveHeaders returns the Headers of the trigger along with the values of
+// its HeadersFromSecrets, read from the secrets in the namespace of the trigger.
+func (ws *watchSubscription) resolveHeaders(ctx context.Context) (map[string]string, error) {
+	headers := make(map[string]string, len(ws.watch.Spec.Headers)+len(ws.watch.Spec.HeadersFromSecrets))
+	for name, value := range ws.watch.Spec.Headers {
+		headers[http.CanonicalHeaderKey(name)] = value
+	}
+	for name, ref := range ws.watch.Spec.HeadersFromSecrets {
+		optional := ref.Optional != nil && *ref.Optional
+		secret, err := ws.kubernetesClient.CoreV1().Secrets(ws.watch.ObjectMeta.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if optional && errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading secret %s for header %s: %w", ref.Name, name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			if optional {
+				continue
+			}
+			return nil, fmt.Errorf("secret %s has no key %s for header %s", ref.Name, ref.Key, name)
+		}
+		headers[http.CanonicalHeaderKey(name)] = string(value)
+	}
+	return headers, nil
+}
+
+// redactHeaders returns a copy of headers for logging, with the values of
+// the trigger's own headers, which may come from secrets, hidden.
+func (ws *watchSubscription) redactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if _, ok := ws.headers[name]; ok {
+			value = "<redacted>"
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// seedResourceVersions sets the resource versions the watches start from
+// according to the ResourceVersion of the trigger.
+func (ws *watchSubscription) seedResourceVersions(ctx context.Context) error {
+	switch ws.watch.Spec.ResourceVersion {
+	case "":
+	case fv1.KubernetesWatchResourceVersionNow:
+		for _, objType := range ws.types {
+			rv, err := getCurrentResourceVersion(ctx, ws.kubernetesClient, &ws.watch, objType)
+			if err != nil {
+				return fmt.Errorf("error getting the current resource version of %s %s: %w", objType, watchScope(&ws.watch), err)
+			}
+			ws.lastResourceVersions[objType] = rv
+		}
+	default:
+		for _, objType := range ws.types {
+			ws.lastResourceVersions[objType] = ws.watch.Spec.ResourceVersion
+		}
+	}
+	return nil
+}
+
+// start launches the dispatch and publish loops of the subscription.
+func (ws *watchSubscription) start(ctx context.Context) {
+	ws.publishQueue = make(chan publishEvent, ws.bufferSize)
+	ws.done = make(chan struct{})
+	go ws.publishLoop(ctx, ws.publishQueue)
+	go ws.eventDispatchLoop(ctx)
+}
+
+// typeNames lists the watched resource types, for messages.
+func (ws *watchSubscription) typeNames() string {
+	return strings.Join(ws.types, ", ")
+}
+
+// createWatches starts a watch per resource type, each from its last resource
+// version. Several watches are multiplexed into one, so that the dispatch loop
+// handles the events of all types.
+func (ws *watchSubscription) createWatches(ctx context.Context) (watch.Interface, error) {
+	watches := make([]watch.Interface, 0, len(ws.types))
+	for _, objType := range ws.types {
+		wi, err := createKubernetesWatch(ctx, ws.kubernetesClient, &ws.watch, objType, ws.lastResourceVersions[objType], ws.watchAttemptTimeout)
+		if err != nil {
+			for _, started := range watches {
+				started.Stop()
+			}
+			return nil, err
+		}
+		watches = append(watches, wi)
+	}
+	if len(watches) == 1 {
+		return watches[0], nil
+	}
+	return newMultiWatch(watches), nil
+}
+
+func (ws *watchSubscription) restartWatch(ctx context.Context, reason string) error {
+	IncreaseWatchRestarts(ws.watch.ObjectMeta.Name, ws.watch.ObjectMeta.Namespace, reason)
+	deadline := time.Now().Add(ws.watchTimeout)
+	for attempt := 1; ; attempt++ {
 		ws.logger.Info("(re)starting watch",
 			zap.Any("watch", ws.watch.ObjectMeta),
-			zap.String("namespace", ws.watch.Spec.Namespace),
-			zap.String("type", ws.watch.Spec.Type),
-			zap.String("last_resource_version", ws.lastResourceVersion))
-		wi, err := createKubernetesWatch(ctx, ws.kubernetesClient, &ws.watch, ws.lastResourceVersion)
+			zap.String("namespace", watchNamespace(&ws.watch)),
+			zap.Strings("types", ws.types),
+			zap.Any("last_resource_versions", ws.lastResourceVersions))
+		wi, err := ws.createWatches(ctx)
 		if err != nil {
-			retries--
-			if retries > 0 {
-				time.Sleep(500 * time.Millisecond)
+			if errors.IsForbidden(err) {
+				// retrying is pointless until the missing permissions are granted
+				atomic.StoreInt32(&ws.healthy, 0)
+				return fmt.Errorf("not allowed to watch %s %s: %w", ws.typeNames(), watchScope(&ws.watch), err)
+			}
+			if !isRecoverableWatchError(err) {
+				atomic.StoreInt32(&ws.healthy, 0)
+				return fmt.Errorf("cannot watch %s %s: %w", ws.typeNames(), watchScope(&ws.watch), err)
+			}
+			if ctx.Err() != nil {
+				atomic.StoreInt32(&ws.healthy, 0)
+				return fmt.Errorf("stopped (re)starting watch: %w", ctx.Err())
+			}
+			if time.Now().Add(watchRetryInterval).Before(deadline) {
+				ws.logger.Warn("failed to (re)start watch, retrying", zap.Error(err),
+					zap.String("watch_name", ws.watch.ObjectMeta.Name), zap.Int("attempt", attempt))
+				time.Sleep(watchRetryInterval)
 				continue
-			} else {
-				return err
 			}
+			atomic.StoreInt32(&ws.healthy, 0)
+			return fmt.Errorf("error creating watch after %d attempts: %w", attempt, err)
 		}
 		ws.kubeWatch = wi
+		atomic.StoreInt32(&ws.healthy, 1)
 		return nil
 	}
 }
 
+// resumeFromCurrent relists the watched resources after a watch error, to get
+// around "too old resource version" and resume from the current resource
+// versions. If that fails, the watches start from the beginning, which replays
+// all existing objects, unless the trigger has SkipReplayOnError: then the
+// relist is retried, and changes made meanwhile are missed. It returns false
+// if the subscription was stopped or keeps failing to relist.
+func (ws *watchSubscription) resumeFromCurrent(ctx context.Context) bool {
+	for _, objType := range ws.types {
+		for {
+			rv, err := getCurrentResourceVersion(ctx, ws.kubernetesClient, &ws.watch, objType)
+			if err == nil {
+				ws.lastResourceVersions[objType] = rv
+				break
+			}
+			if !ws.watch.Spec.SkipReplayOnError {
+				ws.logger.Warn("failed to relist watched resources - replaying all objects", zap.Error(err),
+					zap.String("watch_name", ws.watch.ObjectMeta.Name), zap.String("type", objType))
+				ws.lastResourceVersions[objType] = ""
+				break
+			}
+			if !ws.watchFailed(err) {
+				return false
+			}
+			ws.logger.Warn("failed to relist watched resources - retrying without replaying", zap.Error(err),
+				zap.String("watch_name", ws.watch.ObjectMeta.Name), zap.String("type", objType))
+			if ws.isStopped() || ctx.Err() != nil {
+				return false
+			}
+			time.Sleep(watchRetryInterval)
+		}
+	}
+	return true
+}
+
+// restartWatchUntilStopped restarts the watch until it succeeds. It returns
+// false if the subscription was stopped in the meantime, or if the watch fails
+// with an unrecoverable error or keeps failing, in which case the subscription
+// stays failed.
+func (ws *watchSubscription) restartWatchUntilStopped(ctx context.Context, reason string) bool {
+	for {
+		err := ws.restartWatch(ctx, reason)
+		if err == nil {
+			return true
+		}
+		if !isRecoverableWatchError(err) {
+			ws.watchUnrecoverable(err)
+			return false
+		}
+		if !ws.watchFailed(err) {
+			return false
+		}
+		ws.logger.Error("failed to restart watch - retrying", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+		if ws.isStopped() || ctx.Err() != nil {
+			return false
+		}
+	}
+}
+
+// watchForbidden reports a watch the kubewatcher lacks RBAC permissions for.
+// It is not retried until the trigger is updated.
+func (ws *watchSubscription) watchForbidden(err error) {
+	ws.logger.Error("watch is forbidden - giving up until the trigger is updated", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+	ws.recordEvent("WatchForbidden", "kubewatcher is not allowed to watch %s %s, grant it list and watch permissions: %v",
+		ws.typeNames(), watchScope(&ws.watch), err)
+}
+
+// isRecoverableWatchError reports whether (re)starting a watch that failed
+// with err is worth retrying. Server errors, timeouts and network failures
+// are; requests the API server rejects as malformed, forbidden or for
+// resources that don't exist fail the same way on every attempt.
+func isRecoverableWatchError(err error) bool {
+	return !errors.IsBadRequest(err) && !errors.IsNotFound(err) && !errors.IsForbidden(err)
+}
+
+// watchUnrecoverable reports a watch that failed with an error retrying won't
+// fix, see isRecoverableWatchError. It is not retried until the trigger is
+// updated.
+func (ws *watchSubscription) watchUnrecoverable(err error) {
+	if errors.IsForbidden(err) {
+		ws.watchForbidden(err)
+		return
+	}
+	ws.logger.Error("watch is misconfigured - giving up until the trigger is updated", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+	ws.recordEvent("WatchMisconfigured", "cannot watch %s %s: %v", ws.typeNames(), watchScope(&ws.watch), err)
+}
+
+// watchFailed counts a failure of the watch. Once the watch failed
+// maxWatchFailures times in a row without delivering an event, it marks the
+// subscription failed and returns false; the watch is then not retried until
+// the trigger is updated.
+func (ws *watchSubscription) watchFailed(err error) bool {
+	failures := atomic.AddInt32(&ws.failures, 1)
+	if failures < maxWatchFailures {
+		return true
+	}
+	atomic.StoreInt32(&ws.healthy, 0)
+	ws.logger.Error("watch keeps failing without delivering events - giving up until the trigger is updated",
+		zap.Error(err), zap.Int32("failures", failures), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+	ws.recordEvent("WatchFailing", "watch of %s %s failed %d times in a row without delivering an event: %v",
+		ws.typeNames(), watchScope(&ws.watch), failures, err)
+	return false
+}
+
+// eventDelivered resets the failures of the watch once one of its events is
+// delivered.
+func (ws *watchSubscription) eventDelivered() {
+	atomic.StoreInt32(&ws.failures, 0)
+}
+
+// recordEvent emits a warning event on the trigger, if a recorder is set.
+func (ws *watchSubscription) recordEvent(reason string, messageFmt string, args ...interface{}) {
+	if ws.recorder == nil {
+		return
+	}
+	ref := &apiv1.ObjectReference{
+		Kind:            "KubernetesWatchTrigger",
+		APIVersion:      fv1.SchemeGroupVersion.String(),
+		Name:            ws.watch.ObjectMeta.Name,
+		Namespace:       ws.watch.ObjectMeta.Namespace,
+		UID:             ws.watch.ObjectMeta.UID,
+		ResourceVersion: ws.watch.ObjectMeta.ResourceVersion,
+	}
+	ws.recorder.Eventf(ref, apiv1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+func (ws *watchSubscription) isHealthy() bool {
+	return atomic.LoadInt32(&ws.healthy) == 1
+}
+
 func getResourceVersion(obj runtime.Object) (string, error) {
 	m, err := meta.Accessor(obj)
 	if err != nil {
@@ -197,6 +1157,8 @@ func getResourceVersion(obj runtime.Object) (string, error) {
 
 func (ws *watchSubscription) eventDispatchLoop(ctx context.Context) {
 	ws.logger.Info("listening to watch", zap.String("name", ws.watch.ObjectMeta.Name))
+	defer close(ws.done)
+	defer close(ws.publishQueue)
 	for {
 		// check watchSubscription is stopped or not before waiting for event
 		// comes from the kubeWatch.ResultChan(). This fix the edge case that
@@ -214,9 +1176,8 @@ func (ws *watchSubscription) eventDispatchLoop(ctx context.Context) {
 			} else {
 				// watch closed due to timeout, restart it.
 				ws.logger.Warn("watch timed out - restarting", zap.String("watch_name", ws.watch.ObjectMeta.Name))
-				err := ws.restartWatch(ctx)
-				if err != nil {
-					ws.logger.Panic("failed to restart watch", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+				if !ws.restartWatchUntilStopped(ctx, restartReasonTimeout) {
+					return
 				}
 				continue
 			}
@@ -224,21 +1185,31 @@ func (ws *watchSubscription) eventDispatchLoop(ctx context.Context) {
 
 		if ev.Type == watch.Error {
 			e := errors.FromObject(ev.Object)
+			if !ws.watchFailed(e) {
+				return
+			}
 			ws.logger.Warn("watch error - retrying after one second", zap.Error(e), zap.String("watch_name", ws.watch.ObjectMeta.Name))
-			// Start from the beginning to get around "too old resource version"
-			ws.lastResourceVersion = ""
 			time.Sleep(time.Second)
-			err := ws.restartWatch(ctx)
-			if err != nil {
-				ws.logger.Panic("failed to restart watch", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			if !ws.resumeFromCurrent(ctx) {
+				return
+			}
+			if !ws.restartWatchUntilStopped(ctx, restartReasonWatchError) {
+				return
 			}
 			continue
 		}
+		objectType := reflect.TypeOf(ev.Object).Elem().Name()
 		rv, err := getResourceVersion(ev.Object)
 		if err != nil {
 			ws.logger.Error("error getting resourceVersion from object", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
 		} else {
-			ws.lastResourceVersion = rv
+			ws.lastResourceVersions[strings.ToUpper(objectType)] = rv
+		}
+
+		cronJob, ownedByCronJob := ownerCronJob(ev.Object)
+		if ws.ownedJobsOnly && objectType == "Job" && !ownedByCronJob {
+			ws.logger.Debug("job is not owned by a cronjob - skipping", zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			continue
 		}
 
 		// Serialize the object
@@ -249,27 +1220,318 @@ func (ws *watchSubscription) eventDispatchLoop(ctx context.Context) {
 			// TODO send a POST request indicating error
 		}
 
+		// track every event, even filtered ones, so the old object is the last state
+		var oldObject []byte
+		if ws.tracker != nil {
+			oldObject, err = ws.tracker.update(ev)
+			if err != nil {
+				ws.logger.Error("failed to track object", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			}
+		}
+
+		if ws.isTooOld(ev) {
+			ws.logger.Debug("object is older than the max event age - skipping", zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			continue
+		}
+
+		match, err := ws.filter.match(buf.Bytes())
+		if err != nil {
+			ws.logger.Error("failed to evaluate filter - skipping event", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			continue
+		}
+		if !match {
+			ws.logger.Debug("event does not match filter - skipping", zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			continue
+		}
+
+		// prune after filtering, so the filter can use the pruned fields
+		if ws.pruneFields != nil {
+			pruned, err := prunedObject(ev.Object, ws.pruneFields)
+			if err != nil {
+				ws.logger.Error("failed to prune object - sending it whole", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			} else {
+				buf.Reset()
+				buf.Write(pruned)
+			}
+			if oldObject != nil {
+				oldObject, err = prunedJSON(oldObject, ws.pruneFields)
+				if err != nil {
+					ws.logger.Error("failed to prune old object - not sending it", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+				}
+			}
+		}
+
+		if ws.transform != nil {
+			transformed, err := ws.transform.apply(buf.Bytes())
+			if err != nil {
+				ws.logger.Error("failed to transform object - skipping event", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+				continue
+			}
+			buf.Reset()
+			buf.Write(transformed)
+		}
+
+		headers := make(map[string]string, len(ws.headers)+5)
+		for name, value := range ws.headers {
+			headers[name] = value
+		}
 		// Event and object type aren't in the serialized object
-		headers := map[string]string{
-			"Content-Type":             "application/json",
-			"X-Kubernetes-Event-Type":  string(ev.Type),
-			"X-Kubernetes-Object-Type": reflect.TypeOf(ev.Object).Elem().Name(),
+		headers["Content-Type"] = "application/json"
+		headers["X-Kubernetes-Event-Type"] = string(ev.Type)
+		headers["X-Kubernetes-Object-Type"] = objectType
+		if oldObject != nil {
+			headers["X-Kubernetes-Old-Object"] = string(oldObject)
+		}
+		if ws.watch.Spec.IncludeOwnedJobs && ownedByCronJob {
+			headers["X-Kubernetes-Owner-CronJob"] = cronJob
+		}
+
+		body := buf.Bytes()
+		if ws.shouldCompress(len(body)) {
+			compressed, err := gzipBody(body)
+			if err != nil {
+				ws.logger.Error("failed to compress object - sending it uncompressed", zap.Error(err), zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			} else {
+				body = compressed
+				headers["Content-Encoding"] = "gzip"
+			}
 		}
 
-		// TODO support other function ref types. Or perhaps delegate to router?
-		if ws.watch.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionName {
-			ws.logger.Error("unsupported function ref type - cannot publish event",
-				zap.Any("type", ws.watch.Spec.FunctionReference.Type),
-				zap.String("watch_name", ws.watch.ObjectMeta.Name))
+		target, ok := ws.publishTarget()
+		if !ok {
 			continue
 		}
+		if ws.watch.Spec.DryRun {
+			ws.logger.Info("dry run - not publishing event",
+				zap.String("watch_name", ws.watch.ObjectMeta.Name),
+				zap.String("target", target),
+				zap.Any("headers", ws.redactHeaders(headers)),
+				zap.String("body", truncate(buf.String(), dryRunBodyLimit)))
+			// logging the event is all there is to deliver in dry run mode
+			ws.eventDelivered()
+			continue
+		}
+		ws.enqueue(publishEvent{body: body, headers: headers, target: target})
+	}
+}
 
-		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
-		// the triggers can only be created in the same namespace as the function.
-		// so essentially, function namespace = trigger namespace.
-		url := utils.UrlForFunction(ws.watch.Spec.FunctionReference.Name, ws.watch.ObjectMeta.Namespace)
-		ws.publisher.Publish(ctx, buf.String(), headers, url)
+// isTooOld returns true for an Added event of an object created longer than
+// maxEventAge ago. Other events are never too old.
+func (ws *watchSubscription) isTooOld(ev watch.Event) bool {
+	if ws.maxEventAge <= 0 || ev.Type != watch.Added {
+		return false
+	}
+	m, err := meta.Accessor(ev.Object)
+	if err != nil {
+		return false
 	}
+	created := m.GetCreationTimestamp()
+	return !created.IsZero() && time.Since(created.Time) > ws.maxEventAge
+}
+
+// publishTarget returns the topic or the function URL events are published to,
+// ok is false if the function reference is not supported.
+func (ws *watchSubscription) publishTarget() (target string, ok bool) {
+	if ws.watch.Spec.Sink == fv1.KubernetesWatchSinkMQTopic {
+		return ws.watch.Spec.Topic, true
+	}
+
+	// TODO support other function ref types. Or perhaps delegate to router?
+	if ws.watch.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionName {
+		ws.logger.Error("unsupported function ref type - cannot publish event",
+			zap.Any("type", ws.watch.Spec.FunctionReference.Type),
+			zap.String("watch_name", ws.watch.ObjectMeta.Name))
+		return "", false
+	}
+
+	// The function lives in the namespace of the trigger, which for a trigger
+	// watching all namespaces differs from the namespace of most events.
+	return utils.UrlForFunction(ws.watch.Spec.FunctionReference.Name, ws.watch.ObjectMeta.Namespace) + ws.watch.Spec.FunctionPath, true
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "...(truncated)"
+}
+
+// shouldCompress tells whether a body of the given size is gzipped.
+func (ws *watchSubscription) shouldCompress(size int) bool {
+	if !ws.watch.Spec.Gzip {
+		return false
+	}
+	minSize := ws.watch.Spec.GzipMinSize
+	if minSize <= 0 {
+		minSize = fv1.DefaultGzipMinSize
+	}
+	return size >= minSize
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(body)
+	if err != nil {
+		return nil, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// enqueue buffers an event for the publish loop. Once the buffer is full, it
+// either blocks, which stops reading from the watch, or drops the oldest
+// buffered event, depending on the buffer policy.
+func (ws *watchSubscription) enqueue(ev publishEvent) {
+	if ws.bufferPolicy != EventBufferDropOldest {
+		ws.publishQueue <- ev
+		return
+	}
+	for {
+		select {
+		case ws.publishQueue <- ev:
+			return
+		default:
+		}
+		// the publish loop may take an event meanwhile, then nothing is dropped
+		select {
+		case <-ws.publishQueue:
+			IncreaseEventsDropped(ws.watch.ObjectMeta.Name, ws.watch.ObjectMeta.Namespace)
+			ws.logger.Warn("event buffer is full - dropped the oldest event", zap.String("watch_name", ws.watch.ObjectMeta.Name))
+		default:
+		}
+	}
+}
+
+// publishLoop publishes the queued events, with at most cap(publishSem)
+// publishes in flight for the namespace of the trigger. Unless the trigger
+// has the Parallel concurrency policy, it waits for each publish to complete
+// before publishing the next event. Once the subscription is stopped or ctx
+// is done, the events left in the queue are dropped.
+func (ws *watchSubscription) publishLoop(ctx context.Context, queue <-chan publishEvent) {
+	serial := ws.watch.Spec.ConcurrencyPolicy != fv1.KubernetesWatchConcurrencyParallel
+	dropped := 0
+	defer func() {
+		if dropped > 0 {
+			ws.logger.Info("watch stopped - dropped queued events", zap.String("watch_name", ws.watch.ObjectMeta.Name), zap.Int("events", dropped))
+		}
+	}()
+	for ev := range queue {
+		if ws.isStopped() || ctx.Err() != nil {
+			IncreaseEventsDropped(ws.watch.ObjectMeta.Name, ws.watch.ObjectMeta.Namespace)
+			dropped++
+			continue
+		}
+		if ws.breaker != nil && !ws.breaker.allow() {
+			IncreaseEventsRejected(ws.watch.ObjectMeta.Name, ws.watch.ObjectMeta.Namespace)
+			ws.logger.Debug("circuit breaker is open - dropped event", zap.String("watch_name", ws.watch.ObjectMeta.Name))
+			continue
+		}
+		select {
+		case ws.publishSem <- struct{}{}:
+		case <-ctx.Done():
+			IncreaseEventsDropped(ws.watch.ObjectMeta.Name, ws.watch.ObjectMeta.Namespace)
+			dropped++
+			continue
+		}
+		if serial {
+			ws.publishAndWait(ctx, ev)
+			<-ws.publishSem
+			continue
+		}
+		ws.publishAsync(ctx, ev)
+	}
+}
+
+// publishAsync publishes an event without waiting for its outcome. The slot of
+// publishSem taken for the event is only released once the outcome is known,
+// as publishers like the webhook publisher merely queue the event. Events are
+// handed to the publisher in order; publishers that don't take PublishOptions
+// are assumed to publish synchronously and are run in the background. As they
+// don't report the outcome, they don't reset the failures of the watch.
+func (ws *watchSubscription) publishAsync(ctx context.Context, ev publishEvent) {
+	if _, ok := ws.publisher.(publisher.OptionsPublisher); !ok {
+		go func() {
+			defer func() { <-ws.publishSem }()
+			ws.publisher.Publish(ctx, ev.body, ev.headers, ev.target)
+		}()
+		return
+	}
+	opts := ws.timedPublishOptions()
+	var release sync.Once
+	done := opts.Done
+	opts.Done = func(err error) {
+		done(err)
+		release.Do(func() { <-ws.publishSem })
+	}
+	publisher.PublishWithOptions(ctx, ws.publisher, ev.body, ev.headers, ev.target, opts)
+}
+
+// publishAndWait publishes an event and returns once the outcome of the
+// publish is known, after any retries, or ctx is done. It waits at most as
+// long as the retries of the publish may take, bounded by maxPublishWait, so
+// that a publish that never completes doesn't stop the trigger. Publishers that don't
+// take PublishOptions are assumed to publish synchronously, they don't reset
+// the failures of the watch as they don't report the outcome.
+func (ws *watchSubscription) publishAndWait(ctx context.Context, ev publishEvent) {
+	if _, ok := ws.publisher.(publisher.OptionsPublisher); !ok {
+		ws.publisher.Publish(ctx, ev.body, ev.headers, ev.target)
+		return
+	}
+	opts := ws.timedPublishOptions()
+	finished := make(chan struct{})
+	done := opts.Done
+	opts.Done = func(err error) {
+		done(err)
+		close(finished)
+	}
+	publisher.PublishWithOptions(ctx, ws.publisher, ev.body, ev.headers, ev.target, opts)
+	wait := publisher.MaxWebhookPublishDuration(opts.Timeout)
+	if wait > maxPublishWait {
+		wait = maxPublishWait
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	case <-timer.C:
+		ws.logger.Warn("publish did not complete in time - publishing the next event",
+			zap.String("watch_name", ws.watch.ObjectMeta.Name), zap.Duration("waited", wait))
+	}
+}
+
+// timedPublishOptions returns the publishOptions of the trigger, with a Done
+// callback recording the duration of the publish starting now, and resetting
+// the failures of the watch if it succeeds.
+func (ws *watchSubscription) timedPublishOptions() publisher.PublishOptions {
+	opts := ws.publishOptions
+	start := time.Now()
+	done := opts.Done
+	opts.Done = func(err error) {
+		ObservePublishDuration(ws.watch.ObjectMeta.Name, ws.watch.ObjectMeta.Namespace, err, time.Since(start))
+		if err == nil {
+			ws.eventDelivered()
+		}
+		if done != nil {
+			done(err)
+		}
+	}
+	return opts
+}
+
+// publishDone takes the outcome of a publish for the circuit breaker.
+func (ws *watchSubscription) publishDone(err error) {
+	if !ws.breaker.record(err) {
+		return
+	}
+	ws.logger.Warn("function keeps failing - opened the circuit breaker", zap.Error(err),
+		zap.String("watch_name", ws.watch.ObjectMeta.Name), zap.Duration("cooldown", ws.breaker.cooldown))
+	ws.recordEvent("CircuitBreakerOpen", "function %s keeps failing, dropping events for %v: %v",
+		ws.watch.Spec.FunctionReference.Name, ws.breaker.cooldown, err)
 }
 
 func (ws *watchSubscription) stop() {
@@ -280,3 +1542,14 @@ func (ws *watchSubscription) stop() {
 func (ws *watchSubscription) isStopped() bool {
 	return atomic.LoadInt32(ws.stopped) == 1
 }
+
+// isDead reports whether the dispatch loop of the subscription gave up on
+// the watch, rather than being stopped.
+func (ws *watchSubscription) isDead() bool {
+	select {
+	case <-ws.done:
+		return !ws.isStopped()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,802 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/publisher"
+)
+
+func TestForbiddenWatchIsNotRetried(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+	})
+	recorder := record.NewFakeRecorder(1)
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod"},
+	}
+
+	start := time.Now()
+	_, err := MakeWatchSubscription(context.Background(), zap.NewNop(), w, kubeClient, nil, recorder, time.Minute, time.Second, make(chan struct{}, 1), 0, EventBufferBlock)
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > watchRetryInterval {
+		t.Errorf("forbidden watch was retried for %v", elapsed)
+	}
+
+	select {
+	case ev := <-recorder.Events:
+		if !strings.Contains(ev, "WatchForbidden") {
+			t.Errorf("unexpected event %q", ev)
+		}
+	default:
+		t.Error("expected an event on the trigger")
+	}
+}
+
+func TestUnrecoverableWatchIsNotRetried(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "gone")
+	})
+	recorder := record.NewFakeRecorder(1)
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "gone", Type: "pod"},
+	}
+
+	start := time.Now()
+	_, err := MakeWatchSubscription(context.Background(), zap.NewNop(), w, kubeClient, nil, recorder, time.Minute, time.Second, make(chan struct{}, 1), 0, EventBufferBlock)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > watchRetryInterval {
+		t.Errorf("unrecoverable watch was retried for %v", elapsed)
+	}
+
+	select {
+	case ev := <-recorder.Events:
+		if !strings.Contains(ev, "WatchMisconfigured") {
+			t.Errorf("unexpected event %q", ev)
+		}
+	default:
+		t.Error("expected an event on the trigger")
+	}
+}
+
+func TestIsRecoverableWatchError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+	for _, test := range []struct {
+		err         error
+		recoverable bool
+	}{
+		{apierrors.NewBadRequest("unknown type"), false},
+		{apierrors.NewNotFound(gr, "ns"), false},
+		{apierrors.NewForbidden(gr, "", nil), false},
+		{apierrors.NewInternalError(errors.New("boom")), true},
+		{apierrors.NewServiceUnavailable("unavailable"), true},
+		{apierrors.NewTimeoutError("timeout", 0), true},
+		{errors.New("connection refused"), true},
+	} {
+		if got := isRecoverableWatchError(test.err); got != test.recoverable {
+			t.Errorf("isRecoverableWatchError(%v) = %v, want %v", test.err, got, test.recoverable)
+		}
+	}
+}
+
+func TestAllNamespacesWatch(t *testing.T) {
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{AllNamespaces: true, Type: "pod"},
+	}
+
+	// the fake clientset denies every access review
+	kubeClient := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(1)
+	_, err := MakeWatchSubscription(context.Background(), zap.NewNop(), w, kubeClient, nil, recorder, time.Minute, time.Second, make(chan struct{}, 1), 0, EventBufferBlock)
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+	if len(recorder.Events) != 1 {
+		t.Error("expected an event on the trigger")
+	}
+
+	kubeClient = fake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	watchNamespaces := make(chan string, 1)
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watchNamespaces <- action.GetNamespace()
+		return true, watch.NewFake(), nil
+	})
+	ws, err := MakeWatchSubscription(context.Background(), zap.NewNop(), w, kubeClient, nil, record.NewFakeRecorder(1), time.Minute, time.Second, make(chan struct{}, 1), 0, EventBufferBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.stop()
+	if ns := <-watchNamespaces; ns != metav1.NamespaceAll {
+		t.Errorf("expected a watch in all namespaces, got namespace %q", ns)
+	}
+}
+
+func TestWatchFailedGivesUp(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	ws := &watchSubscription{logger: zap.NewNop(), recorder: recorder, healthy: 1}
+	for i := 1; i < maxWatchFailures; i++ {
+		if !ws.watchFailed(errors.New("watch error")) {
+			t.Fatalf("watch given up after %d failures", i)
+		}
+	}
+
+	// a failed publish doesn't reset the budget, delivering an event does
+	ws.timedPublishOptions().Done(errors.New("publish error"))
+	if ws.failures != maxWatchFailures-1 {
+		t.Fatalf("expected a failed publish not to reset the failures, got %d", ws.failures)
+	}
+	ws.timedPublishOptions().Done(nil)
+	for i := 1; i < maxWatchFailures; i++ {
+		ws.watchFailed(errors.New("watch error"))
+	}
+	if !ws.isHealthy() {
+		t.Error("expected watch to be healthy before exhausting its failures")
+	}
+
+	if ws.watchFailed(errors.New("watch error")) {
+		t.Fatalf("expected watch to be given up after %d failures", maxWatchFailures)
+	}
+	if ws.isHealthy() {
+		t.Error("expected given up watch to be unhealthy")
+	}
+	select {
+	case ev := <-recorder.Events:
+		if !strings.Contains(ev, "WatchFailing") {
+			t.Errorf("unexpected event %q", ev)
+		}
+	default:
+		t.Error("expected an event on the trigger")
+	}
+}
+
+// testOptions returns Options for a KubeWatcher that gives up on watches quickly.
+func testOptions() Options {
+	return Options{
+		WatchTimeout:        time.Second,
+		WatchAttemptTimeout: time.Second,
+		PublishConcurrency:  1,
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kw := MakeKubeWatcher(ctx, zap.NewNop(), fake.NewSimpleClientset(), nil, nil, nil, testOptions())
+	trigger := func(uid, app string) fv1.KubernetesWatchTrigger {
+		return fv1.KubernetesWatchTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: uid, Namespace: "default", UID: types.UID(uid)},
+			Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod", LabelSelector: map[string]string{"app": app}},
+		}
+	}
+	unchanged, changed, deleted := trigger("unchanged", ""), trigger("changed", ""), trigger("deleted", "")
+	for _, w := range []fv1.KubernetesWatchTrigger{unchanged, changed, deleted} {
+		if err := kw.addWatch(ctx, &w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kw.watches["unchanged"].lastResourceVersions["POD"] = "42"
+	oldChanged := kw.watches["changed"]
+
+	kw.Reload(ctx, []fv1.KubernetesWatchTrigger{unchanged, trigger("changed", "new"), trigger("added", "")})
+
+	if len(kw.watches) != 3 {
+		t.Fatalf("expected 3 watches, got %d", len(kw.watches))
+	}
+	if _, ok := kw.watches["deleted"]; ok {
+		t.Error("expected watch of deleted trigger to be removed")
+	}
+	if _, ok := kw.watches["added"]; !ok {
+		t.Error("expected watch of new trigger to be added")
+	}
+	if rv := kw.watches["unchanged"].lastResourceVersions["POD"]; rv != "42" {
+		t.Errorf("expected unchanged watch to keep its resource version, got %q", rv)
+	}
+	if kw.watches["changed"] == oldChanged || !oldChanged.isStopped() {
+		t.Error("expected watch of changed trigger to be replaced")
+	}
+	if app := kw.watches["changed"].watch.Spec.LabelSelector["app"]; app != "new" {
+		t.Errorf("expected updated label selector, got %q", app)
+	}
+}
+
+func TestPauseResumeWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeClient := fake.NewSimpleClientset()
+	resourceVersions := make(chan string, 2)
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		resourceVersions <- action.(k8stesting.WatchAction).GetWatchRestrictions().ResourceVersion
+		return true, watch.NewFake(), nil
+	})
+	kw := MakeKubeWatcher(ctx, zap.NewNop(), kubeClient, nil, nil, nil, testOptions())
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default", UID: "uid"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod"},
+	}
+	if err := kw.addWatch(ctx, w); err != nil {
+		t.Fatal(err)
+	}
+	<-resourceVersions
+	ws := kw.watches["uid"]
+
+	if err := kw.pauseWatch("uid"); err != nil {
+		t.Fatal(err)
+	}
+	<-ws.done
+	if !ws.isStopped() {
+		t.Error("expected paused watch to be stopped")
+	}
+	ws.lastResourceVersions["POD"] = "42"
+
+	if err := kw.resumeWatch(ctx, "uid"); err != nil {
+		t.Fatal(err)
+	}
+	if rv := <-resourceVersions; rv != "42" {
+		t.Errorf("expected watch to resume from resource version 42, got %q", rv)
+	}
+	if kw.watches["uid"] != ws || ws.isStopped() {
+		t.Error("expected the same subscription to be running again")
+	}
+
+	if err := kw.pauseWatch("missing"); err == nil {
+		t.Error("expected an error pausing an unknown watch")
+	}
+	ws.stop()
+}
+
+func TestResumeWatchDoesNotBlockWatches(t *testing.T) {
+	for _, remove := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		kubeClient := fake.NewSimpleClientset()
+		restarting, release := make(chan struct{}), make(chan struct{})
+		var calls int32
+		kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			if atomic.AddInt32(&calls, 1) > 1 {
+				close(restarting)
+				<-release
+			}
+			return true, watch.NewFake(), nil
+		})
+		kw := MakeKubeWatcher(ctx, zap.NewNop(), kubeClient, nil, nil, nil, testOptions())
+		w := &fv1.KubernetesWatchTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default", UID: "uid"},
+			Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod"},
+		}
+		if err := kw.addWatch(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+		ws := kw.watches["uid"]
+		if err := kw.pauseWatch("uid"); err != nil {
+			t.Fatal(err)
+		}
+
+		resumed := make(chan error, 1)
+		go func() { resumed <- kw.resumeWatch(ctx, "uid") }()
+		<-restarting
+		checked := make(chan struct{})
+		go func() {
+			kw.failedWatches()
+			if remove {
+				kw.removeWatch(w) //nolint: errCheck
+			}
+			close(checked)
+		}()
+		select {
+		case <-checked:
+		case <-time.After(time.Second):
+			t.Fatal("expected the watches not to be locked while a watch resumes")
+		}
+		close(release)
+		if err := <-resumed; err != nil {
+			t.Fatal(err)
+		}
+		if ws.isStopped() != remove {
+			t.Errorf("expected a watch removed while resuming to be stopped, and only then, removed: %v", remove)
+		}
+		ws.stop()
+		cancel()
+	}
+}
+
+func TestIsTooOld(t *testing.T) {
+	ws := &watchSubscription{maxEventAge: time.Minute}
+	pod := func(age time.Duration) *apiv1.Pod {
+		return &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now().Add(-age))}}
+	}
+	if !ws.isTooOld(watch.Event{Type: watch.Added, Object: pod(time.Hour)}) {
+		t.Error("expected Added event of an old object to be too old")
+	}
+	if ws.isTooOld(watch.Event{Type: watch.Added, Object: pod(time.Second)}) {
+		t.Error("expected Added event of a new object to be delivered")
+	}
+	if ws.isTooOld(watch.Event{Type: watch.Modified, Object: pod(time.Hour)}) {
+		t.Error("expected Modified event of an old object to be delivered")
+	}
+	ws.maxEventAge = 0
+	if ws.isTooOld(watch.Event{Type: watch.Added, Object: pod(time.Hour)}) {
+		t.Error("expected every event to be delivered without max event age")
+	}
+}
+
+func TestPublishTargetAppendsFunctionPath(t *testing.T) {
+	ws := &watchSubscription{logger: zap.NewNop(), watch: fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+		Spec: fv1.KubernetesWatchTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+			FunctionPath:      "/events/pod",
+		},
+	}}
+	target, ok := ws.publishTarget()
+	if !ok || !strings.HasSuffix(target, "/fn/events/pod") {
+		t.Errorf("expected function URL with path, got %q", target)
+	}
+}
+
+func TestEnqueueDropOldest(t *testing.T) {
+	ws := &watchSubscription{
+		logger:       zap.NewNop(),
+		publishQueue: make(chan publishEvent, 2),
+		bufferPolicy: EventBufferDropOldest,
+	}
+	for _, target := range []string{"a", "b", "c"} {
+		ws.enqueue(publishEvent{target: target})
+	}
+	if len(ws.publishQueue) != 2 {
+		t.Fatalf("expected a full buffer of 2 events, got %d", len(ws.publishQueue))
+	}
+	for _, want := range []string{"b", "c"} {
+		if ev := <-ws.publishQueue; ev.target != want {
+			t.Errorf("expected event %q, got %q", want, ev.target)
+		}
+	}
+}
+
+func TestWatchStartsFromSpecResourceVersion(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	resourceVersions := make(chan string, 1)
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		resourceVersions <- action.(k8stesting.WatchAction).GetWatchRestrictions().ResourceVersion
+		return true, watch.NewFake(), nil
+	})
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod", ResourceVersion: "42"},
+	}
+	ws, err := MakeWatchSubscription(context.Background(), zap.NewNop(), w, kubeClient, nil, nil, time.Minute, time.Second, make(chan struct{}, 1), 0, EventBufferBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.stop()
+	if rv := <-resourceVersions; rv != "42" {
+		t.Errorf("expected watch to start from resource version 42, got %q", rv)
+	}
+}
+
+func TestResolveHeaders(t *testing.T) {
+	optional := true
+	kubeClient := fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	})
+	ws := &watchSubscription{
+		kubernetesClient: kubeClient,
+		watch: fv1.KubernetesWatchTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+			Spec: fv1.KubernetesWatchTriggerSpec{
+				Headers: map[string]string{"x-source": "kubewatcher"},
+				HeadersFromSecrets: map[string]apiv1.SecretKeySelector{
+					"X-Token": {LocalObjectReference: apiv1.LocalObjectReference{Name: "shared"}, Key: "token"},
+					"X-Other": {LocalObjectReference: apiv1.LocalObjectReference{Name: "missing"}, Key: "token", Optional: &optional},
+				},
+			},
+		},
+	}
+	headers, err := ws.resolveHeaders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 2 || headers["X-Source"] != "kubewatcher" || headers["X-Token"] != "s3cr3t" {
+		t.Errorf("unexpected headers %v", headers)
+	}
+
+	ws.watch.Spec.HeadersFromSecrets["X-Other"] = apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "missing"}, Key: "token"}
+	if _, err := ws.resolveHeaders(context.Background()); err == nil {
+		t.Error("expected an error for a missing secret that isn't optional")
+	}
+}
+
+func TestResumeFromCurrentSkipReplay(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	lists := 0
+	kubeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		lists++
+		if lists == 1 {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver is restarting")
+		}
+		return true, &apiv1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: "100"}}, nil
+	})
+	var stopped int32
+	ws := &watchSubscription{
+		logger:               zap.NewNop(),
+		kubernetesClient:     kubeClient,
+		stopped:              &stopped,
+		types:                []string{"POD"},
+		lastResourceVersions: map[string]string{"POD": "42"},
+		watch: fv1.KubernetesWatchTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+			Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod", SkipReplayOnError: true},
+		},
+	}
+	if !ws.resumeFromCurrent(context.Background()) {
+		t.Fatal("expected the watch to resume")
+	}
+	if rv := ws.lastResourceVersions["POD"]; rv != "100" {
+		t.Errorf("expected the watch to resume from the current resource version 100, got %q", rv)
+	}
+
+	// by default a failed relist replays all objects
+	lists = 0
+	ws.watch.Spec.SkipReplayOnError = false
+	if !ws.resumeFromCurrent(context.Background()) {
+		t.Fatal("expected the watch to resume")
+	}
+	if rv := ws.lastResourceVersions["POD"]; rv != "" {
+		t.Errorf("expected the watch to restart from scratch, got %q", rv)
+	}
+}
+
+func TestWatchWithTimeout(t *testing.T) {
+	hang := func(ctx context.Context) (watch.Interface, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	_, err := watchWithTimeout(context.Background(), 10*time.Millisecond, "pod", hang)
+	if !apierrors.IsTimeout(err) {
+		t.Errorf("expected a hung attempt to time out, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = watchWithTimeout(ctx, time.Minute, "pod", hang)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the attempt to end with its parent context, got %v", err)
+	}
+
+	// the watch outlives the attempt timeout, stopping it releases its context
+	var watchCtx context.Context
+	wi, err := watchWithTimeout(context.Background(), 10*time.Millisecond, "pod", func(ctx context.Context) (watch.Interface, error) {
+		watchCtx = ctx
+		return watch.NewFake(), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if watchCtx.Err() != nil {
+		t.Error("expected the started watch to keep running past the attempt timeout")
+	}
+	wi.Stop()
+	if watchCtx.Err() == nil {
+		t.Error("expected stopping the watch to cancel its context")
+	}
+}
+
+func TestEventFieldSelector(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	selectors := make(chan string, 1)
+	kubeClient.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		selectors <- action.(k8stesting.WatchAction).GetWatchRestrictions().Fields.String()
+		return true, watch.NewFake(), nil
+	})
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+		Spec: fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "event",
+			EventInvolvedObjectKind: "Pod", EventReason: "FailedScheduling"},
+	}
+	ws, err := MakeWatchSubscription(context.Background(), zap.NewNop(), w, kubeClient, nil, nil, time.Minute, time.Second, make(chan struct{}, 1), 0, EventBufferBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.stop()
+	if selector := <-selectors; selector != "involvedObject.kind=Pod,reason=FailedScheduling,type!=Normal" {
+		t.Errorf("expected the watch to select the events by kind and reason, got %q", selector)
+	}
+
+	w.Spec = fv1.KubernetesWatchTriggerSpec{IncludeNormalEvents: true}
+	if selector := eventFieldSelector(w); selector != "" {
+		t.Errorf("expected no field selector, got %q", selector)
+	}
+}
+
+func TestTimedPublishOptions(t *testing.T) {
+	var done error
+	ws := &watchSubscription{
+		watch:          fv1.KubernetesWatchTrigger{ObjectMeta: metav1.ObjectMeta{Name: "timed", Namespace: "default"}},
+		publishOptions: publisher.PublishOptions{Done: func(err error) { done = err }},
+	}
+	failed := errors.New("request returned status code 503")
+	ws.timedPublishOptions().Done(failed)
+	if done != failed {
+		t.Errorf("expected the Done option of the trigger to be called, got %v", done)
+	}
+	ws.timedPublishOptions().Done(context.DeadlineExceeded)
+	if n := testutil.CollectAndCount(publishDuration, "fission_kubewatcher_publish_duration_seconds"); n < 2 {
+		t.Errorf("expected a series per outcome, got %d", n)
+	}
+
+	for err, outcome := range map[error]string{
+		nil:                      publishOutcomeSuccess,
+		failed:                   publishOutcomeFailure,
+		context.DeadlineExceeded: publishOutcomeTimeout,
+		&url.Error{Op: "Post", URL: "http://router", Err: context.DeadlineExceeded}: publishOutcomeTimeout,
+	} {
+		if got := publishOutcome(err); got != outcome {
+			t.Errorf("expected outcome %q for %v, got %q", outcome, err, got)
+		}
+	}
+}
+
+// asyncPublisher completes publishes in the background, like the webhook
+// publisher, in batches of hold: a publish completes once the publishes of
+// its batch have all started. It records the most publishes in flight.
+type asyncPublisher struct {
+	lock        sync.Mutex
+	cond        *sync.Cond
+	hold        int
+	started     int
+	inFlight    int
+	maxInFlight int
+	published   []string
+}
+
+func newAsyncPublisher(hold int) *asyncPublisher {
+	p := &asyncPublisher{hold: hold}
+	p.cond = sync.NewCond(&p.lock)
+	return p
+}
+
+func (p *asyncPublisher) Publish(ctx context.Context, body []byte, headers map[string]string, target string) {
+	p.PublishWithOptions(ctx, body, headers, target, publisher.PublishOptions{})
+}
+
+func (p *asyncPublisher) PublishWithOptions(ctx context.Context, body []byte, headers map[string]string, target string, opts publisher.PublishOptions) {
+	p.lock.Lock()
+	p.started++
+	batchEnd := (p.started + p.hold - 1) / p.hold * p.hold
+	p.inFlight++
+	if p.inFlight > p.maxInFlight {
+		p.maxInFlight = p.inFlight
+	}
+	p.cond.Broadcast()
+	p.lock.Unlock()
+	go func() {
+		p.lock.Lock()
+		for p.started < batchEnd {
+			p.cond.Wait()
+		}
+		p.inFlight--
+		p.published = append(p.published, string(body))
+		p.lock.Unlock()
+		if opts.Done != nil {
+			opts.Done(nil)
+		}
+	}()
+}
+
+// plainPublisher doesn't take PublishOptions, so it doesn't report outcomes.
+type plainPublisher struct{}
+
+func (plainPublisher) Publish(ctx context.Context, body []byte, headers map[string]string, target string) {
+}
+
+func TestPlainPublishKeepsFailures(t *testing.T) {
+	ws := &watchSubscription{logger: zap.NewNop(), publisher: plainPublisher{}, failures: 3}
+	ws.publishAndWait(context.Background(), publishEvent{target: "fn"})
+	if ws.failures != 3 {
+		t.Errorf("expected a publish with unknown outcome not to reset the failures, got %d", ws.failures)
+	}
+}
+
+func TestPublishLoopConcurrencyPolicy(t *testing.T) {
+	for _, test := range []struct {
+		policy      fv1.KubernetesWatchConcurrencyPolicy
+		concurrency int
+		maxInFlight int
+	}{
+		{fv1.KubernetesWatchConcurrencySerial, 4, 1},
+		// events are kept in order by default
+		{"", 4, 1},
+		{fv1.KubernetesWatchConcurrencyParallel, 4, 4},
+		// publishes hold their slot until they complete
+		{fv1.KubernetesWatchConcurrencyParallel, 2, 2},
+	} {
+		p := newAsyncPublisher(test.maxInFlight)
+		ws := &watchSubscription{
+			logger:     zap.NewNop(),
+			publisher:  p,
+			publishSem: make(chan struct{}, test.concurrency),
+			stopped:    new(int32),
+			watch: fv1.KubernetesWatchTrigger{
+				ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"},
+				Spec:       fv1.KubernetesWatchTriggerSpec{ConcurrencyPolicy: test.policy},
+			},
+		}
+		queue := make(chan publishEvent, 4)
+		for _, body := range []string{"1", "2", "3", "4"} {
+			queue <- publishEvent{body: []byte(body), target: "fn"}
+		}
+		close(queue)
+		ws.publishLoop(context.Background(), queue)
+		// wait for the parallel publishes to complete, a publish releases its
+		// slot once it is recorded
+		for i := 0; i < cap(ws.publishSem); i++ {
+			ws.publishSem <- struct{}{}
+		}
+
+		p.lock.Lock()
+		if p.maxInFlight != test.maxInFlight {
+			t.Errorf("%v: expected at most %d publishes in flight, got %d", test.policy, test.maxInFlight, p.maxInFlight)
+		}
+		if test.maxInFlight == 1 && strings.Join(p.published, "") != "1234" {
+			t.Errorf("%v: expected events to be published in order, got %v", test.policy, p.published)
+		}
+		p.lock.Unlock()
+	}
+}
+
+func TestPublishLoopDrainsStopped(t *testing.T) {
+	p := newAsyncPublisher(1)
+	ws := &watchSubscription{
+		logger:     zap.NewNop(),
+		publisher:  p,
+		publishSem: make(chan struct{}, 1),
+		stopped:    new(int32),
+		watch:      fv1.KubernetesWatchTrigger{ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default"}},
+	}
+	queue := make(chan publishEvent, 2)
+	queue <- publishEvent{body: []byte("1"), target: "fn"}
+	queue <- publishEvent{body: []byte("2"), target: "fn"}
+	close(queue)
+	atomic.StoreInt32(ws.stopped, 1)
+	ws.publishLoop(context.Background(), queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue = make(chan publishEvent, 1)
+	queue <- publishEvent{body: []byte("3"), target: "fn"}
+	close(queue)
+	atomic.StoreInt32(ws.stopped, 0)
+	ws.publishLoop(ctx, queue)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.published) != 0 {
+		t.Errorf("expected no events to be published once the watch is stopped, got %v", p.published)
+	}
+}
+
+func TestOwnerCronJob(t *testing.T) {
+	controller := true
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name: "backup-28000000",
+		OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "batch/v1", Kind: "CronJob", Name: "backup", Controller: &controller},
+		},
+	}}
+	if name, ok := ownerCronJob(job); !ok || name != "backup" {
+		t.Errorf("expected the job to be owned by cronjob backup, got %q, %v", name, ok)
+	}
+
+	job.OwnerReferences[0].Controller = nil
+	if _, ok := ownerCronJob(job); ok {
+		t.Error("expected a job without a controller not to be owned by a cronjob")
+	}
+
+	job.OwnerReferences[0] = metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "CronJob", Name: "other", Controller: &controller}
+	if _, ok := ownerCronJob(job); ok {
+		t.Error("expected a CronJob of another API group not to count")
+	}
+
+	pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+		{APIVersion: "batch/v1", Kind: "CronJob", Name: "backup", Controller: &controller},
+	}}}
+	if _, ok := ownerCronJob(pod); ok {
+		t.Error("expected only jobs to be owned by a cronjob")
+	}
+}
+
+func TestSubscriptionLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	base := zap.New(core)
+
+	logger, err := subscriptionLogger(base, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("hidden")
+	logger.Info("shown")
+	if logs.Len() != 1 {
+		t.Fatalf("expected the level of the kubewatcher without a log level, got %d entries", logs.Len())
+	}
+
+	logger, err = subscriptionLogger(base, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.With(zap.String("name", "w")).Debug("shown")
+	entries := logs.TakeAll()
+	if len(entries) != 2 || entries[1].Message != "shown" || entries[1].LoggerName != "watch_subscription" {
+		t.Fatalf("expected debug entries with a debug log level, got %v", entries)
+	}
+	base.Debug("hidden")
+	if logs.Len() != 0 {
+		t.Error("a log level must not change the kubewatcher logger")
+	}
+
+	logger, err = subscriptionLogger(base, "error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Warn("hidden")
+	if logs.Len() != 0 {
+		t.Error("expected warnings to be dropped with an error log level")
+	}
+
+	if _, err := subscriptionLogger(base, "verbose"); err == nil {
+		t.Error("expected an error for an invalid log level")
+	}
+}
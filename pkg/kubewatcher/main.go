@@ -18,16 +18,71 @@ package kubewatcher
 
 import (
 	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/mqtrigger/factory"
+	"github.com/fission/fission/pkg/mqtrigger/messageQueue"
 	"github.com/fission/fission/pkg/publisher"
+	"github.com/fission/fission/pkg/utils/httpserver"
 	"github.com/fission/fission/pkg/utils/manager"
+	"github.com/fission/fission/pkg/utils/metrics"
 )
 
-func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *zap.Logger, mgr manager.Interface, routerUrl string) error {
+// Options configures the kubewatcher started by Start.
+type Options struct {
+	// WatchTimeout is how long (re)starting a watch is retried for.
+	WatchTimeout time.Duration
+	// WatchAttemptTimeout bounds a single attempt to start a watch.
+	WatchAttemptTimeout time.Duration
+	// PublishConcurrency is the number of in-flight publishes per namespace.
+	PublishConcurrency int
+	// UnhealthyWatchRatio is the ratio of watches that failed to restart at
+	// which the kubewatcher reports itself unhealthy.
+	UnhealthyWatchRatio float64
+	// MaxPublishBodySize limits the size of a published event, 0 for no limit.
+	MaxPublishBodySize int
+	// ReportOversizedEvents sends an error report in place of the body of
+	// the events over MaxPublishBodySize.
+	ReportOversizedEvents bool
+	// EventBufferSize is the number of events buffered per watch.
+	EventBufferSize int
+	// EventBufferPolicy is what a watch does once its event buffer is full.
+	EventBufferPolicy EventBufferPolicy
+	// WatchdogInterval is how often the watchdog looks for dead watches.
+	WatchdogInterval time.Duration
+}
+
+// DefaultOptions returns the Options the kubewatcher runs with by default.
+func DefaultOptions() Options {
+	return Options{
+		WatchTimeout:        DefaultWatchTimeout,
+		WatchAttemptTimeout: DefaultWatchAttemptTimeout,
+		PublishConcurrency:  DefaultPublishConcurrency,
+		UnhealthyWatchRatio: DefaultUnhealthyWatchRatio,
+		EventBufferSize:     DefaultEventBufferSize,
+		EventBufferPolicy:   EventBufferBlock,
+		WatchdogInterval:    DefaultWatchdogInterval,
+	}
+}
+
+func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *zap.Logger, mgr manager.Interface, routerUrl string, opts Options) error {
+	err := opts.EventBufferPolicy.Validate()
+	if err != nil {
+		return err
+	}
 	fissionClient, err := clientGen.GetFissionClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get fission client")
@@ -42,13 +97,100 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 		return errors.Wrap(err, "error waiting for CRDs")
 	}
 
-	poster := publisher.MakeWebhookPublisher(logger, routerUrl)
-	kubeWatch := MakeKubeWatcher(ctx, logger, kubeClient, poster)
+	tlsOpts, err := publisher.TLSOptionsFromEnv()
+	if err != nil {
+		return errors.Wrap(err, "error reading publisher TLS options")
+	}
+	tlsConfig, err := tlsOpts.Config()
+	if err != nil {
+		return errors.Wrap(err, "error configuring publisher TLS")
+	}
+
+	// the publishes are bounded per namespace by the watches, see publishSem
+	poster := publisher.MakeWebhookPublisher(logger, routerUrl, publisher.WebhookOptions{
+		TLSConfig:       tlsConfig,
+		MaxBodySize:     opts.MaxPublishBodySize,
+		ReportOversized: opts.ReportOversizedEvents,
+		Concurrent:      true,
+	})
+	topicPublisher, err := makeTopicPublisher(logger, routerUrl)
+	if err != nil {
+		return errors.Wrap(err, "error connecting to message queue")
+	}
+	kubeWatch := MakeKubeWatcher(ctx, logger, kubeClient, poster, topicPublisher, eventRecorder(logger, kubeClient), opts)
 	ws, err := MakeWatchSync(ctx, logger, fissionClient, kubeWatch)
 	if err != nil {
 		return errors.Wrap(err, "error making watch sync")
 	}
 	ws.Run(ctx, mgr)
+	mgr.Add(ctx, ws.ReloadOnSignal)
+	mgr.Add(ctx, func(ctx context.Context) {
+		kubeWatch.RunWatchdog(ctx, opts.WatchdogInterval)
+	})
+
+	mgr.Add(ctx, func(ctx context.Context) {
+		metrics.ServeMetrics(ctx, "kubewatcher", logger, mgr)
+	})
+
+	healthAddr := os.Getenv("HEALTH_ADDR")
+	if healthAddr == "" {
+		healthAddr = "8081"
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", kubeWatch.HealthHandler(opts.UnhealthyWatchRatio))
+	mgr.Add(ctx, func(ctx context.Context) {
+		httpserver.StartServer(ctx, logger, mgr, "kubewatcher/health", healthAddr, mux)
+	})
 
 	return nil
 }
+
+// makeTopicPublisher connects to the message queue given by MESSAGE_QUEUE_TYPE
+// and MESSAGE_QUEUE_URL for watches with sink mqtopic. It returns nil if no
+// message queue is configured.
+func makeTopicPublisher(logger *zap.Logger, routerUrl string) (publisher.Publisher, error) {
+	mqType := (fv1.MessageQueueType)(os.Getenv("MESSAGE_QUEUE_TYPE"))
+	if len(mqType) == 0 {
+		return nil, nil
+	}
+
+	var secrets map[string][]byte
+	secretsPath := strings.TrimSpace(os.Getenv("MESSAGE_QUEUE_SECRETS"))
+	if len(secretsPath) > 0 {
+		var err error
+		secrets, err = messageQueue.ReadSecrets(logger, secretsPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mq, err := factory.Create(
+		logger,
+		mqType,
+		messageQueue.Config{
+			MQType:  (string)(mqType),
+			Url:     os.Getenv("MESSAGE_QUEUE_URL"),
+			Secrets: secrets,
+		},
+		routerUrl,
+	)
+	if err != nil {
+		return nil, err
+	}
+	producer, ok := mq.(messageQueue.Producer)
+	if !ok {
+		return nil, errors.Errorf("message queue type %q does not support publishing", mqType)
+	}
+	return publisher.MakeMessageQueuePublisher(logger, producer), nil
+}
+
+func eventRecorder(logger *zap.Logger, kubeClient kubernetes.Interface) record.EventRecorder {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logger.Sugar().Infof)
+	eventBroadcaster.StartRecordingToSink(
+		&typedcorev1.EventSinkImpl{
+			Interface: kubeClient.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(
+		scheme.Scheme,
+		apiv1.EventSource{Component: "kubewatcher"})
+}
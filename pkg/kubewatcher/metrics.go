@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fission/fission/pkg/utils/metrics"
+)
+
+// Reasons for (re)starting a watch, used as the "reason" label value.
+const (
+	restartReasonInitial    = "initial"
+	restartReasonTimeout    = "timeout"
+	restartReasonWatchError = "watch-error"
+	restartReasonResume     = "resume"
+)
+
+// Outcomes of publishing an event, used as the "outcome" label value.
+const (
+	publishOutcomeSuccess = "success"
+	publishOutcomeTimeout = "timeout"
+	publishOutcomeFailure = "failure"
+)
+
+var (
+	watchRestarts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_kubewatcher_watch_restarts_total",
+			Help: "Total number of times a watch was (re)started, by trigger and reason",
+		},
+		[]string{"trigger_name", "trigger_namespace", "reason"},
+	)
+	eventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_kubewatcher_events_dropped_total",
+			Help: "Total number of events dropped because the event buffer of a watch was full, by trigger",
+		},
+		[]string{"trigger_name", "trigger_namespace"},
+	)
+	eventsRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_kubewatcher_events_rejected_total",
+			Help: "Total number of events dropped because the circuit breaker of a watch was open, by trigger",
+		},
+		[]string{"trigger_name", "trigger_namespace"},
+	)
+	publishDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_kubewatcher_publish_duration_seconds",
+			Help:    "Time taken to publish an event until the function responded or publishing failed, including retries, by trigger and outcome",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"trigger_name", "trigger_namespace", "outcome"},
+	)
+)
+
+func IncreaseWatchRestarts(trigname, trignamespace, reason string) {
+	watchRestarts.WithLabelValues(trigname, trignamespace, reason).Inc()
+}
+
+func IncreaseEventsDropped(trigname, trignamespace string) {
+	eventsDropped.WithLabelValues(trigname, trignamespace).Inc()
+}
+
+func IncreaseEventsRejected(trigname, trignamespace string) {
+	eventsRejected.WithLabelValues(trigname, trignamespace).Inc()
+}
+
+// ObservePublishDuration records how long publishing an event of the trigger
+// took, labelled with the outcome of err.
+func ObservePublishDuration(trigname, trignamespace string, err error, duration time.Duration) {
+	publishDuration.WithLabelValues(trigname, trignamespace, publishOutcome(err)).Observe(duration.Seconds())
+}
+
+// publishOutcome returns the outcome label value of a publish that ended with err.
+func publishOutcome(err error) string {
+	if err == nil {
+		return publishOutcomeSuccess
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeout) && timeout.Timeout()) {
+		return publishOutcomeTimeout
+	}
+	return publishOutcomeFailure
+}
+
+func init() {
+	registry := metrics.Registry
+	registry.MustRegister(watchRestarts)
+	registry.MustRegister(eventsDropped)
+	registry.MustRegister(eventsRejected)
+	registry.MustRegister(publishDuration)
+}
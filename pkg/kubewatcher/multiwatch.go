@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// multiWatch merges the events of several watches into one result channel.
+// Once any of the watches ends, all of them are stopped and the result
+// channel is closed, so that they are restarted together.
+type multiWatch struct {
+	watches  []watch.Interface
+	result   chan watch.Event
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+var _ watch.Interface = &multiWatch{}
+
+func newMultiWatch(watches []watch.Interface) *multiWatch {
+	mw := &multiWatch{
+		watches: watches,
+		result:  make(chan watch.Event),
+		stopCh:  make(chan struct{}),
+	}
+	var wg sync.WaitGroup
+	for _, wi := range watches {
+		wg.Add(1)
+		go func(wi watch.Interface) {
+			defer wg.Done()
+			defer mw.Stop()
+			mw.forward(wi)
+		}(wi)
+	}
+	go func() {
+		wg.Wait()
+		close(mw.result)
+	}()
+	return mw
+}
+
+// forward sends the events of wi to the result channel until wi ends or the
+// multiWatch is stopped.
+func (mw *multiWatch) forward(wi watch.Interface) {
+	for {
+		select {
+		case ev, more := <-wi.ResultChan():
+			if !more {
+				return
+			}
+			select {
+			case mw.result <- ev:
+			case <-mw.stopCh:
+				return
+			}
+		case <-mw.stopCh:
+			return
+		}
+	}
+}
+
+func (mw *multiWatch) Stop() {
+	mw.stopOnce.Do(func() {
+		close(mw.stopCh)
+		for _, wi := range mw.watches {
+			wi.Stop()
+		}
+	})
+}
+
+func (mw *multiWatch) ResultChan() <-chan watch.Event {
+	return mw.result
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestMultiWatch(t *testing.T) {
+	pods, services := watch.NewFake(), watch.NewFake()
+	mw := newMultiWatch([]watch.Interface{pods, services})
+
+	go pods.Add(&apiv1.Pod{})
+	ev := <-mw.ResultChan()
+	if _, ok := ev.Object.(*apiv1.Pod); !ok {
+		t.Errorf("expected a pod event, got %T", ev.Object)
+	}
+	go services.Add(&apiv1.Service{})
+	ev = <-mw.ResultChan()
+	if _, ok := ev.Object.(*apiv1.Service); !ok {
+		t.Errorf("expected a service event, got %T", ev.Object)
+	}
+
+	// the end of one watch ends all of them
+	pods.Stop()
+	select {
+	case _, more := <-mw.ResultChan():
+		if more {
+			t.Error("expected the result channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the result channel to be closed")
+	}
+	if !services.IsStopped() {
+		t.Error("expected the other watch to be stopped")
+	}
+}
+
+func TestWatchTypes(t *testing.T) {
+	w := &fv1.KubernetesWatchTrigger{Spec: fv1.KubernetesWatchTriggerSpec{
+		Type:  "pod",
+		Types: []string{"Service", "POD", "event", "service"},
+	}}
+	if types := watchTypes(w); !reflect.DeepEqual(types, []string{"POD", "SERVICE", "EVENT"}) {
+		t.Errorf("unexpected types %v", types)
+	}
+
+	w = &fv1.KubernetesWatchTrigger{Spec: fv1.KubernetesWatchTriggerSpec{Type: "CronJob", IncludeOwnedJobs: true}}
+	if types := watchTypes(w); !reflect.DeepEqual(types, []string{"CRONJOB", "JOB"}) {
+		t.Errorf("expected the jobs of the cronjobs to be watched, got %v", types)
+	}
+	if !watchesOwnedJobsOnly(w) {
+		t.Error("expected only the jobs owned by cronjobs to be delivered")
+	}
+	w.Spec.Types = []string{"job"}
+	if types := watchTypes(w); !reflect.DeepEqual(types, []string{"CRONJOB", "JOB"}) {
+		t.Errorf("unexpected types %v", types)
+	}
+	if watchesOwnedJobsOnly(w) {
+		t.Error("expected all jobs to be delivered when watching the Job type")
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// parsePruneFields splits the dot separated PruneFields of a trigger.
+func parsePruneFields(fields []string) [][]string {
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		paths = append(paths, strings.Split(field, "."))
+	}
+	return paths
+}
+
+// prunedObject serializes the object like printKubernetesObject, without
+// the fields at the given paths.
+func prunedObject(obj runtime.Object, paths [][]string) ([]byte, error) {
+	var content map[string]interface{}
+	if unknown, ok := obj.(*runtime.Unknown); ok {
+		err := json.Unmarshal(unknown.Raw, &content)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range paths {
+		unstructured.RemoveNestedField(content, path...)
+	}
+	data, err := json.MarshalIndent(content, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// prunedJSON removes the fields at the given paths from a JSON object.
+func prunedJSON(data []byte, paths [][]string) ([]byte, error) {
+	var content map[string]interface{}
+	err := json.Unmarshal(data, &content)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		unstructured.RemoveNestedField(content, path...)
+	}
+	return json.Marshal(content)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"encoding/json"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrunedObject(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "pod",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		},
+		Spec:   apiv1.PodSpec{NodeName: "node"},
+		Status: apiv1.PodStatus{Phase: apiv1.PodRunning},
+	}
+	paths := parsePruneFields([]string{"metadata.managedFields", "status", "spec.missing.field"})
+
+	data, err := prunedObject(pod, paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var content map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := content["status"]; ok {
+		t.Error("expected status to be pruned")
+	}
+	if _, ok := content["metadata"]["managedFields"]; ok {
+		t.Error("expected metadata.managedFields to be pruned")
+	}
+	if content["metadata"]["name"] != "pod" || content["spec"]["nodeName"] != "node" {
+		t.Errorf("expected other fields to be kept, got %s", data)
+	}
+
+	data, err = prunedJSON([]byte(`{"metadata":{"name":"pod","managedFields":[]},"status":{}}`), paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"metadata":{"name":"pod"}}` {
+		t.Errorf("unexpected pruned old object %s", data)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"container/list"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// maxTrackedObjects is how many objects a watch remembers for the old object
+// of Modified events, the least recently seen ones are forgotten first.
+const maxTrackedObjects = 1024
+
+type (
+	// objectTracker remembers the last seen state of the watched objects.
+	// It is only used by the dispatch loop of a watch, so it is not locked.
+	objectTracker struct {
+		capacity int
+		objects  map[types.UID]*list.Element
+		lru      *list.List // front is the most recently seen
+	}
+
+	trackedObject struct {
+		uid  types.UID
+		data []byte
+	}
+)
+
+func makeObjectTracker(capacity int) *objectTracker {
+	return &objectTracker{
+		capacity: capacity,
+		objects:  make(map[types.UID]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// update records the object of the event and returns the previously seen
+// state of a modified object as compact JSON, or nil if it is unknown.
+func (t *objectTracker) update(ev watch.Event) ([]byte, error) {
+	m, err := meta.Accessor(ev.Object)
+	if err != nil {
+		return nil, err
+	}
+	uid := m.GetUID()
+
+	if ev.Type == watch.Deleted {
+		if e, ok := t.objects[uid]; ok {
+			t.lru.Remove(e)
+			delete(t.objects, uid)
+		}
+		return nil, nil
+	}
+
+	data, err := json.Marshal(ev.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	if e, ok := t.objects[uid]; ok {
+		tracked := e.Value.(*trackedObject)
+		old := tracked.data
+		tracked.data = data
+		t.lru.MoveToFront(e)
+		if ev.Type == watch.Modified {
+			return old, nil
+		}
+		return nil, nil
+	}
+
+	t.objects[uid] = t.lru.PushFront(&trackedObject{uid: uid, data: data})
+	if t.lru.Len() > t.capacity {
+		oldest := t.lru.Back()
+		t.lru.Remove(oldest)
+		delete(t.objects, oldest.Value.(*trackedObject).uid)
+	}
+	return nil, nil
+}
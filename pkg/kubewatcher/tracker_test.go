@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"strings"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestObjectTracker(t *testing.T) {
+	pod := func(uid types.UID, phase apiv1.PodPhase) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{UID: uid},
+			Status:     apiv1.PodStatus{Phase: phase},
+		}
+	}
+	update := func(tracker *objectTracker, evType watch.EventType, obj *apiv1.Pod) string {
+		t.Helper()
+		old, err := tracker.update(watch.Event{Type: evType, Object: obj})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(old)
+	}
+
+	tracker := makeObjectTracker(2)
+	if old := update(tracker, watch.Added, pod("a", apiv1.PodPending)); old != "" {
+		t.Errorf("expected no old object on Added, got %s", old)
+	}
+	if old := update(tracker, watch.Modified, pod("a", apiv1.PodRunning)); !strings.Contains(old, `"phase":"Pending"`) {
+		t.Errorf("expected the pending pod as old object, got %s", old)
+	}
+	if old := update(tracker, watch.Modified, pod("a", apiv1.PodSucceeded)); !strings.Contains(old, `"phase":"Running"`) {
+		t.Errorf("expected the running pod as old object, got %s", old)
+	}
+
+	update(tracker, watch.Deleted, pod("a", apiv1.PodSucceeded))
+	if old := update(tracker, watch.Modified, pod("a", apiv1.PodSucceeded)); old != "" {
+		t.Errorf("expected deleted object to be forgotten, got %s", old)
+	}
+
+	// "a" is the least recently seen and evicted by "c"
+	update(tracker, watch.Added, pod("b", apiv1.PodPending))
+	update(tracker, watch.Added, pod("c", apiv1.PodPending))
+	if len(tracker.objects) != 2 {
+		t.Errorf("expected 2 tracked objects, got %d", len(tracker.objects))
+	}
+	if old := update(tracker, watch.Modified, pod("a", apiv1.PodRunning)); old != "" {
+		t.Errorf("expected evicted object to be forgotten, got %s", old)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// eventTransform reshapes the event object according to the Transform of
+// the watch trigger spec.
+type eventTransform struct {
+	fields map[string]*jsonpath.JSONPath
+}
+
+// makeEventTransform returns nil if fields is empty, so that the object is
+// sent unchanged.
+func makeEventTransform(fields map[string]string) (*eventTransform, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	t := &eventTransform{fields: make(map[string]*jsonpath.JSONPath, len(fields))}
+	for field, expr := range fields {
+		path := jsonpath.New(field).AllowMissingKeys(true)
+		if err := path.Parse(expr); err != nil {
+			return nil, fmt.Errorf("error parsing transform of field %q %q: %w", field, expr, err)
+		}
+		t.fields[field] = path
+	}
+	return t, nil
+}
+
+// apply evaluates the templates against the serialized event object and
+// returns a JSON object with a field per template. A field is null if its
+// template yields nothing and an array if it yields several values.
+func (t *eventTransform) apply(obj []byte) ([]byte, error) {
+	var data interface{}
+	if err := json.Unmarshal(obj, &data); err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(t.fields))
+	for field, path := range t.fields {
+		results, err := path.FindResults(data)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating transform of field %q: %w", field, err)
+		}
+		var values []interface{}
+		for _, result := range results {
+			for _, v := range result {
+				if v.IsValid() {
+					values = append(values, v.Interface())
+				}
+			}
+		}
+		switch len(values) {
+		case 0:
+			out[field] = nil
+		case 1:
+			out[field] = values[0]
+		default:
+			out[field] = values
+		}
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEventTransform(t *testing.T) {
+	pod := []byte(`{"metadata": {"name": "foo", "labels": {"app": "web"}}, "status": {"phase": "Running", "conditions": [{"type": "Ready"}, {"type": "Scheduled"}]}}`)
+
+	tr, err := makeEventTransform(map[string]string{
+		"name":       "{.metadata.name}",
+		"labels":     "{.metadata.labels}",
+		"conditions": "{.status.conditions[*].type}",
+		"reason":     "{.status.reason}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := tr.apply(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"name":       "foo",
+		"labels":     map[string]interface{}{"app": "web"},
+		"conditions": []interface{}{"Ready", "Scheduled"},
+		"reason":     nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if tr, err := makeEventTransform(nil); tr != nil || err != nil {
+		t.Error("expected no transform without fields")
+	}
+	if _, err := makeEventTransform(map[string]string{"phase": "{.status["}); err == nil {
+		t.Error("expected error for invalid expression")
+	}
+}
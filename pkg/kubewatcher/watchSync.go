@@ -18,9 +18,13 @@ package kubewatcher
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -63,6 +67,13 @@ func (ws *WatchSync) KubeWatcherEventHandlers(ctx context.Context) error {
 				objKubeWatcher := obj.(*fv1.KubernetesWatchTrigger)
 				ws.kubeWatcher.addWatch(ctx, objKubeWatcher) //nolint: errCheck
 			},
+			UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+				oldKubeWatcher := oldObj.(*fv1.KubernetesWatchTrigger)
+				newKubeWatcher := newObj.(*fv1.KubernetesWatchTrigger)
+				if oldKubeWatcher.ObjectMeta.ResourceVersion != newKubeWatcher.ObjectMeta.ResourceVersion {
+					ws.kubeWatcher.updateWatch(ctx, oldKubeWatcher, newKubeWatcher) //nolint: errCheck
+				}
+			},
 			DeleteFunc: func(obj interface{}) {
 				objKubeWatcher := obj.(*fv1.KubernetesWatchTrigger)
 				ws.kubeWatcher.removeWatch(objKubeWatcher) //nolint: errCheck
@@ -74,3 +85,34 @@ func (ws *WatchSync) KubeWatcherEventHandlers(ctx context.Context) error {
 	}
 	return nil
 }
+
+// ReloadOnSignal re-lists the watch triggers and reloads the watches of the
+// KubeWatcher whenever the process receives SIGHUP, until ctx is done.
+func (ws *WatchSync) ReloadOnSignal(ctx context.Context) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			ws.logger.Info("received SIGHUP, reloading watches")
+			ws.reload(ctx)
+		}
+	}
+}
+
+func (ws *WatchSync) reload(ctx context.Context) {
+	var triggers []fv1.KubernetesWatchTrigger
+	for ns := range ws.kubeWatcherInformer {
+		list, err := ws.client.CoreV1().KubernetesWatchTriggers(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			// reloading a partial list would remove the watches of the namespace
+			ws.logger.Error("error listing watch triggers, not reloading", zap.Error(err), zap.String("namespace", ns))
+			return
+		}
+		triggers = append(triggers, list.Items...)
+	}
+	ws.kubeWatcher.Reload(ctx, triggers)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const (
+	// DefaultWatchdogInterval is how often the watchdog looks for dead watches.
+	DefaultWatchdogInterval = 30 * time.Second
+
+	// maxRecreateBackoff bounds the wait between two attempts to recreate a
+	// dead watch.
+	maxRecreateBackoff = 10 * time.Minute
+)
+
+// deadWatch is a trigger whose watch failed to start or gave up, e.g. because
+// it was forbidden or kept failing.
+type deadWatch struct {
+	trigger fv1.KubernetesWatchTrigger
+	// failures counts the failed attempts of the watchdog to recreate the watch
+	failures int
+	// retryAt is when the watchdog next tries to recreate the watch
+	retryAt time.Time
+}
+
+// markDead records a trigger whose watch failed to start. It must be called
+// with the watchesLock held.
+func (kw *KubeWatcher) markDead(w *fv1.KubernetesWatchTrigger) {
+	if d, ok := kw.dead[w.ObjectMeta.UID]; ok {
+		d.trigger = *w
+		return
+	}
+	kw.dead[w.ObjectMeta.UID] = &deadWatch{trigger: *w}
+}
+
+// recreateBackoff is how long the watchdog waits after the failures-th failed
+// attempt to recreate a watch: interval, doubled on every failure, up to
+// maxRecreateBackoff.
+func recreateBackoff(interval time.Duration, failures int) time.Duration {
+	backoff := interval
+	for i := 1; i < failures && backoff < maxRecreateBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRecreateBackoff)
+}
+
+// RunWatchdog recreates the dead watches every interval until ctx is done, so
+// that watches recover once e.g. their RBAC permissions are restored, without
+// restarting the kubewatcher. A watch failing again is retried with
+// exponential backoff. DefaultWatchdogInterval is used if interval is not
+// positive.
+func (kw *KubeWatcher) RunWatchdog(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultWatchdogInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			kw.recreateDeadWatches(ctx, interval)
+		}
+	}
+}
+
+// recreateDeadWatches tries to recreate the dead watches whose backoff has
+// passed, after adding the subscriptions that gave up since the last run.
+func (kw *KubeWatcher) recreateDeadWatches(ctx context.Context, interval time.Duration) {
+	now := time.Now()
+	var due []fv1.KubernetesWatchTrigger
+	kw.watchesLock.Lock()
+	for uid, ws := range kw.watches {
+		// a paused subscription, or one being resumed, is not dead
+		if _, ok := kw.dead[uid]; !ok && !ws.paused && ws.isDead() {
+			kw.dead[uid] = &deadWatch{trigger: ws.watch}
+		}
+	}
+	for _, d := range kw.dead {
+		if !d.retryAt.After(now) {
+			due = append(due, d.trigger)
+		}
+	}
+	kw.watchesLock.Unlock()
+
+	for i := range due {
+		kw.recreateWatch(ctx, &due[i], interval)
+	}
+}
+
+// recreateWatch replaces the dead watch of a trigger with a new subscription.
+// The trigger may have been updated or deleted meanwhile, the new
+// subscription is then dropped.
+func (kw *KubeWatcher) recreateWatch(ctx context.Context, w *fv1.KubernetesWatchTrigger, interval time.Duration) {
+	kw.logger.Info("recreating dead watch", zap.String("name", w.ObjectMeta.Name))
+	ws, err := kw.makeSubscription(ctx, w)
+
+	kw.watchesLock.Lock()
+	defer kw.watchesLock.Unlock()
+	d, ok := kw.dead[w.ObjectMeta.UID]
+	if !ok || d.trigger.ObjectMeta.ResourceVersion != w.ObjectMeta.ResourceVersion {
+		if ws != nil {
+			ws.stop()
+		}
+		return
+	}
+	if err != nil {
+		d.failures++
+		backoff := recreateBackoff(interval, d.failures)
+		d.retryAt = time.Now().Add(backoff)
+		kw.logger.Error("error recreating dead watch", zap.Error(err), zap.String("name", w.ObjectMeta.Name),
+			zap.Int("failures", d.failures), zap.Duration("retry_in", backoff))
+		return
+	}
+	delete(kw.dead, w.ObjectMeta.UID)
+	if old, ok := kw.watches[w.ObjectMeta.UID]; ok {
+		old.stop()
+	}
+	kw.watches[w.ObjectMeta.UID] = ws
+	kw.logger.Info("recreated dead watch", zap.String("name", w.ObjectMeta.Name), zap.Int("failures", d.failures))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubewatcher
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// makeWatchdogTest returns a KubeWatcher whose pod watches are forbidden
+// while forbidden is set, and the channel receiving the watches it starts.
+func makeWatchdogTest(ctx context.Context, forbidden *atomic.Bool) (*KubeWatcher, <-chan *watch.FakeWatcher) {
+	kubeClient := fake.NewSimpleClientset()
+	watches := make(chan *watch.FakeWatcher, 2)
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		if forbidden.Load() {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+		}
+		fw := watch.NewFake()
+		watches <- fw
+		return true, fw, nil
+	})
+	return MakeKubeWatcher(ctx, zap.NewNop(), kubeClient, nil, nil, nil, testOptions()), watches
+}
+
+func TestRecreateDeadWatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var forbidden atomic.Bool
+	kw, watches := makeWatchdogTest(ctx, &forbidden)
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default", UID: "uid"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod"},
+	}
+	if err := kw.addWatch(ctx, w); err != nil {
+		t.Fatal(err)
+	}
+	ws := kw.watches["uid"]
+
+	// the watch ends and is forbidden to restart, so the subscription gives up
+	forbidden.Store(true)
+	(<-watches).Stop()
+	<-ws.done
+	if !ws.isDead() {
+		t.Fatal("expected the subscription to be dead")
+	}
+
+	kw.recreateDeadWatches(ctx, time.Minute)
+	d, ok := kw.dead["uid"]
+	if !ok || d.failures != 1 || time.Until(d.retryAt) <= 0 {
+		t.Fatalf("expected a failed attempt to be retried later, got %+v", d)
+	}
+	kw.recreateDeadWatches(ctx, time.Minute)
+	if d.failures != 1 {
+		t.Errorf("expected no attempt before the backoff passed, got %d failures", d.failures)
+	}
+
+	forbidden.Store(false)
+	d.retryAt = time.Time{}
+	kw.recreateDeadWatches(ctx, time.Minute)
+	<-watches
+	if kw.watches["uid"] == ws || len(kw.dead) != 0 {
+		t.Error("expected the dead watch to be recreated")
+	}
+	kw.watches["uid"].stop()
+}
+
+func TestDeadWatchOfDeletedTrigger(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var forbidden atomic.Bool
+	forbidden.Store(true)
+	kw, _ := makeWatchdogTest(ctx, &forbidden)
+	w := &fv1.KubernetesWatchTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "watch", Namespace: "default", UID: "uid"},
+		Spec:       fv1.KubernetesWatchTriggerSpec{Namespace: "default", Type: "pod"},
+	}
+	if err := kw.addWatch(ctx, w); err == nil {
+		t.Fatal("expected the watch to be forbidden")
+	}
+	if _, ok := kw.dead["uid"]; !ok {
+		t.Fatal("expected a watch failing to start to be dead")
+	}
+
+	if err := kw.removeWatch(w); err != nil {
+		t.Fatal(err)
+	}
+	forbidden.Store(false)
+	kw.recreateDeadWatches(ctx, time.Minute)
+	if len(kw.dead) != 0 || len(kw.watches) != 0 {
+		t.Error("expected the watch of a deleted trigger not to be recreated")
+	}
+}
+
+func TestRecreateBackoff(t *testing.T) {
+	for failures, expected := range map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		3:  4 * time.Minute,
+		10: maxRecreateBackoff,
+	} {
+		if backoff := recreateBackoff(time.Minute, failures); backoff != expected {
+			t.Errorf("expected backoff %v after %d failures, got %v", expected, failures, backoff)
+		}
+	}
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package kafka
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/pkg/errors"
@@ -40,6 +42,7 @@ type MqtConsumerGroupHandler struct {
 	producer       sarama.SyncProducer
 	fnUrl          string
 	ready          chan bool
+	retryBackoff   time.Duration
 }
 
 func NewMqtConsumerGroupHandler(version sarama.KafkaVersion,
@@ -63,10 +66,24 @@ func NewMqtConsumerGroupHandler(version sarama.KafkaVersion,
 	// Generate the Headers
 	ch.fissionHeaders = map[string]string{
 		"X-Fission-MQTrigger-Topic":      ch.trigger.Spec.Topic,
-		"X-Fission-MQTrigger-RespTopic":  ch.trigger.Spec.ResponseTopic,
+		"X-Fission-MQTrigger-RespTopic":  strings.Join(ch.trigger.Spec.GetResponseTopics(), ","),
 		"X-Fission-MQTrigger-ErrorTopic": ch.trigger.Spec.ErrorTopic,
 		"Content-Type":                   ch.trigger.Spec.ContentType,
 	}
+	if len(ch.trigger.Spec.ResponseContentType) > 0 {
+		ch.fissionHeaders["Accept"] = ch.trigger.Spec.ResponseContentType
+	}
+	if len(ch.trigger.Spec.RetryBackoff) > 0 {
+		backoff, err := time.ParseDuration(ch.trigger.Spec.RetryBackoff)
+		if err != nil {
+			ch.logger.Error("invalid retry backoff for trigger, retrying without delay",
+				zap.Error(err),
+				zap.String("retry_backoff", ch.trigger.Spec.RetryBackoff),
+				zap.String("trigger", ch.trigger.ObjectMeta.Name))
+		} else {
+			ch.retryBackoff = backoff
+		}
+	}
 	ch.fnUrl = routerUrl + "/" + strings.TrimPrefix(utils.UrlForFunction(ch.trigger.Spec.FunctionReference.Name, ch.trigger.ObjectMeta.Namespace), "/")
 	ch.logger.Debug("function HTTP URL", zap.String("url", ch.fnUrl))
 	return ch
@@ -159,7 +176,14 @@ func (ch *MqtConsumerGroupHandler) kafkaMsgHandler(msg *sarama.ConsumerMessage)
 
 	// Make the request
 	var resp *http.Response
+	retries := 0
 	for attempt := 0; attempt <= ch.trigger.Spec.MaxRetries; attempt++ {
+		retries = attempt
+		if attempt > 0 {
+			time.Sleep(retryDelay(ch.retryBackoff, attempt))
+			// the body was consumed by the previous attempt
+			req.Body, _ = req.GetBody()
+		}
 		// Make the request
 		resp, err = http.DefaultClient.Do(req)
 		if err != nil {
@@ -195,7 +219,7 @@ func (ch *MqtConsumerGroupHandler) kafkaMsgHandler(msg *sarama.ConsumerMessage)
 	if resp == nil {
 		errorString := fmt.Sprintf("request exceed retries: %v", ch.trigger.Spec.MaxRetries)
 		errorHeaders := generateErrorHeaders(errorString)
-		errorHandler(ch.logger, ch.trigger, ch.producer, ch.fnUrl,
+		errorHandler(ch.logger, ch.trigger, ch.producer, ch.fnUrl, msg, retries,
 			fmt.Errorf(errorString), errorHeaders)
 		return
 	}
@@ -210,52 +234,103 @@ func (ch *MqtConsumerGroupHandler) kafkaMsgHandler(msg *sarama.ConsumerMessage)
 	if err != nil {
 		errorString := "request body error: " + string(body)
 		errorHeaders := generateErrorHeaders(errorString)
-		errorHandler(ch.logger, ch.trigger, ch.producer, ch.fnUrl,
+		errorHandler(ch.logger, ch.trigger, ch.producer, ch.fnUrl, msg, retries,
 			errors.Wrapf(err, errorString), errorHeaders)
 		return
 	}
 	if resp.StatusCode != 200 {
 		errorString := fmt.Sprintf("request returned failure: %v, request body error: %v", resp.StatusCode, body)
 		errorHeaders := generateErrorHeaders(errorString)
-		errorHandler(ch.logger, ch.trigger, ch.producer, ch.fnUrl,
+		errorHandler(ch.logger, ch.trigger, ch.producer, ch.fnUrl, msg, retries,
 			fmt.Errorf("request returned failure: %v", resp.StatusCode), errorHeaders)
 		return
 	}
-	if len(ch.trigger.Spec.ResponseTopic) > 0 {
+	if respTopics := ch.trigger.Spec.GetResponseTopics(); len(respTopics) > 0 {
 		// Generate Kafka record headers
 		var kafkaRecordHeaders []sarama.RecordHeader
 		if ch.version.IsAtLeast(sarama.V0_11_0_0) {
 			for k, v := range resp.Header {
+				if http.CanonicalHeaderKey(k) == "Content-Type" {
+					continue
+				}
 				// One key may have multiple values
 				for _, v := range v {
 					kafkaRecordHeaders = append(kafkaRecordHeaders, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
 				}
 			}
+			respContentType := ch.trigger.Spec.ResponseContentType
+			if len(respContentType) == 0 {
+				respContentType = ch.trigger.Spec.ContentType
+			}
+			kafkaRecordHeaders = append(kafkaRecordHeaders, sarama.RecordHeader{Key: []byte("Content-Type"), Value: []byte(respContentType)})
 		} else {
 			ch.logger.Warn("headers are not supported by current Kafka version, needs v0.11+: no record headers to add in HTTP request",
 				zap.Any("current_version", ch.version))
 		}
 
-		_, _, err := ch.producer.SendMessage(&sarama.ProducerMessage{
-			Topic:   ch.trigger.Spec.ResponseTopic,
-			Value:   sarama.StringEncoder(body),
-			Headers: kafkaRecordHeaders,
-		})
-		if err != nil {
-			ch.logger.Warn("failed to publish response body from function invocation to topic",
-				zap.Error(err),
-				zap.String("topic", ch.trigger.Spec.Topic),
-				zap.String("function_url", ch.fnUrl))
-			return
+		// publish to every response topic, a failure on one doesn't keep
+		// the response from the others
+		for _, t := range respTopics {
+			_, _, err := ch.producer.SendMessage(&sarama.ProducerMessage{
+				Topic:   t,
+				Value:   sarama.StringEncoder(body),
+				Headers: kafkaRecordHeaders,
+			})
+			if err != nil {
+				ch.logger.Warn("failed to publish response body from function invocation to topic",
+					zap.Error(err),
+					zap.String("topic", t),
+					zap.String("function_url", ch.fnUrl))
+			}
 		}
 	}
 }
 
-func errorHandler(logger *zap.Logger, trigger *fv1.MessageQueueTrigger, producer sarama.SyncProducer, funcUrl string, err error, errorTopicHeaders []sarama.RecordHeader) {
+// errorEnvelope is the message sent to the error topic of triggers with the
+// envelope error format.
+type errorEnvelope struct {
+	// Message is the value of the message the function failed to process
+	Message   string    `json:"message"`
+	Topic     string    `json:"topic"`
+	Function  string    `json:"function"`
+	Error     string    `json:"error"`
+	Retries   int       `json:"retries"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// errorMessage returns the value sent to the error topic of the trigger for
+// the failed message, in the error format of the trigger.
+func errorMessage(trigger *fv1.MessageQueueTrigger, msg *sarama.ConsumerMessage, retries int, err error) (sarama.Encoder, error) {
+	if trigger.Spec.ErrorFormat != fv1.MessageQueueErrorFormatEnvelope {
+		return sarama.StringEncoder(err.Error()), nil
+	}
+	value, e := json.Marshal(errorEnvelope{
+		Message:   string(msg.Value),
+		Topic:     msg.Topic,
+		Function:  trigger.Spec.FunctionReference.Name,
+		Error:     err.Error(),
+		Retries:   retries,
+		Timestamp: time.Now().UTC(),
+	})
+	if e != nil {
+		return nil, e
+	}
+	return sarama.ByteEncoder(value), nil
+}
+
+func errorHandler(logger *zap.Logger, trigger *fv1.MessageQueueTrigger, producer sarama.SyncProducer, funcUrl string, msg *sarama.ConsumerMessage, retries int, err error, errorTopicHeaders []sarama.RecordHeader) {
 	if len(trigger.Spec.ErrorTopic) > 0 {
-		_, _, e := producer.SendMessage(&sarama.ProducerMessage{
+		value, e := errorMessage(trigger, msg, retries, err)
+		if e != nil {
+			logger.Error("failed to encode message to error topic",
+				zap.Error(e),
+				zap.String("trigger", trigger.ObjectMeta.Name),
+				zap.String("message", err.Error()))
+			return
+		}
+		_, _, e = producer.SendMessage(&sarama.ProducerMessage{
 			Topic:   trigger.Spec.ErrorTopic,
-			Value:   sarama.StringEncoder(err.Error()),
+			Value:   value,
 			Headers: errorTopicHeaders,
 		})
 		if e != nil {
@@ -270,3 +345,16 @@ func errorHandler(logger *zap.Logger, trigger *fv1.MessageQueueTrigger, producer
 			zap.String("message", err.Error()), zap.String("trigger", trigger.ObjectMeta.Name), zap.String("function_url", funcUrl))
 	}
 }
+
+// retryDelay returns the delay before the given retry attempt, doubling the
+// backoff on every attempt without exceeding fv1.MaxRetryBackoff.
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	delay := backoff
+	for i := 1; i < attempt && delay < fv1.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > fv1.MaxRetryBackoff {
+		delay = fv1.MaxRetryBackoff
+	}
+	return delay
+}
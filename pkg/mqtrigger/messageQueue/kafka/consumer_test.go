@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestErrorMessage(t *testing.T) {
+	trigger := &fv1.MessageQueueTrigger{
+		Spec: fv1.MessageQueueTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+			ErrorTopic:        "errors",
+		},
+	}
+	msg := &sarama.ConsumerMessage{Topic: "orders", Value: []byte(`{"id":1}`)}
+	failure := errors.New("request returned failure: 500")
+
+	value, err := errorMessage(trigger, msg, 2, failure)
+	require.NoError(t, err)
+	raw, err := value.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, failure.Error(), string(raw))
+
+	trigger.Spec.ErrorFormat = fv1.MessageQueueErrorFormatEnvelope
+	value, err = errorMessage(trigger, msg, 2, failure)
+	require.NoError(t, err)
+	raw, err = value.Encode()
+	require.NoError(t, err)
+	var envelope errorEnvelope
+	require.NoError(t, json.Unmarshal(raw, &envelope))
+	assert.Equal(t, `{"id":1}`, envelope.Message)
+	assert.Equal(t, "orders", envelope.Topic)
+	assert.Equal(t, "fn", envelope.Function)
+	assert.Equal(t, failure.Error(), envelope.Error)
+	assert.Equal(t, 2, envelope.Retries)
+	assert.False(t, envelope.Timestamp.IsZero())
+}
@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/pkg/errors"
@@ -46,6 +47,10 @@ var (
 
 	// Map for ErrorTopic messages to maintain recycle counter
 	errorMessageMap = make(map[string]int)
+
+	// topicPatternPollInterval is how often the topics are matched again while
+	// none matches the pattern of a trigger.
+	topicPatternPollInterval = 30 * time.Second
 )
 
 type (
@@ -55,6 +60,8 @@ type (
 		brokers   []string
 		version   sarama.KafkaVersion
 		client    sarama.Client
+		config    *sarama.Config
+		producer  sarama.SyncProducer
 		authKeys  map[string][]byte
 		tls       bool
 	}
@@ -73,8 +80,9 @@ func (factory *Factory) Create(logger *zap.Logger, mqCfg messageQueue.Config, ro
 }
 
 func New(logger *zap.Logger, mqCfg messageQueue.Config, routerUrl string) (messageQueue.MessageQueue, error) {
-	if len(routerUrl) == 0 || len(mqCfg.Url) == 0 {
-		return nil, errors.New("the router URL or MQ URL is empty")
+	// the router URL is only needed to subscribe, see Subscribe
+	if len(mqCfg.Url) == 0 {
+		return nil, errors.New("the MQ URL is empty")
 	}
 	mqKafkaVersion := os.Getenv("MESSAGE_QUEUE_KAFKA_VERSION")
 
@@ -94,7 +102,7 @@ func New(logger *zap.Logger, mqCfg messageQueue.Config, routerUrl string) (messa
 		version:   kafkaVersion,
 	}
 
-	if tls, _ := strconv.ParseBool(os.Getenv("TLS_ENABLED")); tls {
+	if tls, _ := strconv.ParseBool(os.Getenv("TLS_ENABLED")); tls || mqCfg.TLS {
 		kafka.tls = true
 
 		authKeys := make(map[string][]byte)
@@ -142,27 +150,53 @@ func New(logger *zap.Logger, mqCfg messageQueue.Config, routerUrl string) (messa
 	}
 
 	kafka.client = saramaClient
+	kafka.config = saramaConfig
+
+	producer, err := sarama.NewSyncProducerFromClient(saramaClient)
+	if err != nil {
+		return nil, err
+	}
+	kafka.producer = producer
 
 	return kafka, nil
 }
 
 func (kafka Kafka) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Subscription, error) {
+	if len(kafka.routerUrl) == 0 {
+		return nil, errors.New("the router URL is empty")
+	}
 	kafka.logger.Debug("inside kakfa subscribe", zap.Any("trigger", trigger))
 	kafka.logger.Debug("brokers set", zap.Strings("brokers", kafka.brokers))
 
-	consumer, err := sarama.NewConsumerGroupFromClient(string(trigger.ObjectMeta.UID), kafka.client)
+	// resolve the topics first so that nothing is left to close if it fails
+	topics, err := kafka.subscribedTopics(trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumer sarama.ConsumerGroup
+	if trigger.Spec.MaxInflight > 0 {
+		// The number of messages buffered ahead of processing is a client
+		// setting, so such triggers get a client of their own.
+		config := *kafka.config
+		config.ChannelBufferSize = trigger.Spec.MaxInflight
+		consumer, err = sarama.NewConsumerGroup(kafka.brokers, string(trigger.ObjectMeta.UID), &config)
+	} else {
+		consumer, err = sarama.NewConsumerGroupFromClient(string(trigger.ObjectMeta.UID), kafka.client)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	producer, err := sarama.NewSyncProducerFromClient(kafka.client)
 	if err != nil {
+		consumer.Close()
 		return nil, err
 	}
 
 	kafka.logger.Info("created a new producer and a new consumer", zap.Strings("brokers", kafka.brokers),
 		zap.String("topic", trigger.Spec.Topic),
-		zap.String("response topic", trigger.Spec.ResponseTopic),
+		zap.Strings("response topics", trigger.Spec.GetResponseTopics()),
 		zap.String("error topic", trigger.Spec.ErrorTopic),
 		zap.String("trigger", trigger.ObjectMeta.Name),
 		zap.String("function namespace", trigger.ObjectMeta.Namespace),
@@ -177,14 +211,31 @@ func (kafka Kafka) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Sub
 
 	ctx, cancel := context.WithCancel(context.Background())
 	ch := NewMqtConsumerGroupHandler(kafka.version, kafka.logger, trigger, producer, kafka.routerUrl)
+	ready, waitReady := ch.ready, len(topics) > 0
 
 	// consume messages
 	go func() {
-		topic := []string{trigger.Spec.Topic}
 		// Create a new session for the consumer group until the context is cancelled
 		for {
+			if len(topics) == 0 {
+				// no topic matches the pattern of the trigger yet
+				select {
+				case <-ctx.Done():
+					kafka.logger.Info("consumer context cancelled", zap.String("trigger", trigger.ObjectMeta.Name))
+					return
+				case <-time.After(topicPatternPollInterval):
+				}
+				matched, err := kafka.subscribedTopics(trigger)
+				if err != nil {
+					kafka.logger.Error("error matching topics", zap.Error(err), zap.String("trigger", trigger.ObjectMeta.Name))
+				} else {
+					topics = matched
+				}
+				continue
+			}
+
 			// Consume messages
-			err := consumer.Consume(ctx, topic, ch)
+			err := consumer.Consume(ctx, topics, ch)
 			if err != nil {
 				kafka.logger.Error("consumer error", zap.Error(err), zap.String("trigger", trigger.ObjectMeta.Name))
 			}
@@ -194,10 +245,24 @@ func (kafka Kafka) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Sub
 				return
 			}
 			ch.ready = make(chan bool)
+			if trigger.Spec.TopicPattern {
+				// pick up topics created since the last session
+				matched, err := kafka.subscribedTopics(trigger)
+				if err != nil {
+					kafka.logger.Error("error matching topics - keeping the previous ones", zap.Error(err), zap.String("trigger", trigger.ObjectMeta.Name))
+				} else {
+					topics = matched
+				}
+			}
 		}
 	}()
 
-	<-ch.ready // wait for consumer to be ready
+	if waitReady {
+		<-ready // wait for consumer to be ready
+	} else {
+		kafka.logger.Info("no topic matches the pattern yet, waiting for one to be created",
+			zap.String("trigger", trigger.ObjectMeta.Name), zap.String("pattern", trigger.Spec.Topic))
+	}
 
 	mqtConsumer := MqtConsumer{
 		ctx:      ctx,
@@ -207,14 +272,43 @@ func (kafka Kafka) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Sub
 	return mqtConsumer, nil
 }
 
-func (kafka Kafka) getTLSConfig() (*tls.Config, error) {
-	tlsConfig := tls.Config{}
-	cert, err := tls.X509KeyPair(kafka.authKeys["userCert"], kafka.authKeys["userKey"])
+// subscribedTopics returns the topics the trigger consumes, for a trigger with
+// TopicPattern the existing topics matching it, which may be none yet.
+func (kafka Kafka) subscribedTopics(trigger *fv1.MessageQueueTrigger) ([]string, error) {
+	if !trigger.Spec.TopicPattern {
+		return []string{trigger.Spec.Topic}, nil
+	}
+	pattern, err := regexp.Compile(trigger.Spec.Topic)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid topic pattern %q", trigger.Spec.Topic)
+	}
+	err = kafka.client.RefreshMetadata()
+	if err != nil {
+		return nil, err
+	}
+	all, err := kafka.client.Topics()
 	if err != nil {
 		return nil, err
 	}
+	var topics []string
+	for _, topic := range all {
+		if pattern.MatchString(topic) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics, nil
+}
 
-	tlsConfig.Certificates = []tls.Certificate{cert}
+func (kafka Kafka) getTLSConfig() (*tls.Config, error) {
+	tlsConfig := tls.Config{}
+	// without a client certificate the brokers are only verified
+	if len(kafka.authKeys["userCert"]) > 0 || len(kafka.authKeys["userKey"]) > 0 {
+		cert, err := tls.X509KeyPair(kafka.authKeys["userCert"], kafka.authKeys["userKey"])
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
 
 	skipVerify, err := strconv.ParseBool(os.Getenv("INSECURE_SKIP_VERIFY"))
 	if err != nil {
@@ -224,9 +318,12 @@ func (kafka Kafka) getTLSConfig() (*tls.Config, error) {
 		tlsConfig.InsecureSkipVerify = skipVerify
 	}
 
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(kafka.authKeys["caCert"])
-	tlsConfig.RootCAs = caCertPool
+	// without a CA certificate the brokers are verified with the system roots
+	if len(kafka.authKeys["caCert"]) > 0 {
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(kafka.authKeys["caCert"])
+		tlsConfig.RootCAs = caCertPool
+	}
 
 	return &tlsConfig, nil
 }
@@ -237,6 +334,73 @@ func (kafka Kafka) Unsubscribe(subscription messageQueue.Subscription) error {
 	return mqtConsumer.consumer.Close()
 }
 
+// Produce publishes body to topic, headers are sent as record headers if
+// the Kafka version supports them.
+func (kafka Kafka) Produce(topic string, body []byte, headers map[string]string) error {
+	msg := &sarama.ProducerMessage{
+		Topic: topic,
+		Value: sarama.ByteEncoder(body),
+	}
+	if kafka.version.IsAtLeast(sarama.V0_11_0_0) {
+		for k, v := range headers {
+			msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	_, _, err := kafka.producer.SendMessage(msg)
+	return err
+}
+
+// Tail streams the messages published to all partitions of topic from now on
+// to handler, until ctx is done.
+func (kafka Kafka) Tail(ctx context.Context, topic string, handler func(msg messageQueue.Message)) error {
+	consumer, err := sarama.NewConsumerFromClient(kafka.client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	partitions, err := consumer.Partitions(topic)
+	if err != nil {
+		return errors.Wrapf(err, "error getting partitions of topic %q", topic)
+	}
+	messages := make(chan *sarama.ConsumerMessage)
+	for _, partition := range partitions {
+		pc, err := consumer.ConsumePartition(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return errors.Wrapf(err, "error consuming partition %d of topic %q", partition, topic)
+		}
+		defer pc.Close()
+		go func(pc sarama.PartitionConsumer) {
+			for msg := range pc.Messages() {
+				select {
+				case messages <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(pc)
+	}
+
+	for {
+		select {
+		case msg := <-messages:
+			headers := make(map[string]string, len(msg.Headers))
+			for _, h := range msg.Headers {
+				headers[string(h.Key)] = string(h.Value)
+			}
+			handler(messageQueue.Message{
+				Topic:     msg.Topic,
+				Key:       msg.Key,
+				Value:     msg.Value,
+				Headers:   headers,
+				Timestamp: msg.Timestamp,
+			})
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 // The validation is based on Kafka's internal implementation:
 // https://github.com/apache/kafka/blob/cde6d18983b5d58199f8857d8d61d7efcbe6e54a/clients/src/main/java/org/apache/kafka/common/internals/Topic.java#L36-L47
 func IsTopicValid(topic string) bool {
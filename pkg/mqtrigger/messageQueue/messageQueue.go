@@ -17,6 +17,15 @@ limitations under the License.
 package messageQueue
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 )
 
@@ -27,10 +36,70 @@ type (
 		MQType  string
 		Url     string
 		Secrets map[string][]byte
+		// TLS connects with the certificates in Secrets even if the
+		// TLS_ENABLED env var of the process is not set.
+		TLS bool
 	}
 
 	MessageQueue interface {
 		Subscribe(trigger *fv1.MessageQueueTrigger) (Subscription, error)
 		Unsubscribe(triggerSub Subscription) error
 	}
+
+	// Producer is implemented by message queues that can publish messages
+	// on behalf of other components, e.g. the kubewatcher.
+	Producer interface {
+		Produce(topic string, body []byte, headers map[string]string) error
+	}
+
+	// Message is a message read from a topic by a Tailer.
+	Message struct {
+		Topic     string
+		Key       []byte
+		Value     []byte
+		Headers   map[string]string
+		Timestamp time.Time
+	}
+
+	// Tailer is implemented by message queues that can stream the new
+	// messages of a topic, e.g. to inspect the error topic of a trigger.
+	Tailer interface {
+		// Tail calls handler for each message published to topic until ctx is done.
+		Tail(ctx context.Context, topic string, handler func(msg Message)) error
+	}
 )
+
+// ReadSecrets reads the files in secretsPath, e.g. a mounted secret, into a map
+// keyed by file name, for authentication with the message queue.
+func ReadSecrets(logger *zap.Logger, secretsPath string) (map[string][]byte, error) {
+	// return if no secrets exist
+	if _, err := os.Stat(secretsPath); os.IsNotExist(err) {
+		return nil, err
+	}
+
+	secretFiles, err := os.ReadDir(secretsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string][]byte)
+	for _, secretFile := range secretFiles {
+
+		fileName := secretFile.Name()
+		// /etc/secrets contain some hidden directories (like .data)
+		// ignore them
+		if !secretFile.IsDir() && !strings.HasPrefix(fileName, ".") {
+			logger.Info(fmt.Sprintf("Reading secret from %s", fileName))
+
+			filePath := path.Join(secretsPath, fileName)
+			secret, fileReadErr := os.ReadFile(filePath)
+			if fileReadErr != nil {
+				return nil, fileReadErr
+			}
+
+			secrets[fileName] = secret
+		}
+	}
+
+	return secrets, nil
+}
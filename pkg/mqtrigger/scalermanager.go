@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -68,20 +70,22 @@ func mqTriggerEventHandlers(ctx context.Context, logger *zap.Logger, kubeClient
 				}
 				logger.Debug("Create deployment for Scaler Object", zap.Any("mqt", mqt.ObjectMeta), zap.Any("mqt.Spec", mqt.Spec))
 
-				authenticationRef := ""
-				if len(mqt.Spec.Secret) > 0 {
-					authenticationRef = fmt.Sprintf("%s-auth-trigger", mqt.ObjectMeta.Name)
-					err := createAuthTrigger(ctx, dynamicClient, mqt, authenticationRef, kubeClient)
+				// a referenced TriggerAuthentication is owned by the user and never created or deleted here
+				authenticationRef := mqt.Spec.AuthenticationRef
+				generatedAuthRef := generatedAuthTriggerName(mqt.ObjectMeta.Name, mqt.Spec)
+				if len(generatedAuthRef) > 0 {
+					err := createAuthTrigger(ctx, dynamicClient, mqt, generatedAuthRef, kubeClient)
 					if err != nil {
 						logger.Error("Failed to create Authentication Trigger", zap.Error(err))
 						return
 					}
+					authenticationRef = generatedAuthRef
 				}
 
 				if err := createDeployment(ctx, mqt, routerURL, kubeClient); err != nil {
 					logger.Error("Failed to create Deployment", zap.Error(err))
-					if len(authenticationRef) > 0 {
-						err = deleteAuthTrigger(ctx, dynamicClient, authenticationRef, mqt.ObjectMeta.Namespace)
+					if len(generatedAuthRef) > 0 {
+						err = deleteAuthTrigger(ctx, dynamicClient, generatedAuthRef, mqt.ObjectMeta.Namespace)
 						if err != nil {
 							logger.Error("Failed to delete Authentication Trigger", zap.Error(err))
 						}
@@ -91,8 +95,8 @@ func mqTriggerEventHandlers(ctx context.Context, logger *zap.Logger, kubeClient
 
 				if err := createScaledObject(ctx, dynamicClient, mqt, authenticationRef); err != nil {
 					logger.Error("Failed to create ScaledObject", zap.Error(err))
-					if len(authenticationRef) > 0 {
-						if err = deleteAuthTrigger(ctx, dynamicClient, authenticationRef, mqt.ObjectMeta.Namespace); err != nil {
+					if len(generatedAuthRef) > 0 {
+						if err = deleteAuthTrigger(ctx, dynamicClient, generatedAuthRef, mqt.ObjectMeta.Namespace); err != nil {
 							logger.Error("Failed to delete Authentication Trigger", zap.Error(err))
 						}
 					}
@@ -106,6 +110,7 @@ func mqTriggerEventHandlers(ctx context.Context, logger *zap.Logger, kubeClient
 			go func() {
 				mqt := obj.(*fv1.MessageQueueTrigger)
 				newMqt := newObj.(*fv1.MessageQueueTrigger)
+				oldSpec := mqt.Spec
 				updated := checkAndUpdateTriggerFields(mqt, newMqt)
 				if mqt.Spec.MqtKind == "fission" {
 					return
@@ -115,13 +120,10 @@ func mqTriggerEventHandlers(ctx context.Context, logger *zap.Logger, kubeClient
 					return
 				}
 
-				authenticationRef := ""
-				if len(newMqt.Spec.Secret) > 0 && newMqt.Spec.Secret != mqt.Spec.Secret {
-					authenticationRef = fmt.Sprintf("%s-auth-trigger", mqt.ObjectMeta.Name)
-					if err := updateAuthTrigger(ctx, dynamicClient, mqt, authenticationRef, kubeClient); err != nil {
-						logger.Error("Failed to update Authentication Trigger", zap.Error(err))
-						return
-					}
+				authenticationRef, err := ensureAuthTrigger(ctx, dynamicClient, mqt, oldSpec, kubeClient)
+				if err != nil {
+					logger.Error("Failed to update Authentication Trigger", zap.Error(err))
+					return
 				}
 
 				if err := updateDeployment(ctx, mqt, routerURL, kubeClient); err != nil {
@@ -133,6 +135,11 @@ func mqTriggerEventHandlers(ctx context.Context, logger *zap.Logger, kubeClient
 					logger.Error("Failed to Update ScaledObject", zap.Error(err))
 					return
 				}
+
+				// the ScaledObject no longer references it
+				if err := deleteStaleAuthTrigger(ctx, dynamicClient, mqt, oldSpec); err != nil {
+					logger.Error("Failed to delete Authentication Trigger", zap.Error(err))
+				}
 			}()
 		},
 	}
@@ -213,6 +220,44 @@ func getEnvVarlist(ctx context.Context, mqt *fv1.MessageQueueTrigger, routerURL
 			Value: mqt.Spec.ContentType,
 		},
 	}
+	if len(mqt.Spec.ResponseTopics) > 0 {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  "RESPONSE_TOPICS",
+			Value: strings.Join(mqt.Spec.GetResponseTopics(), ","),
+		})
+	}
+	if len(mqt.Spec.ResponseContentType) > 0 {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  "RESPONSE_CONTENT_TYPE",
+			Value: mqt.Spec.ResponseContentType,
+		})
+	}
+	if len(mqt.Spec.RetryBackoff) > 0 {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  "RETRY_BACKOFF",
+			Value: mqt.Spec.RetryBackoff,
+		})
+	}
+	if len(mqt.Spec.ErrorFormat) > 0 {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  "ERROR_FORMAT",
+			Value: string(mqt.Spec.ErrorFormat),
+		})
+	}
+	if mqt.Spec.TopicPattern {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  "TOPIC_PATTERN",
+			Value: "true",
+		})
+	}
+	// MAX_INFLIGHT is a hint, connectors that can't bound how many messages
+	// their consumer prefetches ignore it and keep their default
+	if mqt.Spec.MaxInflight > 0 {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  "MAX_INFLIGHT",
+			Value: strconv.Itoa(mqt.Spec.MaxInflight),
+		})
+	}
 	// Metadata Fields
 	for key, value := range mqt.Spec.Metadata {
 		envVars = append(envVars, apiv1.EnvVar{
@@ -244,10 +289,23 @@ func checkAndUpdateTriggerFields(mqt, newMqt *fv1.MessageQueueTrigger) bool {
 		mqt.Spec.Topic = newMqt.Spec.Topic
 		updated = true
 	}
+	if newMqt.Spec.ErrorFormat != mqt.Spec.ErrorFormat {
+		mqt.Spec.ErrorFormat = newMqt.Spec.ErrorFormat
+		updated = true
+	}
+	if newMqt.Spec.TopicPattern != mqt.Spec.TopicPattern {
+		mqt.Spec.TopicPattern = newMqt.Spec.TopicPattern
+		updated = true
+	}
 	if len(newMqt.Spec.ResponseTopic) > 0 && newMqt.Spec.ResponseTopic != mqt.Spec.ResponseTopic {
 		mqt.Spec.ResponseTopic = newMqt.Spec.ResponseTopic
 		updated = true
 	}
+	if !slices.Equal(newMqt.Spec.ResponseTopics, mqt.Spec.ResponseTopics) {
+		mqt.Spec.ResponseTopic = newMqt.Spec.ResponseTopic
+		mqt.Spec.ResponseTopics = newMqt.Spec.ResponseTopics
+		updated = true
+	}
 	if len(newMqt.Spec.ErrorTopic) > 0 && newMqt.Spec.ErrorTopic != mqt.Spec.ErrorTopic {
 		mqt.Spec.ErrorTopic = newMqt.Spec.ErrorTopic
 		updated = true
@@ -256,6 +314,14 @@ func checkAndUpdateTriggerFields(mqt, newMqt *fv1.MessageQueueTrigger) bool {
 		mqt.Spec.MaxRetries = newMqt.Spec.MaxRetries
 		updated = true
 	}
+	if newMqt.Spec.RetryBackoff != mqt.Spec.RetryBackoff {
+		mqt.Spec.RetryBackoff = newMqt.Spec.RetryBackoff
+		updated = true
+	}
+	if newMqt.Spec.MaxInflight != mqt.Spec.MaxInflight {
+		mqt.Spec.MaxInflight = newMqt.Spec.MaxInflight
+		updated = true
+	}
 	if len(newMqt.Spec.FunctionReference.Name) > 0 && newMqt.Spec.FunctionReference.Name != mqt.Spec.FunctionReference.Name {
 		mqt.Spec.FunctionReference.Name = newMqt.Spec.FunctionReference.Name
 		updated = true
@@ -264,6 +330,10 @@ func checkAndUpdateTriggerFields(mqt, newMqt *fv1.MessageQueueTrigger) bool {
 		mqt.Spec.ContentType = newMqt.Spec.ContentType
 		updated = true
 	}
+	if newMqt.Spec.ResponseContentType != mqt.Spec.ResponseContentType {
+		mqt.Spec.ResponseContentType = newMqt.Spec.ResponseContentType
+		updated = true
+	}
 	if *newMqt.Spec.PollingInterval >= 0 && *newMqt.Spec.PollingInterval != *mqt.Spec.PollingInterval {
 		mqt.Spec.PollingInterval = newMqt.Spec.PollingInterval
 		updated = true
@@ -302,11 +372,21 @@ func checkAndUpdateTriggerFields(mqt, newMqt *fv1.MessageQueueTrigger) bool {
 		updated = true
 	}
 
+	if newMqt.Spec.AuthenticationRef != mqt.Spec.AuthenticationRef {
+		mqt.Spec.AuthenticationRef = newMqt.Spec.AuthenticationRef
+		updated = true
+	}
+
 	if newMqt.Spec.MqtKind != mqt.Spec.MqtKind {
 		mqt.Spec.MqtKind = newMqt.Spec.MqtKind
 		updated = true
 	}
 
+	if !reflect.DeepEqual(newMqt.ObjectMeta.Labels, mqt.ObjectMeta.Labels) {
+		mqt.ObjectMeta.Labels = newMqt.ObjectMeta.Labels
+		updated = true
+	}
+
 	return updated
 }
 
@@ -335,7 +415,7 @@ func getAuthTriggerSpec(ctx context.Context, mqt *fv1.MessageQueueTrigger, authe
 						"kind":               "MessageQueueTrigger",
 						"apiVersion":         "fission.io/v1",
 						"name":               mqt.ObjectMeta.Name,
-						"uid":                mqt.ObjectMeta.UID,
+						"uid":                string(mqt.ObjectMeta.UID),
 						"blockOwnerDeletion": true,
 					},
 				},
@@ -381,6 +461,54 @@ func updateAuthTrigger(ctx context.Context, client dynamic.Interface, mqt *fv1.M
 	return nil
 }
 
+// generatedAuthTriggerName returns the name of the TriggerAuthentication
+// generated for a trigger with the spec, or "" if the trigger references a
+// TriggerAuthentication of the user or has no secret.
+func generatedAuthTriggerName(name string, spec fv1.MessageQueueTriggerSpec) string {
+	if len(spec.AuthenticationRef) > 0 || len(spec.Secret) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s-auth-trigger", name)
+}
+
+// ensureAuthTrigger returns the TriggerAuthentication the ScaledObject of an
+// updated trigger references: its AuthenticationRef or, if it only has a
+// Secret, the generated one, which is created or updated from the secret
+// unless it was already generated from the same secret. oldSpec is the spec
+// of the trigger before the update.
+func ensureAuthTrigger(ctx context.Context, client dynamic.Interface, mqt *fv1.MessageQueueTrigger, oldSpec fv1.MessageQueueTriggerSpec, kubeClient kubernetes.Interface) (string, error) {
+	authenticationRef := generatedAuthTriggerName(mqt.ObjectMeta.Name, mqt.Spec)
+	if len(authenticationRef) == 0 {
+		return mqt.Spec.AuthenticationRef, nil
+	}
+	if generatedAuthTriggerName(mqt.ObjectMeta.Name, oldSpec) == authenticationRef && oldSpec.Secret == mqt.Spec.Secret {
+		return authenticationRef, nil
+	}
+	err := updateAuthTrigger(ctx, client, mqt, authenticationRef, kubeClient)
+	if k8serrors.IsNotFound(err) {
+		err = createAuthTrigger(ctx, client, mqt, authenticationRef, kubeClient)
+	}
+	if err != nil {
+		return "", err
+	}
+	return authenticationRef, nil
+}
+
+// deleteStaleAuthTrigger deletes the TriggerAuthentication generated for the
+// secret of a trigger that references a TriggerAuthentication of the user
+// since the update, or no longer has a secret.
+func deleteStaleAuthTrigger(ctx context.Context, client dynamic.Interface, mqt *fv1.MessageQueueTrigger, oldSpec fv1.MessageQueueTriggerSpec) error {
+	oldAuthenticationRef := generatedAuthTriggerName(mqt.ObjectMeta.Name, oldSpec)
+	if len(oldAuthenticationRef) == 0 || len(generatedAuthTriggerName(mqt.ObjectMeta.Name, mqt.Spec)) > 0 {
+		return nil
+	}
+	err := deleteAuthTrigger(ctx, client, oldAuthenticationRef, mqt.ObjectMeta.Namespace)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func deleteAuthTrigger(ctx context.Context, client dynamic.Interface, name, namespace string) error {
 	authTriggerClient := getAuthTriggerClient(client, namespace)
 	err := authTriggerClient.Delete(ctx, name, metav1.DeleteOptions{})
@@ -390,6 +518,18 @@ func deleteAuthTrigger(ctx context.Context, client dynamic.Interface, name, name
 	return nil
 }
 
+// getResourceLabels returns the labels of the trigger to set on the resources
+// created for it. The labels Fission relies on take precedence over the
+// trigger's own labels.
+func getResourceLabels(mqt *fv1.MessageQueueTrigger) map[string]string {
+	labels := make(map[string]string, len(mqt.ObjectMeta.Labels)+1)
+	for k, v := range mqt.ObjectMeta.Labels {
+		labels[k] = v
+	}
+	labels["app"] = mqt.ObjectMeta.Name
+	return labels
+}
+
 func getDeploymentSpec(ctx context.Context, mqt *fv1.MessageQueueTrigger, routerURL string, kubeClient kubernetes.Interface) (*appsv1.Deployment, error) {
 	envVars, err := getEnvVarlist(ctx, mqt, routerURL, kubeClient)
 	if err != nil {
@@ -417,10 +557,8 @@ func getDeploymentSpec(ctx context.Context, mqt *fv1.MessageQueueTrigger, router
 	blockOwnerDeletion := true
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: mqt.ObjectMeta.Name,
-			Labels: map[string]string{
-				"app": mqt.ObjectMeta.Name,
-			},
+			Name:   mqt.ObjectMeta.Name,
+			Labels: getResourceLabels(mqt),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					Kind:               "MessageQueueTrigger",
@@ -439,9 +577,7 @@ func getDeploymentSpec(ctx context.Context, mqt *fv1.MessageQueueTrigger, router
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app": mqt.ObjectMeta.Name,
-					},
+					Labels: getResourceLabels(mqt),
 				},
 				Spec: *podSpec,
 			},
@@ -484,7 +620,7 @@ func deleteDeployment(ctx context.Context, name string, namespace string, kubeCl
 }
 
 func getScaledObject(mqt *fv1.MessageQueueTrigger, authenticationRef string) *unstructured.Unstructured {
-	return &unstructured.Unstructured{
+	scaledObject := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"kind":       "ScaledObject",
 			"apiVersion": apiVersion,
@@ -496,7 +632,7 @@ func getScaledObject(mqt *fv1.MessageQueueTrigger, authenticationRef string) *un
 						"kind":               "MessageQueueTrigger",
 						"apiVersion":         "fission.io/v1",
 						"name":               mqt.ObjectMeta.Name,
-						"uid":                mqt.ObjectMeta.UID,
+						"uid":                string(mqt.ObjectMeta.UID),
 						"blockOwnerDeletion": true,
 					},
 				},
@@ -521,6 +657,8 @@ func getScaledObject(mqt *fv1.MessageQueueTrigger, authenticationRef string) *un
 			},
 		},
 	}
+	scaledObject.SetLabels(getResourceLabels(mqt))
+	return scaledObject
 }
 
 func createScaledObject(ctx context.Context, client dynamic.Interface, mqt *fv1.MessageQueueTrigger, authenticationRef string) error {
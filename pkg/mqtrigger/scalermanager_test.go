@@ -9,8 +9,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -39,6 +43,25 @@ func Test_toEnvVar(t *testing.T) {
 	}
 }
 
+func Test_getResourceLabels(t *testing.T) {
+	mqt := &fv1.MessageQueueTrigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Labels: map[string]string{
+				"cost-center": "team-a",
+				"app":         "overridden",
+			},
+		},
+	}
+	want := map[string]string{
+		"cost-center": "team-a",
+		"app":         "test",
+	}
+	assert.Equal(t, want, getResourceLabels(mqt))
+	assert.Equal(t, "overridden", mqt.ObjectMeta.Labels["app"])
+	assert.Equal(t, want, getScaledObject(mqt, "").GetLabels())
+}
+
 func Test_getEnvVarlist(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -63,6 +86,7 @@ func Test_getEnvVarlist(t *testing.T) {
 			ResponseTopic:    "response-topic",
 			ErrorTopic:       "error-topic",
 			MaxRetries:       4,
+			MaxInflight:      20,
 			ContentType:      "application/json",
 			PollingInterval:  &pollingInterval,
 			CooldownPeriod:   &cooldownPeriod,
@@ -133,6 +157,10 @@ func Test_getEnvVarlist(t *testing.T) {
 			Name:  "CONTENT_TYPE",
 			Value: "application/json",
 		},
+		{
+			Name:  "MAX_INFLIGHT",
+			Value: "20",
+		},
 		{
 			Name:  "BOOTSTRAP_SERVERS",
 			Value: "my-cluster-kafka-brokers.my-kafka-project.svc:9092",
@@ -450,7 +478,7 @@ func Test_getAuthTriggerSpec(t *testing.T) {
 						"kind":               "MessageQueueTrigger",
 						"apiVersion":         "fission.io/v1",
 						"name":               mqt1.ObjectMeta.Name,
-						"uid":                mqt1.ObjectMeta.UID,
+						"uid":                string(mqt1.ObjectMeta.UID),
 						"blockOwnerDeletion": true,
 					},
 				},
@@ -571,3 +599,69 @@ func Test_getAuthTriggerSpec(t *testing.T) {
 		})
 	}
 }
+
+func Test_getScaledObject(t *testing.T) {
+	mqt := &fv1.MessageQueueTrigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: fv1.MessageQueueTriggerSpec{
+			MessageQueueType:  "kafka",
+			AuthenticationRef: "kafka-auth",
+		},
+	}
+	triggers, _, _ := unstructured.NestedFieldNoCopy(getScaledObject(mqt, mqt.Spec.AuthenticationRef).Object, "spec", "triggers")
+	trigger := triggers.([]interface{})[0].(map[string]interface{})
+	name, _, _ := unstructured.NestedString(trigger, "authenticationRef", "name")
+	if name != "kafka-auth" {
+		t.Errorf("expected authenticationRef kafka-auth, got %q", name)
+	}
+}
+
+func Test_ensureAuthTrigger(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-secrets", Namespace: apiv1.NamespaceDefault},
+		Data:       map[string][]byte{"username": []byte("admin")},
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{authTriggerGVR: "TriggerAuthenticationList"})
+	mqt := &fv1.MessageQueueTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "mqt", Namespace: apiv1.NamespaceDefault},
+		Spec:       fv1.MessageQueueTriggerSpec{AuthenticationRef: "user-auth"},
+	}
+
+	ref, err := ensureAuthTrigger(ctx, dynamicClient, mqt, mqt.Spec, kubeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-auth", ref, "a TriggerAuthentication of the user is referenced as is")
+
+	// switching from a TriggerAuthentication of the user to a secret
+	oldSpec := mqt.Spec
+	mqt.Spec.AuthenticationRef = ""
+	mqt.Spec.Secret = "kafka-secrets"
+	ref, err = ensureAuthTrigger(ctx, dynamicClient, mqt, oldSpec, kubeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "mqt-auth-trigger", ref)
+	authTriggerClient := getAuthTriggerClient(dynamicClient, apiv1.NamespaceDefault)
+	_, err = authTriggerClient.Get(ctx, ref, metav1.GetOptions{})
+	assert.NoError(t, err, "expected the generated TriggerAuthentication to be created")
+	assert.NoError(t, deleteStaleAuthTrigger(ctx, dynamicClient, mqt, oldSpec))
+
+	// another field changed, the secret is not read again
+	deletedSecretClient := fake.NewSimpleClientset()
+	ref, err = ensureAuthTrigger(ctx, dynamicClient, mqt, mqt.Spec, deletedSecretClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "mqt-auth-trigger", ref)
+
+	// switching from the secret to a TriggerAuthentication of the user
+	oldSpec = mqt.Spec
+	mqt.Spec.AuthenticationRef = "user-auth"
+	ref, err = ensureAuthTrigger(ctx, dynamicClient, mqt, oldSpec, kubeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-auth", ref)
+	assert.NoError(t, deleteStaleAuthTrigger(ctx, dynamicClient, mqt, oldSpec))
+	_, err = authTriggerClient.Get(ctx, "mqt-auth-trigger", metav1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err), "expected the generated TriggerAuthentication to be deleted, got %v", err)
+	assert.NoError(t, deleteStaleAuthTrigger(ctx, dynamicClient, mqt, oldSpec), "a deleted TriggerAuthentication is ignored")
+}
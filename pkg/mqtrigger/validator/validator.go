@@ -17,6 +17,11 @@ limitations under the License.
 package validator
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -33,10 +38,69 @@ var (
 		"redis":              true,
 		"nats-jetstream":     true,
 	}
+	// topicPatternMqTypes are the message queue types that can subscribe to
+	// the topics matching a regular expression.
+	topicPatternMqTypes = map[string]bool{
+		"kafka": true,
+	}
+	// secretSchemas describe the keys of the secret of a trigger the KEDA
+	// scaler of the message queue type authenticates with.
+	secretSchemas = map[string]secretSchema{
+		"kafka": {
+			optional: []string{"sasl", "username", "password", "tls", "ca", "cert", "key"},
+		},
+		"aws-sqs-queue": {
+			anyOf: [][]string{{"awsAccessKeyID", "awsSecretAccessKey"}, {"awsRoleArn"}},
+		},
+		"aws-kinesis-stream": {
+			anyOf: [][]string{{"awsAccessKeyID", "awsSecretAccessKey"}, {"awsRoleArn"}},
+		},
+		"gcp-pubsub": {
+			required: []string{"GoogleApplicationCredentials"},
+		},
+		"rabbitmq": {
+			required: []string{"host"},
+		},
+		"redis": {
+			optional: []string{"username", "password"},
+		},
+	}
+	// kafkaSASLMechanisms are the values of the sasl metadata the KEDA kafka
+	// scaler accepts.
+	kafkaSASLMechanisms = map[string]bool{
+		"none":         true,
+		"plaintext":    true,
+		"scram_sha256": true,
+		"scram_sha512": true,
+		"oauthbearer":  true,
+		"gssapi":       true,
+	}
 )
 
+// kafkaSASLKey is the metadata key of the SASL mechanism of kafka triggers.
+const kafkaSASLKey = "sasl"
+
+// kafkaSASLCredentialMechanisms are the SASL mechanisms the KEDA kafka
+// scaler authenticates with a username and password for.
+var kafkaSASLCredentialMechanisms = map[string]bool{
+	"plaintext":    true,
+	"scram_sha256": true,
+	"scram_sha512": true,
+}
+
 type (
 	TopicValidator func(topic string) bool
+
+	// secretSchema lists the keys a scaler reads from the secret of a trigger.
+	secretSchema struct {
+		// required keys must all be in the secret.
+		required []string
+		// anyOf are alternative sets of keys, one of which must be complete,
+		// e.g. static credentials or a role to assume.
+		anyOf [][]string
+		// optional keys are read if they are in the secret.
+		optional []string
+	}
 )
 
 func Register(mqType string, validator TopicValidator) {
@@ -73,3 +137,130 @@ func IsValidMessageQueue(mqType, mqtKind string) bool {
 	_, registered := topicValidators[mqType]
 	return registered
 }
+
+// MessageQueueTypes returns the sorted message queue types triggers of the
+// kind, keda or fission, can be created for.
+func MessageQueueTypes(mqtKind string) []string {
+	var types []string
+	if mqtKind == "keda" {
+		for mqType := range kedaMqTypeValidators {
+			types = append(types, mqType)
+		}
+	} else {
+		lock.Lock()
+		for mqType := range topicValidators {
+			types = append(types, mqType)
+		}
+		lock.Unlock()
+	}
+	sort.Strings(types)
+	return types
+}
+
+// SecretKeys returns the keys of the secret of a trigger the KEDA scaler of
+// the message queue type authenticates with, nil if they are not known.
+func SecretKeys(mqType string) []string {
+	schema, ok := secretSchemas[mqType]
+	if !ok {
+		return nil
+	}
+	keys := append([]string{}, schema.required...)
+	for _, set := range schema.anyOf {
+		for _, key := range set {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return append(keys, schema.optional...)
+}
+
+// ValidateSecretKeys checks that the data of the secret of a trigger has the
+// keys the KEDA scaler of the message queue type needs, and names the ones
+// that are missing. Secrets of message queue types without a known schema
+// are not checked.
+func ValidateSecretKeys(mqType string, data map[string]string) error {
+	schema, ok := secretSchemas[mqType]
+	if !ok {
+		return nil
+	}
+	missing := missingKeys(data, schema.required)
+	if mqType == "kafka" {
+		if kafkaSASLCredentialMechanisms[data[kafkaSASLKey]] {
+			missing = append(missing, missingKeys(data, []string{"username", "password"})...)
+		}
+		// a client certificate needs its key and the other way around
+		if _, hasCert := data["cert"]; hasCert {
+			missing = append(missing, missingKeys(data, []string{"key"})...)
+		} else if _, hasKey := data["key"]; hasKey {
+			missing = append(missing, "cert")
+		}
+	}
+
+	var alternatives []string
+	if len(schema.anyOf) > 0 {
+		// report the alternative closest to complete, if none is
+		var closest []string
+		for i, set := range schema.anyOf {
+			setMissing := missingKeys(data, set)
+			if len(setMissing) == 0 {
+				closest, alternatives = nil, nil
+				break
+			}
+			if i == 0 || len(setMissing) < len(closest) {
+				closest = setMissing
+			}
+			alternatives = append(alternatives, strings.Join(set, " and "))
+		}
+		missing = append(missing, closest...)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("secret is missing the keys %s the %s scaler needs", strings.Join(missing, ", "), mqType)
+	if len(alternatives) > 0 {
+		msg += fmt.Sprintf(", it must have %s", strings.Join(alternatives, ", or "))
+	}
+	return errors.New(msg)
+}
+
+// missingKeys returns the keys that are not in data.
+func missingKeys(data map[string]string, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if _, ok := data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// IsTopicPatternSupported returns true if the message queue type can subscribe
+// to the topics matching a regular expression.
+func IsTopicPatternSupported(mqType string) bool {
+	return topicPatternMqTypes[mqType]
+}
+
+// ValidateMetadata checks the metadata of a trigger of the message queue
+// type for values the scaler is known to reject. It doesn't connect to the
+// message queue.
+func ValidateMetadata(mqType string, metadata map[string]string) error {
+	if mqType != "kafka" {
+		return nil
+	}
+	mechanism, ok := metadata[kafkaSASLKey]
+	if !ok {
+		return nil
+	}
+	if !kafkaSASLMechanisms[mechanism] {
+		supported := make([]string, 0, len(kafkaSASLMechanisms))
+		for m := range kafkaSASLMechanisms {
+			supported = append(supported, m)
+		}
+		sort.Strings(supported)
+		return fmt.Errorf("unsupported %s mechanism %q for kafka, supported mechanisms are: %s",
+			kafkaSASLKey, mechanism, strings.Join(supported, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	assert.NoError(t, ValidateMetadata("kafka", map[string]string{"sasl": "scram_sha512"}))
+	assert.NoError(t, ValidateMetadata("kafka", map[string]string{"bootstrapServers": "kafka:9092"}))
+	assert.ErrorContains(t, ValidateMetadata("kafka", map[string]string{"sasl": "scram-sha-512"}), "scram_sha512")
+	// other message queue types don't use the sasl key
+	assert.NoError(t, ValidateMetadata("rabbitmq", map[string]string{"sasl": "scram-sha-512"}))
+}
+
+func TestMessageQueueTypes(t *testing.T) {
+	types := MessageQueueTypes("keda")
+	assert.Contains(t, types, "kafka")
+	assert.Contains(t, types, "rabbitmq")
+	assert.IsIncreasing(t, types)
+}
+
+func TestValidateSecretKeys(t *testing.T) {
+	assert.NoError(t, ValidateSecretKeys("rabbitmq", map[string]string{"host": "amqp://rabbitmq"}))
+	assert.EqualError(t, ValidateSecretKeys("rabbitmq", map[string]string{}), "secret is missing the keys host the rabbitmq scaler needs")
+	// types without a known schema are not checked
+	assert.NoError(t, ValidateSecretKeys("nats-jetstream", map[string]string{}))
+
+	assert.NoError(t, ValidateSecretKeys("aws-sqs-queue", map[string]string{"awsRoleArn": "arn"}))
+	assert.NoError(t, ValidateSecretKeys("aws-sqs-queue", map[string]string{"awsAccessKeyID": "id", "awsSecretAccessKey": "secret"}))
+	assert.EqualError(t, ValidateSecretKeys("aws-sqs-queue", map[string]string{"awsAccessKeyID": "id"}),
+		"secret is missing the keys awsSecretAccessKey the aws-sqs-queue scaler needs, it must have awsAccessKeyID and awsSecretAccessKey, or awsRoleArn")
+
+	assert.NoError(t, ValidateSecretKeys("kafka", map[string]string{"sasl": "none"}))
+	assert.EqualError(t, ValidateSecretKeys("kafka", map[string]string{"sasl": "scram_sha512", "username": "user"}),
+		"secret is missing the keys password the kafka scaler needs")
+	assert.EqualError(t, ValidateSecretKeys("kafka", map[string]string{"tls": "enable", "key": "k"}),
+		"secret is missing the keys cert the kafka scaler needs")
+
+	assert.Equal(t, []string{"awsAccessKeyID", "awsSecretAccessKey", "awsRoleArn"}, SecretKeys("aws-sqs-queue"))
+}
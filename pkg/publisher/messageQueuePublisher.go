@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publisher
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+
+	"github.com/fission/fission/pkg/mqtrigger/messageQueue"
+)
+
+// MessageQueuePublisher publishes to the topic given as target. Satisfies the
+// Publisher interface.
+type MessageQueuePublisher struct {
+	logger   *zap.Logger
+	producer messageQueue.Producer
+}
+
+// MakeMessageQueuePublisher creates a MessageQueuePublisher sending messages with producer.
+func MakeMessageQueuePublisher(logger *zap.Logger, producer messageQueue.Producer) *MessageQueuePublisher {
+	return &MessageQueuePublisher{
+		logger:   logger.Named("message_queue_publisher"),
+		producer: producer,
+	}
+}
+
+// Publish sends body and headers as a message to the topic target
+func (p *MessageQueuePublisher) Publish(ctx context.Context, body []byte, headers map[string]string, target string) {
+	p.PublishWithOptions(ctx, body, headers, target, PublishOptions{})
+}
+
+// PublishWithOptions sends the message like Publish. Only the Done option
+// applies to messages, it is called once the message is produced.
+func (p *MessageQueuePublisher) PublishWithOptions(ctx context.Context, body []byte, headers map[string]string, target string, opts PublishOptions) {
+	tracer := otel.Tracer("MessageQueuePublisher")
+	_, span := tracer.Start(ctx, "MessageQueuePublisher/Publish")
+	defer span.End()
+
+	err := p.producer.Produce(target, body, headers)
+	if err != nil {
+		p.logger.Error("error publishing message", zap.Error(err), zap.String("topic", target))
+	}
+	if opts.Done != nil {
+		opts.Done(err)
+	}
+}
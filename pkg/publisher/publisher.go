@@ -16,7 +16,10 @@ limitations under the License.
 
 package publisher
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Publisher interface wraps the Publish method that publishes an request
@@ -24,7 +27,37 @@ type (
 	Publisher interface {
 		// Publish a request to a "target". Target's meaning depends on the
 		// publisher: it's a URL in the case of a webhook publisher, or a queue
-		// name in a queue-based publisher such as NATS.
-		Publish(ctx context.Context, body string, headers map[string]string, target string)
+		// name in a queue-based publisher such as NATS. The body is passed as raw
+		// bytes so that serialized payloads can be published without being copied.
+		Publish(ctx context.Context, body []byte, headers map[string]string, target string)
+	}
+
+	// PublishOptions override settings of the publisher for a single publish.
+	PublishOptions struct {
+		// Timeout of the request to the target, the publisher default is used
+		// if it is not positive.
+		Timeout time.Duration
+		// Method of the request to the target, POST if it is empty.
+		Method string
+		// Done, if set, is called once the outcome of the publish is known:
+		// with nil if the target handled it, or with the error it failed
+		// with, after any retries.
+		Done func(err error)
+	}
+
+	// OptionsPublisher is a Publisher accepting PublishOptions.
+	OptionsPublisher interface {
+		Publisher
+		PublishWithOptions(ctx context.Context, body []byte, headers map[string]string, target string, opts PublishOptions)
 	}
 )
+
+// PublishWithOptions publishes with opts if p supports them, otherwise it
+// falls back to a plain Publish.
+func PublishWithOptions(ctx context.Context, p Publisher, body []byte, headers map[string]string, target string, opts PublishOptions) {
+	if op, ok := p.(OptionsPublisher); ok {
+		op.PublishWithOptions(ctx, body, headers, target, opts)
+		return
+	}
+	p.Publish(ctx, body, headers, target)
+}
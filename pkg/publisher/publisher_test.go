@@ -2,6 +2,7 @@ package publisher
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -28,7 +29,139 @@ func TestPublisher(t *testing.T) {
 		defer shutdown(ctx)
 	}
 
-	wp := MakeWebhookPublisher(logger, s.URL)
-	wp.Publish(ctx, "", map[string]string{"X-Fission-Test": "aaa"}, fnName)
+	wp := MakeWebhookPublisher(logger, s.URL, WebhookOptions{})
+	wp.Publish(ctx, nil, map[string]string{"X-Fission-Test": "aaa"}, fnName)
 	time.Sleep(time.Second * 1)
 }
+
+func TestPublisherMaxBodySize(t *testing.T) {
+	requests := make(chan *http.Request, 2)
+	bodies := make(chan string, 2)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- string(body)
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+	wp := MakeWebhookPublisher(loggerfactory.GetLogger(), s.URL, WebhookOptions{MaxBodySize: 4})
+	wp.Publish(ctx, []byte("too large"), nil, "fn")
+	wp.Publish(ctx, []byte("ok"), nil, "fn")
+	assert.Equal(t, "ok", <-bodies)
+	<-requests
+
+	wp = MakeWebhookPublisher(loggerfactory.GetLogger(), s.URL, WebhookOptions{MaxBodySize: 4, ReportOversized: true})
+	wp.Publish(ctx, []byte("too large"), map[string]string{"Content-Encoding": "gzip", "X-Fission-Test": "aaa"}, "fn")
+	assert.JSONEq(t, `{"error":"payload too large","size":9,"maxSize":4}`, <-bodies)
+	r := <-requests
+	assert.Equal(t, "PayloadTooLarge", r.Header.Get(PublishErrorHeader))
+	assert.Equal(t, "aaa", r.Header.Get("X-Fission-Test"))
+	assert.Empty(t, r.Header.Get("Content-Encoding"))
+}
+
+func TestPublisherTimeoutOption(t *testing.T) {
+	elapsed := make(chan time.Duration, 1)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		select {
+		case elapsed <- time.Since(start):
+		default:
+		}
+	}))
+	defer s.Close()
+
+	wp := MakeWebhookPublisher(loggerfactory.GetLogger(), s.URL, WebhookOptions{})
+	PublishWithOptions(context.Background(), wp, nil, nil, "fn", PublishOptions{Timeout: 100 * time.Millisecond})
+	assert.Less(t, <-elapsed, 5*time.Second, "expected the request to time out after the given timeout")
+}
+
+func TestPublisherMethodOption(t *testing.T) {
+	methods := make(chan string, 2)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods <- r.Method
+	}))
+	defer s.Close()
+
+	wp := MakeWebhookPublisher(loggerfactory.GetLogger(), s.URL, WebhookOptions{})
+	wp.Publish(context.Background(), nil, nil, "fn")
+	assert.Equal(t, http.MethodPost, <-methods)
+	PublishWithOptions(context.Background(), wp, nil, nil, "fn", PublishOptions{Method: http.MethodPut})
+	assert.Equal(t, http.MethodPut, <-methods)
+}
+
+func TestPublisherDoneOption(t *testing.T) {
+	status := http.StatusOK
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer s.Close()
+
+	results := make(chan error, 1)
+	opts := PublishOptions{Done: func(err error) { results <- err }}
+	wp := MakeWebhookPublisher(loggerfactory.GetLogger(), s.URL, WebhookOptions{})
+	PublishWithOptions(context.Background(), wp, nil, nil, "fn", opts)
+	assert.NoError(t, <-results)
+
+	status = http.StatusServiceUnavailable
+	PublishWithOptions(context.Background(), wp, nil, nil, "fn", opts)
+	assert.ErrorContains(t, <-results, "503")
+}
+
+func TestPublisherConcurrent(t *testing.T) {
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))
+	defer s.Close()
+	defer close(release)
+
+	// both requests are in flight at once, the second isn't queued behind the first
+	wp := MakeWebhookPublisher(loggerfactory.GetLogger(), s.URL, WebhookOptions{Concurrent: true})
+	wp.Publish(context.Background(), nil, nil, "fn")
+	wp.Publish(context.Background(), nil, nil, "fn")
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected 2 concurrent requests, got %d", i)
+		}
+	}
+}
+
+func TestMaxWebhookPublishDuration(t *testing.T) {
+	// 10 attempts of a second, with the retries delayed by 1s, 2s, ... 256s
+	assert.Equal(t, 10*time.Second+511*time.Second, MaxWebhookPublishDuration(time.Second))
+	assert.Equal(t, MaxWebhookPublishDuration(DefaultWebhookTimeout), MaxWebhookPublishDuration(0))
+}
+
+type fakeProducer struct {
+	topic   string
+	body    []byte
+	headers map[string]string
+}
+
+func (p *fakeProducer) Produce(topic string, body []byte, headers map[string]string) error {
+	p.topic, p.body, p.headers = topic, body, headers
+	return nil
+}
+
+func TestMessageQueuePublisher(t *testing.T) {
+	producer := &fakeProducer{}
+	mp := MakeMessageQueuePublisher(loggerfactory.GetLogger(), producer)
+	mp.Publish(context.Background(), []byte(`{"kind":"Pod"}`), map[string]string{"X-Kubernetes-Event-Type": "ADDED"}, "events")
+
+	assert.Equal(t, "events", producer.topic)
+	assert.Equal(t, `{"kind":"Pod"}`, string(producer.body))
+	assert.Equal(t, "ADDED", producer.headers["X-Kubernetes-Event-Type"])
+
+	done := make(chan error, 1)
+	PublishWithOptions(context.Background(), mp, []byte(`{}`), nil, "events", PublishOptions{Done: func(err error) { done <- err }})
+	assert.NoError(t, <-done)
+}
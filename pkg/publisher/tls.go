@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publisher
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	// Environment variables holding the paths of the PEM files, usually mounted from a secret.
+	envTLSCACert             = "PUBLISHER_TLS_CA_CERT"
+	envTLSClientCert         = "PUBLISHER_TLS_CLIENT_CERT"
+	envTLSClientKey          = "PUBLISHER_TLS_CLIENT_KEY"
+	envTLSInsecureSkipVerify = "PUBLISHER_TLS_INSECURE_SKIP_VERIFY"
+)
+
+// TLSOptions configures the transport used to deliver webhook requests.
+type TLSOptions struct {
+	// CACertFile is a PEM bundle used to verify the server certificate.
+	CACertFile string
+	// CertFile and KeyFile are the client certificate presented for mTLS.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables server certificate verification, for development only.
+	InsecureSkipVerify bool
+}
+
+// TLSOptionsFromEnv reads TLSOptions from the PUBLISHER_TLS_* environment variables.
+func TLSOptionsFromEnv() (TLSOptions, error) {
+	opts := TLSOptions{
+		CACertFile: os.Getenv(envTLSCACert),
+		CertFile:   os.Getenv(envTLSClientCert),
+		KeyFile:    os.Getenv(envTLSClientKey),
+	}
+	if v := os.Getenv(envTLSInsecureSkipVerify); v != "" {
+		skipVerify, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid value %q of %s, expected true or false: %w", v, envTLSInsecureSkipVerify, err)
+		}
+		opts.InsecureSkipVerify = skipVerify
+	}
+	return opts, nil
+}
+
+// Config builds a tls.Config from the options. It returns nil if no option is
+// set, in which case the default transport should be used.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	if o.CACertFile == "" && o.CertFile == "" && o.KeyFile == "" && !o.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify, //nolint: gosec
+	}
+
+	if o.CACertFile != "" {
+		caCert, err := os.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", o.CACertFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -19,6 +19,8 @@ package publisher
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -44,48 +46,176 @@ type (
 
 		baseURL string
 		timeout time.Duration
+		client  *http.Client
+
+		// maxBodySize rejects larger bodies, 0 means no limit.
+		maxBodySize int
+		// reportOversized sends an error report to the target in place of
+		// a rejected body.
+		reportOversized bool
+		// concurrent sends each request in a goroutine of its own.
+		concurrent bool
+	}
+
+	// WebhookOptions configures a WebhookPublisher.
+	WebhookOptions struct {
+		// TLSConfig, if set, is used by the transport making the requests.
+		TLSConfig *tls.Config
+		// MaxBodySize rejects larger bodies, 0 means no limit.
+		MaxBodySize int
+		// ReportOversized sends a small error report to the target in place
+		// of a rejected body.
+		ReportOversized bool
+		// Concurrent sends each request, and its retries, in a goroutine of
+		// its own instead of one at a time in the order they were published.
+		// The caller is expected to bound the requests in flight.
+		Concurrent bool
 	}
 	publishRequest struct {
 		ctx        context.Context
-		body       string
+		body       []byte
 		headers    map[string]string
 		target     string
+		timeout    time.Duration
+		method     string
 		retries    int
 		retryDelay time.Duration
+		done       func(err error)
 	}
 )
 
-// MakeWebhookPublisher creates a WebhookPublisher object for the given baseURL
-func MakeWebhookPublisher(logger *zap.Logger, baseURL string) *WebhookPublisher {
+// PublishErrorHeader is set on the error report sent in place of a rejected body.
+const PublishErrorHeader = "X-Fission-Publish-Error"
+
+const (
+	// DefaultWebhookTimeout is the timeout of a request unless the publish
+	// sets one.
+	DefaultWebhookTimeout = 60 * time.Minute
+	// WebhookRetries is the number of attempts to send a request.
+	WebhookRetries = 10
+	// webhookRetryDelay is doubled before each retry.
+	webhookRetryDelay = 500 * time.Millisecond
+)
+
+// MaxWebhookPublishDuration returns how long a publish with the given request
+// timeout may take until its outcome is known, with all its retries.
+func MaxWebhookPublishDuration(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	total := WebhookRetries * timeout
+	delay := webhookRetryDelay
+	for i := 1; i < WebhookRetries; i++ {
+		delay *= 2
+		total += delay
+	}
+	return total
+}
+
+// MakeWebhookPublisher creates a WebhookPublisher object for the given baseURL.
+func MakeWebhookPublisher(logger *zap.Logger, baseURL string, opts WebhookOptions) *WebhookPublisher {
+	client := otelhttp.DefaultClient
+	if opts.TLSConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = opts.TLSConfig
+		client = &http.Client{Transport: otelhttp.NewTransport(transport)}
+	}
 	p := &WebhookPublisher{
 		logger:         logger.Named("webhook_publisher"),
 		baseURL:        baseURL,
+		client:         client,
 		requestChannel: make(chan *publishRequest, 32), // buffered channel
 		// TODO make this configurable
-		timeout: 60 * time.Minute,
+		timeout: DefaultWebhookTimeout,
 		// TODO make this configurable
-		maxRetries: 10,
-		retryDelay: 500 * time.Millisecond,
+		maxRetries: WebhookRetries,
+		retryDelay: webhookRetryDelay,
+
+		maxBodySize:     opts.MaxBodySize,
+		reportOversized: opts.ReportOversized,
+		concurrent:      opts.Concurrent,
+	}
+	if !p.concurrent {
+		go p.svc()
 	}
-	go p.svc()
 	return p
 }
 
 // Publish sends a request to the target with payload having given body and headers
-func (p *WebhookPublisher) Publish(ctx context.Context, body string, headers map[string]string, target string) {
+func (p *WebhookPublisher) Publish(ctx context.Context, body []byte, headers map[string]string, target string) {
+	p.PublishWithOptions(ctx, body, headers, target, PublishOptions{})
+}
+
+// PublishWithOptions is Publish with the timeout and method given by opts,
+// if they are set.
+func (p *WebhookPublisher) PublishWithOptions(ctx context.Context, body []byte, headers map[string]string, target string, opts PublishOptions) {
+	timeout := p.timeout
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	method := http.MethodPost
+	if len(opts.Method) > 0 {
+		method = opts.Method
+	}
+
 	tracer := otel.Tracer("WebhookPublisher")
 	ctx, span := tracer.Start(ctx, "WebhookPublisher/Publish")
 	defer span.End()
 
-	// serializing the request gives user a guarantee that the request is sent in sequence order
-	p.requestChannel <- &publishRequest{
+	if p.maxBodySize > 0 && len(body) > p.maxBodySize {
+		otelUtils.LoggerWithTraceID(ctx, p.logger).Error("body exceeds the maximum size - not publishing it",
+			zap.String("target", target), zap.Int("size", len(body)), zap.Int("max_size", p.maxBodySize))
+		if !p.reportOversized {
+			if opts.Done != nil {
+				opts.Done(fmt.Errorf("body of %d bytes exceeds the maximum size of %d bytes", len(body), p.maxBodySize))
+			}
+			return
+		}
+		body, headers = p.oversizedReport(len(body), headers)
+	}
+
+	p.dispatch(&publishRequest{
 		ctx:        ctx,
 		body:       body,
 		headers:    headers,
 		target:     target,
+		timeout:    timeout,
+		method:     method,
 		retries:    p.maxRetries,
 		retryDelay: p.retryDelay,
+		done:       opts.Done,
+	})
+}
+
+// dispatch sends the request in a goroutine of its own if the publisher is
+// concurrent. Otherwise it is queued for svc, serializing the requests gives
+// the user a guarantee that they are sent in sequence order.
+func (p *WebhookPublisher) dispatch(r *publishRequest) {
+	if p.concurrent {
+		go p.makeHTTPRequest(r)
+		return
 	}
+	p.requestChannel <- r
+}
+
+// oversizedReport returns the body and headers of the error report sent in
+// place of a body of the given size.
+func (p *WebhookPublisher) oversizedReport(size int, headers map[string]string) ([]byte, map[string]string) {
+	reportHeaders := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		// the report replaces the body, so it is not encoded like the body
+		if !strings.EqualFold(k, "Content-Encoding") {
+			reportHeaders[k] = v
+		}
+	}
+	reportHeaders["Content-Type"] = "application/json"
+	reportHeaders[PublishErrorHeader] = "PayloadTooLarge"
+	body, _ := json.Marshal(map[string]interface{}{
+		"error":   "payload too large",
+		"size":    size,
+		"maxSize": p.maxBodySize,
+	})
+	return body, reportHeaders
 }
 
 func (p *WebhookPublisher) svc() {
@@ -109,22 +239,20 @@ func (p *WebhookPublisher) makeHTTPRequest(r *publishRequest) {
 		}
 	}()
 
-	var buf bytes.Buffer
-	buf.WriteString(r.body)
-
 	// Create request
-	req, err := http.NewRequest(http.MethodPost, url, &buf)
+	req, err := http.NewRequest(r.method, url, bytes.NewReader(r.body))
 	if err != nil {
 		fields = append(fields, zap.Error(err))
+		r.finish(err)
 		return
 	}
 	for k, v := range r.headers {
 		req.Header.Set(k, v)
 	}
 	// Make the request
-	ctx, cancel := context.WithTimeoutCause(r.ctx, p.timeout, fmt.Errorf("webhook request timed out (%f)s exceeded ", p.timeout.Seconds()))
+	ctx, cancel := context.WithTimeoutCause(r.ctx, r.timeout, fmt.Errorf("webhook request timed out (%f)s exceeded ", r.timeout.Seconds()))
 	defer cancel()
-	resp, err := ctxhttp.Do(ctx, otelhttp.DefaultClient, req)
+	resp, err := ctxhttp.Do(ctx, p.client, req)
 	if err != nil {
 		fields = append(fields, zap.Error(err), zap.Any("request", r))
 	} else {
@@ -137,11 +265,15 @@ func (p *WebhookPublisher) makeHTTPRequest(r *publishRequest) {
 			fields = append(fields, zap.Int("status_code", resp.StatusCode), zap.String("body", string(body)))
 			if resp.StatusCode >= 200 && resp.StatusCode < 400 {
 				level = zap.InfoLevel
+				r.finish(nil)
 			} else if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 				msg = "request returned bad request status code"
 				level = zap.WarnLevel
+				// the target is up, it rejected this request
+				r.finish(nil)
 			} else {
 				msg = "request returned failure status code"
+				r.finish(fmt.Errorf("request returned status code %d", resp.StatusCode))
 			}
 			return
 		}
@@ -152,10 +284,18 @@ func (p *WebhookPublisher) makeHTTPRequest(r *publishRequest) {
 	if r.retries > 0 {
 		r.retryDelay *= time.Duration(2)
 		time.AfterFunc(r.retryDelay, func() {
-			p.requestChannel <- r
+			p.dispatch(r)
 		})
 	} else {
 		msg = "final retry failed, giving up"
 		// Event dropped
+		r.finish(err)
+	}
+}
+
+// finish reports the outcome of the request to its done callback, if any.
+func (r *publishRequest) finish(err error) {
+	if r.done != nil {
+		r.done(err)
 	}
 }
@@ -21,11 +21,12 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math/rand"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,6 +43,7 @@ import (
 	"github.com/fission/fission/pkg/throttler"
 	"github.com/fission/fission/pkg/utils"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
+	"github.com/fission/fission/pkg/utils/weightedpick"
 )
 
 const (
@@ -50,22 +52,36 @@ const (
 
 	// X_FORWARDED_HOST represents the 'X_FORWARDED_HOST' request header
 	X_FORWARDED_HOST = "X-Forwarded-Host"
+
+	// NoCacheHeader, set to true, makes the router resolve the function
+	// reference of the trigger afresh for the request, e.g. to test a new
+	// deploy, see functionHandler.bypassesCache.
+	NoCacheHeader = "X-Fission-No-Cache"
 )
 
 type (
 	functionHandler struct {
-		logger                   *zap.Logger
-		fmap                     *functionServiceMap
-		executor                 eclient.ClientInterface
-		function                 *fv1.Function
-		httpTrigger              *fv1.HTTPTrigger
-		functionMap              map[string]*fv1.Function
-		fnWeightDistributionList []functionWeightDistribution
-		tsRoundTripperParams     *tsRoundTripperParams
-		isDebugEnv               bool
-		svcAddrUpdateThrottler   *throttler.Throttler
-		functionTimeoutMap       map[k8stypes.UID]int
-		unTapServiceTimeout      time.Duration
+		logger                 *zap.Logger
+		fmap                   *functionServiceMap
+		executor               eclient.ClientInterface
+		function               *fv1.Function
+		httpTrigger            *fv1.HTTPTrigger
+		functionMap            map[string]*fv1.Function
+		functionWeights        *weightedpick.Selector
+		headerOverrides        []fv1.FunctionHeaderOverride
+		tsRoundTripperParams   *tsRoundTripperParams
+		isDebugEnv             bool
+		svcAddrUpdateThrottler *throttler.Throttler
+		functionTimeoutMap     map[k8stypes.UID]int
+		unTapServiceTimeout    time.Duration
+		// timeout overrides the function timeout for requests of the trigger, if positive
+		timeout time.Duration
+		// resolveUncached resolves the function reference of the trigger
+		// without the resolver cache, for requests with the NoCacheHeader.
+		resolveUncached func(ctx context.Context) (*resolveResult, error)
+		// noCacheTrustedNets are the client networks the NoCacheHeader is
+		// honored from, besides any client in debug mode.
+		noCacheTrustedNets []*net.IPNet
 	}
 
 	tsRoundTripperParams struct {
@@ -436,13 +452,33 @@ func (fh *functionHandler) tapService(fn *fv1.Function, serviceURL *url.URL) {
 }
 
 func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *http.Request) {
+	if fh.resolveUncached != nil && fh.bypassesCache(request) {
+		rr, err := fh.resolveUncached(request.Context())
+		if err != nil {
+			fh.logger.Error("error resolving function reference without cache", zap.Error(err))
+			http.Error(responseWriter, "error resolving function reference", http.StatusInternalServerError)
+			return
+		}
+		// only this request uses the fresh result, the cached one is kept
+		fh.functionMap = rr.functionMap
+		fh.functionWeights = rr.functionWeights
+		fh.headerOverrides = rr.headerOverrides
+		fh.timeout = rr.timeout
+		if rr.resolveResultType == resolveResultSingleFunction {
+			fh.function = rr.function
+		}
+	}
+
 	if fh.httpTrigger != nil && fh.httpTrigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights {
 		// canary deployment. need to determine the function to send request to now
-		fn := getCanaryBackend(fh.functionMap, fh.fnWeightDistributionList)
+		fn := getHeaderOverrideBackend(fh.functionMap, fh.headerOverrides, request.Header)
+		if fn == nil {
+			fn = getCanaryBackend(fh.functionMap, fh.functionWeights)
+		}
 		if fn == nil {
 			fh.logger.Error("could not get canary backend",
 				zap.Any("fnMap", fh.functionMap),
-				zap.Any("distributionList", fh.fnWeightDistributionList))
+				zap.Any("functionWeights", fh.functionWeights))
 			// TODO : write error to responseWrite and return response
 			return
 		}
@@ -463,15 +499,19 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		}
 	}
 
-	fnTimeout := fh.functionTimeoutMap[fh.function.ObjectMeta.GetUID()]
-	if fnTimeout == 0 {
-		fnTimeout = fv1.DEFAULT_FUNCTION_TIMEOUT
+	funcTimeout := fh.timeout
+	if funcTimeout <= 0 {
+		fnTimeout := fh.functionTimeoutMap[fh.function.ObjectMeta.GetUID()]
+		if fnTimeout == 0 {
+			fnTimeout = fv1.DEFAULT_FUNCTION_TIMEOUT
+		}
+		funcTimeout = time.Duration(fnTimeout) * time.Second
 	}
 
 	rrt := &RetryingRoundTripper{
 		logger:      fh.logger.Named("roundtripper"),
 		funcHandler: &fh,
-		funcTimeout: time.Duration(fnTimeout) * time.Second,
+		funcTimeout: funcTimeout,
 	}
 
 	start := time.Now()
@@ -503,36 +543,58 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 	proxy.ServeHTTP(responseWriter, request)
 }
 
-// findCeil picks a function from the functionWeightDistribution list based on the
-// random number generated. It uses the prefix calculated for the function weights.
-func findCeil(randomNumber int, wtDistrList []functionWeightDistribution) string {
-	low := 0
-	high := len(wtDistrList) - 1
-
-	for {
-		if low >= high {
-			break
-		}
-
-		mid := low + high/2
-		if randomNumber >= wtDistrList[mid].sumPrefix {
-			low = mid + 1
-		} else {
-			high = mid
+// bypassesCache tells whether the request asks for a fresh resolution of the
+// function reference with the NoCacheHeader and may do so: the router runs in
+// debug mode, or the client address is in one of the trusted networks.
+func (fh *functionHandler) bypassesCache(request *http.Request) bool {
+	noCache, err := strconv.ParseBool(request.Header.Get(NoCacheHeader))
+	if err != nil || !noCache {
+		return false
+	}
+	if fh.isDebugEnv {
+		return true
+	}
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range fh.noCacheTrustedNets {
+		if network.Contains(ip) {
+			return true
 		}
 	}
+	fh.logger.Debug("ignoring no-cache header of untrusted client", zap.String("remote_addr", request.RemoteAddr))
+	return false
+}
 
-	if wtDistrList[low].sumPrefix >= randomNumber {
-		return wtDistrList[low].name
+// picks a function to route to based on a random number generated
+func getCanaryBackend(fnMap map[string]*fv1.Function, functionWeights *weightedpick.Selector) *fv1.Function {
+	if functionWeights == nil {
+		return nil
 	}
-	return ""
+	return fnMap[functionWeights.Random()]
 }
 
-// picks a function to route to based on a random number generated
-func getCanaryBackend(fnMap map[string]*fv1.Function, fnWtDistributionList []functionWeightDistribution) *fv1.Function {
-	randomNumber := rand.Intn(fnWtDistributionList[len(fnWtDistributionList)-1].sumPrefix + 1)
-	fnName := findCeil(randomNumber, fnWtDistributionList)
-	return fnMap[fnName]
+// picks the function forced by the first header override matching the request
+// headers, or nil if none matches. Unlike the NoCacheHeader the headers are
+// honored from any client, overrides let clients opt in to a function.
+func getHeaderOverrideBackend(fnMap map[string]*fv1.Function, overrides []fv1.FunctionHeaderOverride, header http.Header) *fv1.Function {
+	for _, o := range overrides {
+		values, ok := header[http.CanonicalHeaderKey(o.Header)]
+		if !ok {
+			continue
+		}
+		if o.Value == "" || slices.Contains(values, o.Value) {
+			if fn, ok := fnMap[o.Function]; ok {
+				return fn
+			}
+		}
+	}
+	return nil
 }
 
 // addForwardedHostHeader add "forwarded host" to request header
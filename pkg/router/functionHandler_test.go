@@ -67,3 +67,55 @@ func TestProxyErrorHandler(t *testing.T) {
 	errHandler(respRecorder, req, errors.New("dummy"))
 	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
 }
+
+func TestGetHeaderOverrideBackend(t *testing.T) {
+	fnMap := map[string]*fv1.Function{
+		"old": {ObjectMeta: metav1.ObjectMeta{Name: "old"}},
+		"new": {ObjectMeta: metav1.ObjectMeta{Name: "new"}},
+	}
+	overrides := []fv1.FunctionHeaderOverride{
+		{Header: "x-canary", Value: "true", Function: "new"},
+		{Header: "X-Stable", Function: "old"},
+	}
+
+	header := http.Header{}
+	assert.Nil(t, getHeaderOverrideBackend(fnMap, overrides, header))
+
+	header.Set("X-Canary", "false")
+	assert.Nil(t, getHeaderOverrideBackend(fnMap, overrides, header))
+
+	header.Set("X-Canary", "true")
+	assert.Equal(t, "new", getHeaderOverrideBackend(fnMap, overrides, header).ObjectMeta.Name)
+
+	header = http.Header{}
+	header.Set("X-Stable", "anything")
+	assert.Equal(t, "old", getHeaderOverrideBackend(fnMap, overrides, header).ObjectMeta.Name)
+}
+
+func TestBypassesCache(t *testing.T) {
+	trusted, err := parseCIDRs("10.0.0.0/8, ::1/128")
+	assert.NoError(t, err)
+	fh := &functionHandler{logger: zap.NewNop(), noCacheTrustedNets: trusted}
+
+	req := httptest.NewRequest("GET", "http://router/fn", nil)
+	req.RemoteAddr = "10.1.2.3:4321"
+	assert.False(t, fh.bypassesCache(req), "no header")
+
+	req.Header.Set(NoCacheHeader, "true")
+	assert.True(t, fh.bypassesCache(req))
+
+	req.RemoteAddr = "[::1]:4321"
+	assert.True(t, fh.bypassesCache(req))
+
+	req.RemoteAddr = "192.168.1.1:4321"
+	assert.False(t, fh.bypassesCache(req), "untrusted client")
+
+	fh.isDebugEnv = true
+	assert.True(t, fh.bypassesCache(req), "any client in debug mode")
+
+	req.Header.Set(NoCacheHeader, "false")
+	assert.False(t, fh.bypassesCache(req))
+
+	_, err = parseCIDRs("10.0.0.0")
+	assert.Error(t, err)
+}
@@ -17,16 +17,25 @@ limitations under the License.
 package router
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/cache"
+	"github.com/fission/fission/pkg/router/resolve"
+	"github.com/fission/fission/pkg/utils/weightedpick"
 )
 
 type (
@@ -37,24 +46,56 @@ type (
 		refCache     *cache.Cache[namespacedTriggerReference, resolveResult]
 		funcInformer map[string]k8sCache.SharedIndexInformer
 		logger       *zap.Logger
+		// sampledLogger logs about 1 in N resolutions, nil if disabled
+		sampledLogger *zap.Logger
+		// cacheByTriggerName keys resolutions by trigger name only, so that
+		// they survive changes of a trigger that leave its function reference
+		// alone. The trigger handlers then invalidate them with invalidateTrigger.
+		cacheByTriggerName bool
+		// skipUnhealthy gives functions annotated as unhealthy no share of
+		// function weights references, see fv1.FunctionHealthAnnotation.
+		skipUnhealthy bool
 		// store    k8sCache.Store
 	}
 
 	resolveResultType int
 
-	functionWeightDistribution struct {
-		name      string
-		weight    int
-		sumPrefix int
-	}
-
 	// resolveResult is the result of resolving a function reference;
 	// it could be the metadata of one function or
 	// a distribution of requests across two functions.
 	resolveResult struct {
 		resolveResultType
-		functionMap                map[string]*fv1.Function
-		functionWtDistributionList []functionWeightDistribution
+		// function is the result of a single function reference, which
+		// doesn't need a functionMap
+		function        *fv1.Function
+		functionMap     map[string]*fv1.Function
+		functionWeights *weightedpick.Selector
+		// headerOverrides select a function by request header instead of by weight
+		headerOverrides []fv1.FunctionHeaderOverride
+		// timeout is how long the router waits for the function to respond, the
+		// Timeout of the trigger or else the FunctionTimeout of a single
+		// function. It is 0 if neither is set, or for multiple functions
+		// without a trigger timeout, whose own timeouts then apply.
+		timeout time.Duration
+	}
+
+	// resolveResultJSON is the serialized form of a resolveResult, used to
+	// dump the resolver cache for debugging. Functions are only referenced,
+	// a resolveResult read back holds functions with just their metadata.
+	resolveResultJSON struct {
+		Type            string                       `json:"type"`
+		Functions       []resolvedFunctionJSON       `json:"functions"`
+		Weights         []weightedpick.Item          `json:"weights,omitempty"`
+		HeaderOverrides []fv1.FunctionHeaderOverride `json:"headerOverrides,omitempty"`
+		Timeout         string                       `json:"timeout,omitempty"`
+	}
+
+	// resolvedFunctionJSON references a function of a resolveResultJSON.
+	resolvedFunctionJSON struct {
+		Name            string    `json:"name"`
+		Namespace       string    `json:"namespace"`
+		UID             types.UID `json:"uid,omitempty"`
+		ResourceVersion string    `json:"resourceVersion,omitempty"`
 	}
 
 	// namespacedTriggerReference is just a trigger reference plus a
@@ -63,6 +104,9 @@ type (
 		namespace              string
 		triggerName            string
 		triggerResourceVersion string
+		// coalesced lists the other triggers of a route resolved with the
+		// trigger as its primary one, see resolveCoalesced.
+		coalesced string
 	}
 )
 
@@ -71,53 +115,221 @@ const (
 	resolveResultMultipleFunctions
 )
 
-func makeFunctionReferenceResolver(logger *zap.Logger, funcInformer map[string]k8sCache.SharedIndexInformer) *functionReferenceResolver {
+// The names of the resolveResultTypes in resolveResultJSON.
+const (
+	resolveResultSingleFunctionName    = "single-function"
+	resolveResultMultipleFunctionsName = "multiple-functions"
+)
+
+// informerSyncTimeout bounds how long a resolution waits for the function
+// informer of a namespace to sync.
+const informerSyncTimeout = 5 * time.Second
+
+// errInformerNotSynced is returned when a function reference can't be resolved
+// yet because the functions haven't been listed, resolving it later may succeed.
+var errInformerNotSynced = errors.New("function informer has not synced")
+
+// makeFunctionReferenceResolver returns a functionReferenceResolver. If
+// logSampleRate is positive, about 1 in logSampleRate resolutions is logged
+// at debug level, in addition to the first one of every second.
+func makeFunctionReferenceResolver(logger *zap.Logger, funcInformer map[string]k8sCache.SharedIndexInformer, logSampleRate int) *functionReferenceResolver {
 	frr := &functionReferenceResolver{
 		refCache:     cache.MakeCache[namespacedTriggerReference, resolveResult](time.Minute, 0),
 		funcInformer: funcInformer,
 		logger:       logger.Named("function_ref_resolver"),
 	}
+	if logSampleRate > 0 {
+		frr.sampledLogger = frr.logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, time.Second, 1, logSampleRate)
+		}))
+	}
+	registerResolverCacheMetrics(frr.refCache)
 	return frr
 }
 
 // resolve translates a trigger's function reference to a resolveResult.
-func (frr *functionReferenceResolver) resolve(trigger fv1.HTTPTrigger) (*resolveResult, error) {
+func (frr *functionReferenceResolver) resolve(ctx context.Context, trigger fv1.HTTPTrigger) (*resolveResult, error) {
 	nfr := namespacedTriggerReference{
 		namespace:              trigger.ObjectMeta.Namespace,
 		triggerName:            trigger.ObjectMeta.Name,
 		triggerResourceVersion: trigger.ObjectMeta.ResourceVersion,
 	}
+	if frr.cacheByTriggerName {
+		nfr.triggerResourceVersion = ""
+	}
 
 	// check cache
 	result, err := frr.refCache.Get(nfr)
 	if err == nil {
+		frr.logResolve(nfr, trigger.Spec.FunctionReference.Type, &result, true)
 		return &result, nil
 	}
 
 	// resolve on cache miss
-	var rr *resolveResult
+	rr, err := frr.resolveUncached(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
 
-	switch trigger.Spec.FunctionReference.Type {
-	case fv1.FunctionReferenceTypeFunctionName:
-		rr, err = frr.resolveByName(nfr.namespace, trigger.Spec.FunctionReference.Name)
-		if err != nil {
-			return nil, err
+	// cache resolve result
+	frr.refCache.Set(nfr, *rr) //nolint: errcheck
+
+	frr.logResolve(nfr, trigger.Spec.FunctionReference.Type, rr, false)
+	return rr, nil
+}
+
+// resolveUncached resolves a trigger's function reference from the current
+// functions, without looking up or updating the cache.
+func (frr *functionReferenceResolver) resolveUncached(ctx context.Context, trigger fv1.HTTPTrigger) (*resolveResult, error) {
+	nfr := namespacedTriggerReference{
+		namespace:              trigger.ObjectMeta.Namespace,
+		triggerName:            trigger.ObjectMeta.Name,
+		triggerResourceVersion: trigger.ObjectMeta.ResourceVersion,
+	}
+
+	rr, err := frr.resolveReference(ctx, nfr.namespace, &trigger.Spec.FunctionReference)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error resolving function reference of trigger %v", nfr)
+	}
+
+	if trigger.Spec.Timeout > 0 {
+		rr.timeout = time.Duration(trigger.Spec.Timeout) * time.Second
+	} else if rr.function != nil && rr.function.Spec.FunctionTimeout > 0 {
+		rr.timeout = time.Duration(rr.function.Spec.FunctionTimeout) * time.Second
+	}
+	return rr, nil
+}
+
+// logResolve writes a sampled debug log of a resolution. All entries share the
+// same message, so the sampler treats them as one stream.
+func (frr *functionReferenceResolver) logResolve(nfr namespacedTriggerReference, refType fv1.FunctionReferenceType, rr *resolveResult, cacheHit bool) {
+	if frr.sampledLogger == nil {
+		return
+	}
+	if ce := frr.sampledLogger.Check(zap.DebugLevel, "resolved function reference"); ce != nil {
+		var functions []string
+		if rr.function != nil {
+			functions = []string{rr.function.ObjectMeta.Name}
+		} else {
+			functions = make([]string, 0, len(rr.functionMap))
+			for name := range rr.functionMap {
+				functions = append(functions, name)
+			}
+			sort.Strings(functions)
 		}
+		ce.Write(zap.Stringer("trigger", nfr),
+			zap.Bool("cache_hit", cacheHit),
+			zap.String("reference_type", string(refType)),
+			zap.Strings("functions", functions))
+	}
+}
 
-	case fv1.FunctionReferenceTypeFunctionWeights:
-		rr, err = frr.resolveByFunctionWeights(nfr.namespace, &trigger.Spec.FunctionReference)
-		if err != nil {
-			return nil, err
+// getFunction returns the function of the result with the given name, or nil.
+func (rr *resolveResult) getFunction(name string) *fv1.Function {
+	if rr.function != nil {
+		if rr.function.ObjectMeta.Name == name {
+			return rr.function
 		}
+		return nil
+	}
+	return rr.functionMap[name]
+}
 
+// MarshalJSON encodes the result as a resolveResultJSON.
+func (rr resolveResult) MarshalJSON() ([]byte, error) {
+	out := resolveResultJSON{
+		HeaderOverrides: rr.headerOverrides,
+	}
+	if rr.timeout > 0 {
+		out.Timeout = rr.timeout.String()
+	}
+	functions := rr.functionMap
+	switch rr.resolveResultType {
+	case resolveResultSingleFunction:
+		out.Type = resolveResultSingleFunctionName
+		if rr.function != nil {
+			functions = map[string]*fv1.Function{rr.function.ObjectMeta.Name: rr.function}
+		}
+	case resolveResultMultipleFunctions:
+		out.Type = resolveResultMultipleFunctionsName
+		if rr.functionWeights != nil {
+			out.Weights = rr.functionWeights.Items()
+		}
 	default:
-		return nil, errors.Errorf("unrecognized function reference type %v", trigger.Spec.FunctionReference.Type)
+		return nil, fmt.Errorf("unknown resolve result type %d", rr.resolveResultType)
+	}
+	out.Functions = make([]resolvedFunctionJSON, 0, len(functions))
+	for _, f := range functions {
+		out.Functions = append(out.Functions, resolvedFunctionJSON{
+			Name:            f.ObjectMeta.Name,
+			Namespace:       f.ObjectMeta.Namespace,
+			UID:             f.ObjectMeta.UID,
+			ResourceVersion: f.ObjectMeta.ResourceVersion,
+		})
 	}
+	sort.Slice(out.Functions, func(i, j int) bool {
+		return out.Functions[i].Name < out.Functions[j].Name
+	})
+	return json.Marshal(out)
+}
 
-	// cache resolve result
-	frr.refCache.Set(nfr, *rr) //nolint: errcheck
+// UnmarshalJSON decodes a resolveResultJSON. The functions of the result
+// only have the metadata the JSON references them by.
+func (rr *resolveResult) UnmarshalJSON(data []byte) error {
+	var in resolveResultJSON
+	err := json.Unmarshal(data, &in)
+	if err != nil {
+		return err
+	}
+	result := resolveResult{
+		headerOverrides: in.HeaderOverrides,
+	}
+	if len(in.Timeout) > 0 {
+		result.timeout, err = time.ParseDuration(in.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", in.Timeout, err)
+		}
+	}
+	functions := make(map[string]*fv1.Function, len(in.Functions))
+	for _, f := range in.Functions {
+		functions[f.Name] = &fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            f.Name,
+				Namespace:       f.Namespace,
+				UID:             f.UID,
+				ResourceVersion: f.ResourceVersion,
+			},
+		}
+	}
+	switch in.Type {
+	case resolveResultSingleFunctionName:
+		if len(in.Functions) != 1 {
+			return fmt.Errorf("single function result references %d functions", len(in.Functions))
+		}
+		result.resolveResultType = resolveResultSingleFunction
+		result.function = functions[in.Functions[0].Name]
+	case resolveResultMultipleFunctionsName:
+		for _, item := range in.Weights {
+			if _, ok := functions[item.Name]; !ok {
+				return fmt.Errorf("weighted function %s is not referenced", item.Name)
+			}
+		}
+		result.resolveResultType = resolveResultMultipleFunctions
+		result.functionMap = functions
+		result.functionWeights = weightedpick.New(in.Weights)
+	default:
+		return fmt.Errorf("unknown resolve result type %q", in.Type)
+	}
+	*rr = result
+	return nil
+}
 
-	return rr, nil
+// String returns the trigger reference in a form suitable for error messages.
+func (nfr namespacedTriggerReference) String() string {
+	if len(nfr.coalesced) > 0 {
+		return fmt.Sprintf("%s/%s (resourceVersion %s, coalesced %s)", nfr.namespace, nfr.triggerName, nfr.triggerResourceVersion, nfr.coalesced)
+	}
+	return fmt.Sprintf("%s/%s (resourceVersion %s)", nfr.namespace, nfr.triggerName, nfr.triggerResourceVersion)
 }
 
 func (frr *functionReferenceResolver) getInformerByNamespace(namespace string) (k8sCache.SharedIndexInformer, error) {
@@ -127,93 +339,184 @@ func (frr *functionReferenceResolver) getInformerByNamespace(namespace string) (
 	return nil, fmt.Errorf("informer for namespace %s not found", namespace)
 }
 
-// resolveByName simply looks up function by name in a namespace.
-func (frr *functionReferenceResolver) resolveByName(namespace, name string) (*resolveResult, error) {
-	// get function from cache
+// hasSynced reports whether the function informer of the namespace has synced,
+// without waiting for it. A namespace without an informer is reported as
+// synced, so that resolving its triggers returns the actual error.
+func (frr *functionReferenceResolver) hasSynced(namespace string) bool {
 	informer, err := frr.getInformerByNamespace(namespace)
 	if err != nil {
-		return nil, err
+		return true
 	}
-	obj, isExist, err := informer.GetStore().Get(&fv1.Function{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      name,
-		},
-	})
+	return informer.HasSynced()
+}
+
+// getSyncedInformerByNamespace returns the function informer of the namespace
+// once it has synced, so that a missing function isn't mistaken for one that
+// hasn't been listed yet. It waits for the sync until ctx is done or
+// informerSyncTimeout has passed, then returns errInformerNotSynced.
+func (frr *functionReferenceResolver) getSyncedInformerByNamespace(ctx context.Context, namespace string) (k8sCache.SharedIndexInformer, error) {
+	informer, err := frr.getInformerByNamespace(namespace)
 	if err != nil {
 		return nil, err
 	}
-	if !isExist {
-		frr.logger.Error("function does not exists", zap.String("name", name), zap.String("namespace", namespace))
-		return nil, errors.Errorf("function %s/%s does not exist", namespace, name)
-	}
-	f := obj.(*fv1.Function)
-
-	functionMap := map[string]*fv1.Function{
-		f.ObjectMeta.Name: f,
+	if informer.HasSynced() {
+		return informer, nil
 	}
-
-	rr := resolveResult{
-		resolveResultType: resolveResultSingleFunction,
-		functionMap:       functionMap,
+	ctx, cancel := context.WithTimeout(ctx, informerSyncTimeout)
+	defer cancel()
+	if !k8sCache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return nil, errors.Wrapf(errInformerNotSynced, "namespace %s", namespace)
 	}
-
-	return &rr, nil
+	return informer, nil
 }
 
-func (frr *functionReferenceResolver) resolveByFunctionWeights(namespace string, fr *fv1.FunctionReference) (*resolveResult, error) {
-
-	functionMap := make(map[string]*fv1.Function)
-	fnWtDistrList := make([]functionWeightDistribution, 0)
-	sumPrefix := 0
-
-	for functionName, functionWeight := range fr.FunctionWeights {
+// resolveReference resolves a function reference from the functions of the
+// namespace in the informer cache, see resolve.FunctionReference.
+func (frr *functionReferenceResolver) resolveReference(ctx context.Context, namespace string, fr *fv1.FunctionReference) (*resolveResult, error) {
+	informer, err := frr.getSyncedInformerByNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	get := func(name string) (*fv1.Function, bool, error) {
 		// get function from cache
-		informer, err := frr.getInformerByNamespace(namespace)
-		if err != nil {
-			return nil, err
-		}
 		obj, isExist, err := informer.GetStore().Get(&fv1.Function{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: namespace,
-				Name:      functionName,
+				Name:      name,
 			},
 		})
-		if err != nil {
-			return nil, err
+		if err != nil || !isExist {
+			if err == nil {
+				frr.logger.Error("function does not exists", zap.String("name", name), zap.String("namespace", namespace))
+			}
+			return nil, false, err
 		}
-		if !isExist {
-			frr.logger.Error("function does not exists", zap.String("name", functionName), zap.String("namespace", namespace))
-			return nil, fmt.Errorf("function %s/%s does not exist", namespace, functionName)
+		return obj.(*fv1.Function), true, nil
+	}
+	res, err := resolve.FunctionReference(namespace, fr, get, frr.skipUnhealthy)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Function != nil {
+		return &resolveResult{
+			resolveResultType: resolveResultSingleFunction,
+			function:          res.Function,
+		}, nil
+	}
+	if frr.skipUnhealthy && len(res.Unhealthy) > 0 {
+		if len(res.Unhealthy) == len(res.Functions) {
+			frr.logger.Warn("all weighted functions are unhealthy - keeping their weights", zap.String("namespace", namespace))
+		} else {
+			for _, name := range res.Unhealthy {
+				frr.logger.Info("function is unhealthy - routing no traffic to it",
+					zap.String("name", name), zap.String("namespace", namespace))
+			}
 		}
-		f := obj.(*fv1.Function)
-		functionMap[f.ObjectMeta.Name] = f
-		sumPrefix = sumPrefix + functionWeight
-		fnWtDistrList = append(fnWtDistrList, functionWeightDistribution{
-			name:      functionName,
-			weight:    functionWeight,
-			sumPrefix: sumPrefix,
-		})
+	}
+	return &resolveResult{
+		resolveResultType: resolveResultMultipleFunctions,
+		functionMap:       res.Functions,
+		functionWeights:   weightedpick.New(res.Weights),
+		headerOverrides:   res.HeaderOverrides,
+	}, nil
+}
+
+// resolveCoalesced resolves the function weights references of triggers
+// sharing a route as a single distribution, see resolve.CoalesceFunctionReferences.
+// The first trigger is the primary one, its Timeout applies. The result is
+// cached under the primary trigger with the other triggers, so that it is
+// resolved again once the triggers of the route change.
+func (frr *functionReferenceResolver) resolveCoalesced(ctx context.Context, triggers []fv1.HTTPTrigger) (*resolveResult, error) {
+	primary := triggers[0]
+	nfr := namespacedTriggerReference{
+		namespace:              primary.ObjectMeta.Namespace,
+		triggerName:            primary.ObjectMeta.Name,
+		triggerResourceVersion: primary.ObjectMeta.ResourceVersion,
+	}
+	others := make([]string, 0, len(triggers)-1)
+	for _, t := range triggers[1:] {
+		if frr.cacheByTriggerName {
+			others = append(others, t.ObjectMeta.Name)
+		} else {
+			others = append(others, t.ObjectMeta.Name+"@"+t.ObjectMeta.ResourceVersion)
+		}
+	}
+	nfr.coalesced = strings.Join(others, ",")
+	if frr.cacheByTriggerName {
+		nfr.triggerResourceVersion = ""
+	}
+
+	result, err := frr.refCache.Get(nfr)
+	if err == nil {
+		frr.logResolve(nfr, fv1.FunctionReferenceTypeFunctionWeights, &result, true)
+		return &result, nil
 	}
 
-	rr := resolveResult{
-		resolveResultType:          resolveResultMultipleFunctions,
-		functionMap:                functionMap,
-		functionWtDistributionList: fnWtDistrList,
+	rr, err := frr.resolveCoalescedUncached(ctx, triggers)
+	if err != nil {
+		return nil, err
 	}
+	frr.refCache.Set(nfr, *rr) //nolint: errcheck
 
-	return &rr, nil
+	frr.logResolve(nfr, fv1.FunctionReferenceTypeFunctionWeights, rr, false)
+	return rr, nil
 }
 
-func (frr *functionReferenceResolver) delete(namespace string, triggerName, triggerRV string) error {
-	nfr := namespacedTriggerReference{
-		namespace:              namespace,
-		triggerName:            triggerName,
-		triggerResourceVersion: triggerRV,
+// resolveCoalescedUncached is resolveCoalesced without looking up or updating
+// the cache.
+func (frr *functionReferenceResolver) resolveCoalescedUncached(ctx context.Context, triggers []fv1.HTTPTrigger) (*resolveResult, error) {
+	primary := triggers[0]
+	fr := resolve.CoalesceFunctionReferences(triggers)
+	rr, err := frr.resolveReference(ctx, primary.ObjectMeta.Namespace, &fr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error resolving coalesced function weights of trigger %s/%s",
+			primary.ObjectMeta.Namespace, primary.ObjectMeta.Name)
 	}
+	if primary.Spec.Timeout > 0 {
+		rr.timeout = time.Duration(primary.Spec.Timeout) * time.Second
+	}
+	return rr, nil
+}
+
+func (frr *functionReferenceResolver) delete(nfr namespacedTriggerReference) error {
 	return frr.refCache.Delete(nfr)
 }
 
+// evictFunction deletes the cached resolutions that reference the function.
+func (frr *functionReferenceResolver) evictFunction(namespace, name string) {
+	for key, rr := range frr.refCache.Copy() {
+		if key.namespace != namespace || rr.getFunction(name) == nil {
+			continue
+		}
+		err := frr.refCache.Delete(key)
+		if err != nil {
+			frr.logger.Debug("error evicting resolution of deleted function", zap.Error(err),
+				zap.String("trigger", key.triggerName), zap.String("function", name), zap.String("namespace", namespace))
+		}
+	}
+}
+
+// invalidateTrigger deletes the cached resolutions of the trigger, its own
+// and the ones of a route it is coalesced into, if resolutions are cached by
+// trigger name, otherwise it does nothing as a changed trigger has a new
+// resource version anyway.
+func (frr *functionReferenceResolver) invalidateTrigger(namespace, triggerName string) {
+	if !frr.cacheByTriggerName {
+		return
+	}
+	for key := range frr.refCache.Copy() {
+		if key.namespace != namespace {
+			continue
+		}
+		if key.triggerName != triggerName && !slices.Contains(strings.Split(key.coalesced, ","), triggerName) {
+			continue
+		}
+		// the resolution may have expired since, so a failed delete is expected
+		_ = frr.delete(key)
+	}
+}
+
 func (frr *functionReferenceResolver) copy() map[namespacedTriggerReference]resolveResult {
 	return frr.refCache.Copy()
 }
@@ -0,0 +1,520 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/router/resolve"
+	"github.com/fission/fission/pkg/utils/weightedpick"
+)
+
+// syncedInformer is an informer that reports to have synced without running.
+type syncedInformer struct {
+	k8sCache.SharedIndexInformer
+}
+
+func (syncedInformer) HasSynced() bool {
+	return true
+}
+
+// byName returns a function name reference.
+func byName(name, packageVersion string) *fv1.FunctionReference {
+	return &fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: name, PackageVersion: packageVersion}
+}
+
+func TestResolveByNamePackageVersion(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	err := informer.GetStore().Add(&fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"},
+		Spec: fv1.FunctionSpec{
+			Package: fv1.FunctionPackageRef{PackageRef: fv1.PackageRef{Name: "pkg", ResourceVersion: "2"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+
+	for _, version := range []string{"", "2"} {
+		rr, err := frr.resolveReference(context.Background(), "default", byName("fn", version))
+		if err != nil {
+			t.Fatalf("expected function to resolve with package version %q, got %v", version, err)
+		}
+		if rr.getFunction("fn") == nil {
+			t.Errorf("expected function in result, got %v", rr.function)
+		}
+	}
+
+	_, err = frr.resolveReference(context.Background(), "default", byName("fn", "1"))
+	if err == nil {
+		t.Error("expected an error for a function with another package version")
+	}
+}
+
+func TestResolveByNameInformerNotSynced(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": informer}, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := frr.resolveReference(ctx, "default", byName("fn", ""))
+	if !errors.Is(err, errInformerNotSynced) {
+		t.Errorf("expected errInformerNotSynced, got %v", err)
+	}
+}
+
+func TestHasSynced(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{
+		"default": informer,
+		"synced":  syncedInformer{informer},
+	}, 0)
+
+	if frr.hasSynced("default") {
+		t.Error("expected the informer of default not to have synced")
+	}
+	if !frr.hasSynced("synced") {
+		t.Error("expected the informer of synced to have synced")
+	}
+	// resolving the triggers of a namespace without informer reports the error
+	if !frr.hasSynced("other") {
+		t.Error("expected a namespace without informer to be reported as synced")
+	}
+}
+
+func BenchmarkResolveSingleFunction(b *testing.B) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	err := informer.GetStore().Add(&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "trigger", Namespace: "default", ResourceVersion: "1"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+		},
+	}
+	ctx := context.Background()
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := frr.resolveReference(ctx, "default", byName("fn", "")); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := frr.resolve(ctx, trigger); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestEvictFunction(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	for _, name := range []string{"fn", "other"} {
+		err := informer.GetStore().Add(&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+
+	triggers := []fv1.HTTPTrigger{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default", ResourceVersion: "1"},
+			Spec: fv1.HTTPTriggerSpec{
+				FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "weighted", Namespace: "default", ResourceVersion: "1"},
+			Spec: fv1.HTTPTriggerSpec{
+				FunctionReference: fv1.FunctionReference{
+					Type:            fv1.FunctionReferenceTypeFunctionWeights,
+					FunctionWeights: map[string]int{"fn": 50, "other": 50},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default", ResourceVersion: "1"},
+			Spec: fv1.HTTPTriggerSpec{
+				FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "other"},
+			},
+		},
+	}
+	for _, trigger := range triggers {
+		if _, err := frr.resolve(context.Background(), trigger); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	frr.evictFunction("default", "fn")
+
+	cached := frr.copy()
+	if len(cached) != 1 {
+		t.Fatalf("expected only the unrelated resolution to stay cached, got %v", cached)
+	}
+	for key := range cached {
+		if key.triggerName != "unrelated" {
+			t.Errorf("expected the unrelated resolution to stay cached, got %v", key)
+		}
+	}
+}
+
+func TestResolveCacheByTriggerName(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	err := informer.GetStore().Add(&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+	frr.cacheByTriggerName = true
+
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "trigger", Namespace: "default", ResourceVersion: "1"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+		},
+	}
+	for _, rv := range []string{"1", "2"} {
+		trigger.ObjectMeta.ResourceVersion = rv
+		if _, err := frr.resolve(context.Background(), trigger); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(frr.copy()); n != 1 {
+		t.Errorf("expected one cached resolution across resource versions, got %d", n)
+	}
+
+	frr.invalidateTrigger("default", "trigger")
+	if n := len(frr.copy()); n != 0 {
+		t.Errorf("expected the resolution to be invalidated, got %d cached", n)
+	}
+}
+
+func TestResolveSkipsUnhealthyFunctions(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	for _, f := range []*fv1.Function{
+		{ObjectMeta: metav1.ObjectMeta{Name: "stable", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: "default",
+			Annotations: map[string]string{fv1.FunctionHealthAnnotation: fv1.FunctionHealthUnhealthy}}},
+	} {
+		if err := informer.GetStore().Add(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+	fr := &fv1.FunctionReference{
+		Type:            fv1.FunctionReferenceTypeFunctionWeights,
+		FunctionWeights: map[string]int{"stable": 50, "canary": 50},
+	}
+
+	rr, err := frr.resolveReference(context.Background(), "default", fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.functionWeights.Len() != 2 {
+		t.Errorf("expected unhealthy function to keep its weight by default, got %v", rr.functionWeights)
+	}
+
+	frr.skipUnhealthy = true
+	rr, err = frr.resolveReference(context.Background(), "default", fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= rr.functionWeights.Total(); i++ {
+		if name := rr.functionWeights.Pick(i); name != "stable" {
+			t.Fatalf("expected only the healthy function to be picked, got %q", name)
+		}
+	}
+
+	fr.FunctionWeights = map[string]int{"canary": 100}
+	rr, err = frr.resolveReference(context.Background(), "default", fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.functionWeights.Len() != 1 {
+		t.Errorf("expected weights to be kept when all functions are unhealthy, got %v", rr.functionWeights)
+	}
+}
+
+func TestResolveTimeout(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	err := informer.GetStore().Add(&fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"},
+		Spec:       fv1.FunctionSpec{FunctionTimeout: 60},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "trigger", Namespace: "default", ResourceVersion: "1"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+		},
+	}
+	rr, err := frr.resolve(context.Background(), trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.timeout != time.Minute {
+		t.Errorf("expected the function timeout, got %v", rr.timeout)
+	}
+
+	trigger.ObjectMeta.ResourceVersion = "2"
+	trigger.Spec.Timeout = 600
+	rr, err = frr.resolve(context.Background(), trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.timeout != 10*time.Minute {
+		t.Errorf("expected the trigger timeout, got %v", rr.timeout)
+	}
+}
+
+func TestResolveResultJSON(t *testing.T) {
+	fn := func(name string) *fv1.Function {
+		return &fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "7"},
+			Spec:       fv1.FunctionSpec{FunctionTimeout: 60},
+		}
+	}
+	rr := resolveResult{
+		resolveResultType: resolveResultMultipleFunctions,
+		functionMap:       map[string]*fv1.Function{"v1": fn("v1"), "v2": fn("v2")},
+		functionWeights:   weightedpick.New([]weightedpick.Item{{Name: "v1", Weight: 90}, {Name: "v2", Weight: 10}}),
+		timeout:           30 * time.Second,
+	}
+	data, err := json.Marshal(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"type":"multiple-functions","functions":[{"name":"v1","namespace":"default","resourceVersion":"7"},` +
+		`{"name":"v2","namespace":"default","resourceVersion":"7"}],"weights":[{"name":"v1","weight":90},{"name":"v2","weight":10}],"timeout":"30s"}`
+	if string(data) != expected {
+		t.Errorf("unexpected JSON %s", data)
+	}
+
+	var decoded resolveResult
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.resolveResultType != resolveResultMultipleFunctions || decoded.timeout != 30*time.Second ||
+		decoded.functionWeights.String() != "v1:90 v2:10" || decoded.getFunction("v2").ObjectMeta.ResourceVersion != "7" {
+		t.Errorf("unexpected decoded result %+v", decoded)
+	}
+
+	single := resolveResult{resolveResultType: resolveResultSingleFunction, function: fn("v1")}
+	data, err = json.Marshal(single)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = resolveResult{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.resolveResultType != resolveResultSingleFunction || decoded.getFunction("v1") == nil {
+		t.Errorf("unexpected decoded result %+v", decoded)
+	}
+}
+
+func TestResolveCoalesced(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	for _, name := range []string{"stable", "canary", "mirror"} {
+		if err := informer.GetStore().Add(&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+
+	weighted := func(name string, created int64, weights map[string]int, methods ...string) fv1.HTTPTrigger {
+		return fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.Unix(created, 0)},
+			Spec: fv1.HTTPTriggerSpec{
+				RelativeURL: "/orders",
+				Methods:     methods,
+				Timeout:     int(created),
+				FunctionReference: fv1.FunctionReference{
+					Type:            fv1.FunctionReferenceTypeFunctionWeights,
+					FunctionWeights: weights,
+					HeaderOverrides: []fv1.FunctionHeaderOverride{{Header: "X-Trigger", Value: name, Function: "stable"}},
+				},
+			},
+		}
+	}
+	triggers := []fv1.HTTPTrigger{
+		weighted("newer", 20, map[string]int{"stable": 50, "mirror": 100}, "GET"),
+		weighted("older", 10, map[string]int{"stable": 90, "canary": 10}, "GET"),
+		// another method is another route
+		weighted("post", 5, map[string]int{"stable": 100}, "POST"),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "by-name", Namespace: "default"},
+			Spec: fv1.HTTPTriggerSpec{
+				RelativeURL:       "/orders",
+				Methods:           []string{"GET"},
+				FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "stable"},
+			},
+		},
+	}
+
+	groups, coalesced := resolve.CoalesceTriggers(triggers)
+	if len(groups) != 1 || len(groups["default/older"]) != 2 {
+		t.Fatalf("expected one group with the oldest trigger as primary, got %v", groups)
+	}
+	if len(coalesced) != 1 || coalesced["default/newer"] != "default/older" {
+		t.Errorf("expected only the newer trigger to be coalesced, got %v", coalesced)
+	}
+
+	rr, err := frr.resolveCoalesced(context.Background(), groups["default/older"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.functionWeights.Total() != 250 || rr.functionWeights.Len() != 3 {
+		t.Errorf("expected the weights of both triggers to add up, got %v", rr.functionWeights)
+	}
+	if len(rr.headerOverrides) != 2 || rr.headerOverrides[0].Value != "older" {
+		t.Errorf("expected the header overrides of the primary trigger first, got %v", rr.headerOverrides)
+	}
+	if rr.timeout != 10*time.Second {
+		t.Errorf("expected the timeout of the primary trigger, got %v", rr.timeout)
+	}
+}
+
+func TestResolveCoalescedCache(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	for _, name := range []string{"stable", "canary"} {
+		err := informer.GetStore().Add(&fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+	frr.cacheByTriggerName = true
+
+	weighted := func(name string, weights map[string]int) fv1.HTTPTrigger {
+		return fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "1"},
+			Spec: fv1.HTTPTriggerSpec{
+				FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionWeights, FunctionWeights: weights},
+			},
+		}
+	}
+	primary := weighted("primary", map[string]int{"stable": 90, "canary": 10})
+	other := weighted("other", map[string]int{"stable": 100})
+	for i := 0; i < 2; i++ {
+		rr, err := frr.resolveCoalesced(context.Background(), []fv1.HTTPTrigger{primary, other})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rr.functionWeights.Total() != 200 {
+			t.Errorf("expected the weights of both triggers to add up, got %v", rr.functionWeights)
+		}
+	}
+	// the resolution of the primary trigger alone is another one
+	if _, err := frr.resolve(context.Background(), primary); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(frr.copy()); n != 2 {
+		t.Errorf("expected the coalesced and the own resolution of the primary trigger to be cached, got %d", n)
+	}
+
+	// a change of the other trigger invalidates the coalesced resolution
+	frr.invalidateTrigger("default", "other")
+	if n := len(frr.copy()); n != 1 {
+		t.Errorf("expected only the own resolution of the primary trigger to be left, got %d", n)
+	}
+	other.Spec.FunctionReference.FunctionWeights["canary"] = 100
+	rr, err := frr.resolveCoalesced(context.Background(), []fv1.HTTPTrigger{primary, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.functionWeights.Total() != 300 {
+		t.Errorf("expected the changed weights to be resolved, got %v", rr.functionWeights)
+	}
+	frr.invalidateTrigger("default", "primary")
+	if n := len(frr.copy()); n != 0 {
+		t.Errorf("expected all resolutions of the primary trigger to be invalidated, got %d cached", n)
+	}
+}
+
+func TestResolveUncachedKeepsCache(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"},
+		Spec:       fv1.FunctionSpec{FunctionTimeout: 10},
+	}
+	if err := informer.GetStore().Add(fn); err != nil {
+		t.Fatal(err)
+	}
+	frr := makeFunctionReferenceResolver(zap.NewNop(), map[string]k8sCache.SharedIndexInformer{"default": syncedInformer{informer}}, 0)
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "ht", Namespace: "default", ResourceVersion: "1"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "fn"},
+		},
+	}
+
+	rr, err := frr.resolve(context.Background(), trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.timeout != 10*time.Second {
+		t.Fatalf("expected a timeout of 10s, got %v", rr.timeout)
+	}
+
+	// a new deploy of the function
+	updated := fn.DeepCopy()
+	updated.Spec.FunctionTimeout = 20
+	if err := informer.GetStore().Update(updated); err != nil {
+		t.Fatal(err)
+	}
+
+	rr, err = frr.resolveUncached(context.Background(), trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.timeout != 20*time.Second {
+		t.Errorf("expected the uncached resolution to see the new deploy, got a timeout of %v", rr.timeout)
+	}
+	rr, err = frr.resolve(context.Background(), trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.timeout != 10*time.Second {
+		t.Errorf("expected the cached resolution to be kept, got a timeout of %v", rr.timeout)
+	}
+}
@@ -18,13 +18,17 @@ package router
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/bep/debounce"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -36,6 +40,7 @@ import (
 	config "github.com/fission/fission/pkg/featureconfig"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	"github.com/fission/fission/pkg/info"
+	"github.com/fission/fission/pkg/router/resolve"
 	"github.com/fission/fission/pkg/throttler"
 	"github.com/fission/fission/pkg/utils"
 	"github.com/fission/fission/pkg/utils/manager"
@@ -62,10 +67,41 @@ type HTTPTriggerSet struct {
 	svcAddrUpdateThrottler     *throttler.Throttler
 	unTapServiceTimeout        time.Duration
 	syncDebouncer              func(func())
+	resolveLogSampleRate       int
+	resolveWarmup              bool
+	resolveCacheByTriggerName  bool
+	skipUnhealthyFunctions     bool
+	// coalesceFunctionWeights routes the function weights triggers sharing a
+	// route by one merged distribution, see resolve.CoalesceTriggers.
+	coalesceFunctionWeights bool
+	// noCacheTrustedNets are the client networks the NoCacheHeader is
+	// honored from.
+	noCacheTrustedNets []*net.IPNet
+}
+
+// triggerSetOptions configures the HTTPTriggerSet made by makeHTTPTriggerSet.
+type triggerSetOptions struct {
+	roundTripperParams     *tsRoundTripperParams
+	isDebugEnv             bool
+	unTapServiceTimeout    time.Duration
+	svcAddrUpdateThrottler *throttler.Throttler
+	// resolveLogSampleRate logs about 1 in N resolutions, 0 to disable
+	resolveLogSampleRate int
+	// resolveWarmup resolves the function references of all triggers once
+	// the informers have synced, see warmupResolver
+	resolveWarmup bool
+	// resolveCacheByTriggerName keeps resolutions across changes of a
+	// trigger that leave its function reference alone
+	resolveCacheByTriggerName bool
+	// skipUnhealthyFunctions gives functions annotated as unhealthy no share
+	// of function weights references
+	skipUnhealthyFunctions  bool
+	coalesceFunctionWeights bool
+	noCacheTrustedNets      []*net.IPNet
 }
 
 func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionClient versioned.Interface,
-	kubeClient kubernetes.Interface, executor eclient.ClientInterface, params *tsRoundTripperParams, isDebugEnv bool, unTapServiceTimeout time.Duration, actionThrottler *throttler.Throttler) (*HTTPTriggerSet, error) {
+	kubeClient kubernetes.Interface, executor eclient.ClientInterface, opts triggerSetOptions) (*HTTPTriggerSet, error) {
 
 	httpTriggerSet := &HTTPTriggerSet{
 		logger:                     logger.Named("http_trigger_set"),
@@ -75,11 +111,17 @@ func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionCli
 		kubeClient:                 kubeClient,
 		executor:                   executor,
 		updateRouterRequestChannel: make(chan struct{}, 10), // use buffer channel
-		tsRoundTripperParams:       params,
-		isDebugEnv:                 isDebugEnv,
-		svcAddrUpdateThrottler:     actionThrottler,
-		unTapServiceTimeout:        unTapServiceTimeout,
+		tsRoundTripperParams:       opts.roundTripperParams,
+		isDebugEnv:                 opts.isDebugEnv,
+		svcAddrUpdateThrottler:     opts.svcAddrUpdateThrottler,
+		unTapServiceTimeout:        opts.unTapServiceTimeout,
 		syncDebouncer:              debounce.New(time.Millisecond * 20),
+		resolveLogSampleRate:       opts.resolveLogSampleRate,
+		resolveWarmup:              opts.resolveWarmup,
+		resolveCacheByTriggerName:  opts.resolveCacheByTriggerName,
+		skipUnhealthyFunctions:     opts.skipUnhealthyFunctions,
+		coalesceFunctionWeights:    opts.coalesceFunctionWeights,
+		noCacheTrustedNets:         opts.noCacheTrustedNets,
 	}
 	httpTriggerSet.triggerInformer = utils.GetInformersForNamespaces(fissionClient, time.Minute*30, fv1.HttpTriggerResource)
 	httpTriggerSet.funcInformer = utils.GetInformersForNamespaces(fissionClient, time.Minute*30, fv1.FunctionResource)
@@ -95,13 +137,15 @@ func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionCli
 }
 
 func (ts *HTTPTriggerSet) subscribeRouter(ctx context.Context, mgr manager.Interface, mr *mutableRouter) error {
-	resolver := makeFunctionReferenceResolver(ts.logger, ts.funcInformer)
+	resolver := makeFunctionReferenceResolver(ts.logger, ts.funcInformer, ts.resolveLogSampleRate)
+	resolver.cacheByTriggerName = ts.resolveCacheByTriggerName
+	resolver.skipUnhealthy = ts.skipUnhealthyFunctions
 	ts.resolver = resolver
 	ts.mutableRouter = mr
 
 	if ts.fissionClient == nil {
 		// Used in tests only.
-		router, err := ts.getRouter(nil)
+		router, err := ts.getRouter(ctx, nil)
 		if err != nil {
 			return err
 		}
@@ -111,13 +155,87 @@ func (ts *HTTPTriggerSet) subscribeRouter(ctx context.Context, mgr manager.Inter
 	}
 	mgr.Add(ctx, func(ctx context.Context) {
 		ts.updateRouter(ctx)
+		// the router isn't rebuilt anymore, stop the cache of resolutions
+		resolver.refCache.Close()
 	})
 	ts.syncTriggers()
 	mgr.AddInformers(ctx, ts.funcInformer)
 	mgr.AddInformers(ctx, ts.triggerInformer)
+	if ts.resolveWarmup {
+		ts.warmupResolver(ctx)
+	}
 	return nil
 }
 
+// resolverWarmupTimeout bounds how long the resolver warm-up waits for the
+// informers to sync before it warms up the namespaces that have synced.
+const resolverWarmupTimeout = 30 * time.Second
+
+// warmupResolver resolves the function references of all HTTP triggers once
+// the informers have synced, so that the first router is built from a warm
+// resolver cache. The namespaces whose informers haven't synced within
+// resolverWarmupTimeout are skipped. Failures are only logged.
+func (ts *HTTPTriggerSet) warmupResolver(ctx context.Context) {
+	synced := make([]k8sCache.InformerSynced, 0, len(ts.funcInformer)+len(ts.triggerInformer))
+	for _, informer := range ts.funcInformer {
+		synced = append(synced, informer.HasSynced)
+	}
+	for _, informer := range ts.triggerInformer {
+		synced = append(synced, informer.HasSynced)
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, resolverWarmupTimeout)
+	defer cancel()
+	if !k8sCache.WaitForCacheSync(syncCtx.Done(), synced...) {
+		if ctx.Err() != nil {
+			return
+		}
+		ts.logger.Warn("informers did not sync in time, skipping resolver warm-up of their namespaces",
+			zap.Strings("namespaces", ts.unsyncedNamespaces()))
+	}
+
+	resolved, failed := 0, 0
+	for namespace, triggerInformer := range ts.triggerInformer {
+		if !triggerInformer.HasSynced() || !ts.resolver.hasSynced(namespace) {
+			continue
+		}
+		for _, obj := range triggerInformer.GetStore().List() {
+			trigger := obj.(*fv1.HTTPTrigger)
+			_, err := ts.resolver.resolve(ctx, *trigger)
+			if err != nil {
+				failed++
+				ts.logger.Warn("error warming up resolver cache", zap.Error(err),
+					zap.String("trigger", trigger.ObjectMeta.Name), zap.String("namespace", trigger.ObjectMeta.Namespace))
+				continue
+			}
+			resolved++
+		}
+	}
+	ts.logger.Info("warmed up resolver cache", zap.Int("resolved", resolved), zap.Int("failed", failed))
+	ts.syncTriggers()
+}
+
+// unsyncedNamespaces returns the sorted namespaces whose function or trigger
+// informer hasn't synced.
+func (ts *HTTPTriggerSet) unsyncedNamespaces() []string {
+	unsynced := make(map[string]bool)
+	for namespace, informer := range ts.funcInformer {
+		if !informer.HasSynced() {
+			unsynced[namespace] = true
+		}
+	}
+	for namespace, informer := range ts.triggerInformer {
+		if !informer.HasSynced() {
+			unsynced[namespace] = true
+		}
+	}
+	namespaces := make([]string, 0, len(unsynced))
+	for namespace := range unsynced {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
 func defaultHomeHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
@@ -141,7 +259,7 @@ func versionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) (*mux.Router, error) {
+func (ts *HTTPTriggerSet) getRouter(ctx context.Context, fnTimeoutMap map[types.UID]int) (*mux.Router, error) {
 
 	featureConfig, err := config.GetFeatureConfig(ts.logger)
 	if err != nil {
@@ -156,15 +274,61 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) (*mux.Router
 
 	// HTTP triggers setup by the user
 	homeHandled := false
+	// namespaces whose functions haven't synced, so that the rest of their
+	// triggers are skipped without waiting for the sync again
+	unsynced := make(map[string]bool)
+	var groups map[string][]fv1.HTTPTrigger
+	var coalesced map[string]string
+	if ts.coalesceFunctionWeights {
+		groups, coalesced = resolve.CoalesceTriggers(ts.triggers)
+	}
 	for i := range ts.triggers {
 		trigger := ts.triggers[i]
 
+		if unsynced[trigger.ObjectMeta.Namespace] {
+			ts.logger.Debug("functions not synced yet, skipping trigger",
+				zap.String("trigger", trigger.ObjectMeta.Name), zap.String("namespace", trigger.ObjectMeta.Namespace))
+			continue
+		}
+		// Don't wait for the sync as a request would, every namespace waited
+		// for would delay the routes of all the others.
+		if !ts.resolver.hasSynced(trigger.ObjectMeta.Namespace) {
+			unsynced[trigger.ObjectMeta.Namespace] = true
+			ts.logger.Info("functions not synced yet, skipping triggers of namespace",
+				zap.String("trigger", trigger.ObjectMeta.Name), zap.String("namespace", trigger.ObjectMeta.Namespace))
+			continue
+		}
+		if _, ok := coalesced[resolve.TriggerKey(&trigger)]; ok {
+			ts.logger.Debug("function weights coalesced into another trigger of the route, skipping trigger",
+				zap.String("trigger", trigger.ObjectMeta.Name), zap.String("namespace", trigger.ObjectMeta.Namespace))
+			continue
+		}
+
 		// resolve function reference
-		rr, err := ts.resolver.resolve(trigger)
+		var rr *resolveResult
+		group, isPrimary := groups[resolve.TriggerKey(&trigger)]
+		if isPrimary {
+			rr, err = ts.resolver.resolveCoalesced(ctx, group)
+		} else {
+			rr, err = ts.resolver.resolve(ctx, trigger)
+		}
+		if errors.Is(err, errInformerNotSynced) {
+			// The router is rebuilt once the functions are listed.
+			unsynced[trigger.ObjectMeta.Namespace] = true
+			ts.logger.Info("functions not synced yet, skipping triggers of namespace", zap.Error(err),
+				zap.String("trigger", trigger.ObjectMeta.Name), zap.String("namespace", trigger.ObjectMeta.Namespace))
+			continue
+		}
 		if err != nil {
 			// Unresolvable function reference. Report the error via
-			// the trigger's status.
-			go ts.updateTriggerStatusFailed(&trigger, err)
+			// the trigger's status, of every trigger of a coalesced route.
+			if isPrimary {
+				for i := range group {
+					go ts.updateTriggerStatusFailed(&group[i], err)
+				}
+			} else {
+				go ts.updateTriggerStatusFailed(&trigger, err)
+			}
 
 			// Ignore this route and let it 404.
 			continue
@@ -176,17 +340,29 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) (*mux.Router
 		}
 
 		fh := &functionHandler{
-			logger:                   ts.logger.Named(trigger.ObjectMeta.Name),
-			fmap:                     ts.functionServiceMap,
-			executor:                 ts.executor,
-			httpTrigger:              &trigger,
-			functionMap:              rr.functionMap,
-			fnWeightDistributionList: rr.functionWtDistributionList,
-			tsRoundTripperParams:     ts.tsRoundTripperParams,
-			isDebugEnv:               ts.isDebugEnv,
-			svcAddrUpdateThrottler:   ts.svcAddrUpdateThrottler,
-			functionTimeoutMap:       fnTimeoutMap,
-			unTapServiceTimeout:      ts.unTapServiceTimeout,
+			logger:                 ts.logger.Named(trigger.ObjectMeta.Name),
+			fmap:                   ts.functionServiceMap,
+			executor:               ts.executor,
+			httpTrigger:            &trigger,
+			functionMap:            rr.functionMap,
+			functionWeights:        rr.functionWeights,
+			headerOverrides:        rr.headerOverrides,
+			timeout:                rr.timeout,
+			tsRoundTripperParams:   ts.tsRoundTripperParams,
+			isDebugEnv:             ts.isDebugEnv,
+			svcAddrUpdateThrottler: ts.svcAddrUpdateThrottler,
+			functionTimeoutMap:     fnTimeoutMap,
+			unTapServiceTimeout:    ts.unTapServiceTimeout,
+			noCacheTrustedNets:     ts.noCacheTrustedNets,
+		}
+		if isPrimary {
+			fh.resolveUncached = func(ctx context.Context) (*resolveResult, error) {
+				return ts.resolver.resolveCoalescedUncached(ctx, group)
+			}
+		} else {
+			fh.resolveUncached = func(ctx context.Context) (*resolveResult, error) {
+				return ts.resolver.resolveUncached(ctx, *fh.httpTrigger)
+			}
 		}
 
 		// The functionHandler for HTTP trigger with fn reference type "FunctionReferenceTypeFunctionName",
@@ -197,24 +373,10 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) (*mux.Router
 		// deployment. For more details, please check "handler" function of functionHandler.
 
 		if rr.resolveResultType == resolveResultSingleFunction {
-			for _, fn := range fh.functionMap {
-				fh.function = fn
-			}
+			fh.function = rr.function
 		}
 
-		methods := trigger.Spec.Methods
-		if len(trigger.Spec.Method) > 0 {
-			present := false
-			for _, m := range trigger.Spec.Methods {
-				if m == trigger.Spec.Method {
-					present = true
-					break
-				}
-			}
-			if !present {
-				methods = append(methods, trigger.Spec.Method)
-			}
-		}
+		methods := resolve.TriggerMethods(&trigger)
 
 		handler := http.HandlerFunc(fh.handler)
 
@@ -318,6 +480,7 @@ func (ts *HTTPTriggerSet) addTriggerHandlers() error {
 			DeleteFunc: func(obj interface{}) {
 				ts.syncTriggers()
 				trigger := obj.(*fv1.HTTPTrigger)
+				ts.resolver.invalidateTrigger(trigger.ObjectMeta.Namespace, trigger.ObjectMeta.Name)
 				go deleteIngress(context.Background(), ts.logger, trigger, ts.kubeClient)
 			},
 			UpdateFunc: func(oldObj interface{}, newObj interface{}) {
@@ -328,6 +491,11 @@ func (ts *HTTPTriggerSet) addTriggerHandlers() error {
 					return
 				}
 
+				if !equality.Semantic.DeepEqual(oldTrigger.Spec.FunctionReference, newTrigger.Spec.FunctionReference) ||
+					oldTrigger.Spec.Timeout != newTrigger.Spec.Timeout {
+					ts.resolver.invalidateTrigger(newTrigger.ObjectMeta.Namespace, newTrigger.ObjectMeta.Name)
+				}
+
 				go updateIngress(context.Background(), ts.logger, oldTrigger, newTrigger, ts.kubeClient)
 				ts.syncTriggers()
 			},
@@ -347,6 +515,24 @@ func (ts *HTTPTriggerSet) addFunctionHandlers() error {
 				ts.syncTriggers()
 			},
 			DeleteFunc: func(obj interface{}) {
+				fn, ok := obj.(*fv1.Function)
+				if !ok {
+					tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown)
+					if !ok {
+						ts.logger.Error("couldn't get object from tombstone", zap.Any("obj", obj))
+						ts.syncTriggers()
+						return
+					}
+					fn, ok = tombstone.Obj.(*fv1.Function)
+					if !ok {
+						ts.logger.Error("tombstone contained object that is not a function", zap.Any("obj", obj))
+						ts.syncTriggers()
+						return
+					}
+				}
+				// Evict the resolutions referencing the function right away, rather
+				// than invoking a deleted function until they expire.
+				ts.resolver.evictFunction(fn.ObjectMeta.Namespace, fn.ObjectMeta.Name)
 				ts.syncTriggers()
 			},
 			UpdateFunc: func(oldObj interface{}, newObj interface{}) {
@@ -359,12 +545,11 @@ func (ts *HTTPTriggerSet) addFunctionHandlers() error {
 
 				// update resolver function reference cache
 				for key, rr := range ts.resolver.copy() {
-					if key.namespace == fn.ObjectMeta.Namespace &&
-						rr.functionMap[fn.ObjectMeta.Name] != nil &&
-						rr.functionMap[fn.ObjectMeta.Name].ObjectMeta.ResourceVersion != fn.ObjectMeta.ResourceVersion {
+					if cached := rr.getFunction(fn.ObjectMeta.Name); key.namespace == fn.ObjectMeta.Namespace &&
+						cached != nil && cached.ObjectMeta.ResourceVersion != fn.ObjectMeta.ResourceVersion {
 						// invalidate resolver cache
 						ts.logger.Debug("invalidating resolver cache")
-						err := ts.resolver.delete(key.namespace, key.triggerName, key.triggerResourceVersion)
+						err := ts.resolver.delete(key)
 						if err != nil {
 							ts.logger.Error("error deleting functionReferenceResolver cache", zap.Error(err))
 						}
@@ -418,7 +603,7 @@ func (ts *HTTPTriggerSet) updateRouter(ctx context.Context) {
 		ts.functions = allfunctions
 
 		// make a new router and use it
-		router, err := ts.getRouter(functionTimeout)
+		router, err := ts.getRouter(ctx, functionTimeout)
 		if err != nil {
 			ts.logger.Error("error updating router", zap.Error(err))
 			continue
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/fission/fission/pkg/utils/metrics"
@@ -46,3 +48,46 @@ func init() {
 	registry.MustRegister(functionCallErrors)
 	registry.MustRegister(functionCallOverhead)
 }
+
+var (
+	resolverCacheMetricsLock sync.Mutex
+	// resolverCacheMetrics are the metrics of the current resolver cache
+	resolverCacheMetrics []prometheus.Collector
+)
+
+// resolverCache is the part of the resolver cache the metrics read from.
+type resolverCache interface {
+	Len() int
+	Expired() uint64
+}
+
+// registerResolverCacheMetrics exposes the entry count and expirations of the
+// function reference resolver cache, replacing the ones of a previous cache.
+func registerResolverCacheMetrics(c resolverCache) {
+	resolverCacheMetricsLock.Lock()
+	defer resolverCacheMetricsLock.Unlock()
+
+	registry := metrics.Registry
+	for _, collector := range resolverCacheMetrics {
+		registry.Unregister(collector)
+	}
+	resolverCacheMetrics = []prometheus.Collector{
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: "fission_router_resolver_cache_entries",
+				Help: "Number of entries in the function reference resolver cache",
+			},
+			func() float64 { return float64(c.Len()) },
+		),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name: "fission_router_resolver_cache_expirations_total",
+				Help: "Count of function reference resolver cache entries removed because they expired",
+			},
+			func() float64 { return float64(c.Expired()) },
+		),
+	}
+	for _, collector := range resolverCacheMetrics {
+		registry.MustRegister(collector)
+	}
+}
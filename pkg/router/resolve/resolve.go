@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolve resolves the function references of HTTP triggers to the
+// functions requests are routed to. It is shared by the router and the CLI,
+// so that both resolve triggers the same way; looking up functions is left
+// to the caller.
+package resolve
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/weightedpick"
+)
+
+type (
+	// FunctionGetter looks up a function of the namespace being resolved by
+	// name, ok is false if it does not exist.
+	FunctionGetter func(name string) (fn *fv1.Function, ok bool, err error)
+
+	// Result is what a function reference resolves to.
+	Result struct {
+		// Function is the function of a function name reference.
+		Function *fv1.Function
+		// Functions are the functions of a function weights reference, by name.
+		Functions map[string]*fv1.Function
+		// Weights distribute the requests across Functions, by function name.
+		Weights []weightedpick.Item
+		// Unhealthy are the names of the functions of Functions annotated as
+		// unhealthy, see fv1.FunctionHealthAnnotation.
+		Unhealthy []string
+		// HeaderOverrides select a function by request header instead of by weight.
+		HeaderOverrides []fv1.FunctionHeaderOverride
+	}
+)
+
+// FunctionReference resolves the function reference of a trigger in the
+// namespace. With skipUnhealthy, unhealthy functions of a function weights
+// reference get no weight, see HealthyWeights.
+func FunctionReference(namespace string, fr *fv1.FunctionReference, get FunctionGetter, skipUnhealthy bool) (*Result, error) {
+	switch fr.Type {
+	case fv1.FunctionReferenceTypeFunctionName:
+		fn, err := getFunction(namespace, fr.Name, get)
+		if err != nil {
+			return nil, err
+		}
+		if len(fr.PackageVersion) > 0 && fn.Spec.Package.PackageRef.ResourceVersion != fr.PackageVersion {
+			return nil, errors.Errorf("function %s/%s references package version %q, the trigger is pinned to %q",
+				namespace, fr.Name, fn.Spec.Package.PackageRef.ResourceVersion, fr.PackageVersion)
+		}
+		return &Result{Function: fn}, nil
+
+	case fv1.FunctionReferenceTypeFunctionWeights:
+		names := make([]string, 0, len(fr.FunctionWeights))
+		for name := range fr.FunctionWeights {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		res := &Result{
+			Functions:       make(map[string]*fv1.Function, len(names)),
+			Weights:         make([]weightedpick.Item, 0, len(names)),
+			HeaderOverrides: fr.HeaderOverrides,
+		}
+		for _, name := range names {
+			fn, err := getFunction(namespace, name, get)
+			if err != nil {
+				return nil, err
+			}
+			res.Functions[name] = fn
+			res.Weights = append(res.Weights, weightedpick.Item{Name: name, Weight: fr.FunctionWeights[name]})
+			if IsUnhealthy(fn) {
+				res.Unhealthy = append(res.Unhealthy, name)
+			}
+		}
+		if skipUnhealthy {
+			res.Weights = HealthyWeights(res.Functions, res.Weights)
+		}
+		return res, nil
+
+	default:
+		return nil, errors.Errorf("unrecognized function reference type %v", fr.Type)
+	}
+}
+
+func getFunction(namespace, name string, get FunctionGetter) (*fv1.Function, error) {
+	fn, ok, err := get(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("function %s/%s does not exist", namespace, name)
+	}
+	return fn, nil
+}
+
+// IsUnhealthy tells whether the function is annotated as unhealthy.
+func IsUnhealthy(fn *fv1.Function) bool {
+	return fn.ObjectMeta.Annotations[fv1.FunctionHealthAnnotation] == fv1.FunctionHealthUnhealthy
+}
+
+// HealthyWeights drops the weights of unhealthy functions, so that they are
+// never picked. If every function is unhealthy, all weights are kept, as
+// failing every request would not be better.
+func HealthyWeights(functions map[string]*fv1.Function, weights []weightedpick.Item) []weightedpick.Item {
+	healthy := make([]weightedpick.Item, 0, len(weights))
+	for _, w := range weights {
+		if !IsUnhealthy(functions[w.Name]) {
+			healthy = append(healthy, w)
+		}
+	}
+	if len(healthy) == 0 {
+		return weights
+	}
+	return healthy
+}
+
+// TriggerMethods returns the Methods of the trigger plus its Method.
+func TriggerMethods(trigger *fv1.HTTPTrigger) []string {
+	methods := trigger.Spec.Methods
+	if len(trigger.Spec.Method) > 0 && !slices.Contains(methods, trigger.Spec.Method) {
+		methods = append(methods, trigger.Spec.Method)
+	}
+	return methods
+}
+
+// TriggerKey identifies a trigger in the maps of CoalesceTriggers.
+func TriggerKey(trigger *fv1.HTTPTrigger) string {
+	return trigger.ObjectMeta.Namespace + "/" + trigger.ObjectMeta.Name
+}
+
+// triggerRoute identifies the route a trigger is served on: its namespace,
+// host, relative URL or prefix and methods.
+func triggerRoute(trigger *fv1.HTTPTrigger) string {
+	path := "url:" + trigger.Spec.RelativeURL
+	if trigger.Spec.Prefix != nil && *trigger.Spec.Prefix != "" {
+		path = "prefix:" + *trigger.Spec.Prefix
+	}
+	methods := slices.Clone(TriggerMethods(trigger))
+	slices.Sort(methods)
+	return strings.Join([]string{trigger.ObjectMeta.Namespace, trigger.Spec.Host, path, strings.Join(methods, ",")}, "|")
+}
+
+// CoalesceTriggers groups the function weights triggers sharing a route, so
+// that the route is served by their merged distribution. The oldest trigger of
+// a group, by creation time and then name, is its primary one: it comes first
+// in the group and its route is registered, the routes of the others are not.
+// It returns the groups of more than one trigger keyed by their primary
+// trigger, and the other triggers of the groups keyed by themselves, with
+// their primary trigger. Function name triggers are never coalesced, they
+// keep competing for the route as before.
+func CoalesceTriggers(triggers []fv1.HTTPTrigger) (groups map[string][]fv1.HTTPTrigger, coalesced map[string]string) {
+	byRoute := make(map[string][]fv1.HTTPTrigger)
+	for _, t := range triggers {
+		if t.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionWeights {
+			continue
+		}
+		route := triggerRoute(&t)
+		byRoute[route] = append(byRoute[route], t)
+	}
+
+	groups = make(map[string][]fv1.HTTPTrigger)
+	coalesced = make(map[string]string)
+	for _, group := range byRoute {
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			ti, tj := group[i].ObjectMeta.CreationTimestamp, group[j].ObjectMeta.CreationTimestamp
+			if !ti.Equal(&tj) {
+				return ti.Before(&tj)
+			}
+			return group[i].ObjectMeta.Name < group[j].ObjectMeta.Name
+		})
+		primary := TriggerKey(&group[0])
+		groups[primary] = group
+		for i := range group[1:] {
+			coalesced[TriggerKey(&group[i+1])] = primary
+		}
+	}
+	return groups, coalesced
+}
+
+// CoalesceFunctionReferences merges the function weights references of the
+// triggers, which share a route, into one. The weights a function is given by
+// several triggers add up. Header overrides are tried in the order of the
+// triggers, so the first trigger wins for requests matching several.
+func CoalesceFunctionReferences(triggers []fv1.HTTPTrigger) fv1.FunctionReference {
+	fr := fv1.FunctionReference{
+		Type:            fv1.FunctionReferenceTypeFunctionWeights,
+		FunctionWeights: make(map[string]int),
+	}
+	for _, t := range triggers {
+		for name, weight := range t.Spec.FunctionReference.FunctionWeights {
+			fr.FunctionWeights[name] += weight
+		}
+		fr.HeaderOverrides = append(fr.HeaderOverrides, t.Spec.FunctionReference.HeaderOverrides...)
+	}
+	return fr
+}
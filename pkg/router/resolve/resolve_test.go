@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolve
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/weightedpick"
+)
+
+func getter(fns ...*fv1.Function) FunctionGetter {
+	return func(name string) (*fv1.Function, bool, error) {
+		for _, fn := range fns {
+			if fn.ObjectMeta.Name == name {
+				return fn, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+}
+
+func TestFunctionReference(t *testing.T) {
+	stable := &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "stable", Namespace: "default"}}
+	stable.Spec.Package.PackageRef.ResourceVersion = "2"
+	canary := &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: "default",
+		Annotations: map[string]string{fv1.FunctionHealthAnnotation: fv1.FunctionHealthUnhealthy}}}
+	get := getter(stable, canary)
+
+	res, err := FunctionReference("default", &fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "stable", PackageVersion: "2"}, get, false)
+	if err != nil || res.Function != stable {
+		t.Fatalf("expected the function of the name reference, got %v, %v", res, err)
+	}
+	_, err = FunctionReference("default", &fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "stable", PackageVersion: "1"}, get, false)
+	if err == nil {
+		t.Error("expected an error for a function not running the pinned package version")
+	}
+	_, err = FunctionReference("default", &fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "missing"}, get, false)
+	if err == nil || err.Error() != "function default/missing does not exist" {
+		t.Errorf("expected an error for a missing function, got %v", err)
+	}
+
+	fr := &fv1.FunctionReference{
+		Type:            fv1.FunctionReferenceTypeFunctionWeights,
+		FunctionWeights: map[string]int{"stable": 90, "canary": 10},
+	}
+	res, err = FunctionReference("default", fr, get, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := []weightedpick.Item{{Name: "canary", Weight: 10}, {Name: "stable", Weight: 90}}
+	if !reflect.DeepEqual(res.Weights, all) || !reflect.DeepEqual(res.Unhealthy, []string{"canary"}) {
+		t.Errorf("expected all weights and the unhealthy function, got %v, %v", res.Weights, res.Unhealthy)
+	}
+
+	res, err = FunctionReference("default", fr, get, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Weights, []weightedpick.Item{{Name: "stable", Weight: 90}}) {
+		t.Errorf("expected the unhealthy function to get no weight, got %v", res.Weights)
+	}
+
+	fr.FunctionWeights = map[string]int{"canary": 100}
+	res, err = FunctionReference("default", fr, get, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Weights) != 1 {
+		t.Errorf("expected weights to be kept when all functions are unhealthy, got %v", res.Weights)
+	}
+}
+
+func TestCoalesceTriggers(t *testing.T) {
+	weighted := func(name string, created int64, weights map[string]int) fv1.HTTPTrigger {
+		return fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.Unix(created, 0)},
+			Spec: fv1.HTTPTriggerSpec{
+				RelativeURL: "/orders",
+				Method:      "GET",
+				FunctionReference: fv1.FunctionReference{
+					Type:            fv1.FunctionReferenceTypeFunctionWeights,
+					FunctionWeights: weights,
+				},
+			},
+		}
+	}
+	triggers := []fv1.HTTPTrigger{
+		weighted("newer", 20, map[string]int{"stable": 50, "mirror": 100}),
+		weighted("older", 10, map[string]int{"stable": 90, "canary": 10}),
+	}
+
+	groups, coalesced := CoalesceTriggers(triggers)
+	if len(groups["default/older"]) != 2 || coalesced["default/newer"] != "default/older" {
+		t.Fatalf("expected the newer trigger to be coalesced into the older one, got %v, %v", groups, coalesced)
+	}
+	fr := CoalesceFunctionReferences(groups["default/older"])
+	if !reflect.DeepEqual(fr.FunctionWeights, map[string]int{"stable": 140, "canary": 10, "mirror": 100}) {
+		t.Errorf("expected the weights of both triggers to add up, got %v", fr.FunctionWeights)
+	}
+}
@@ -42,8 +42,10 @@ package router
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -198,14 +200,94 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 			zap.Bool("default", displayAccessLog))
 	}
 
-	triggers, err := makeHTTPTriggerSet(logger.Named("triggerset"), fmap, fissionClient, kubeClient, executor, &tsRoundTripperParams{
-		timeout:           timeout,
-		timeoutExponent:   timeoutExponent,
-		disableKeepAlive:  disableKeepAlive,
-		keepAliveTime:     keepAliveTime,
-		maxRetries:        maxRetries,
-		svcAddrRetryCount: svcAddrRetryCount,
-	}, isDebugEnv, unTapServiceTimeout, throttler.MakeThrottler(svcAddrUpdateTimeout))
+	resolveLogSampleRateStr := os.Getenv("ROUTER_RESOLVE_LOG_SAMPLE_RATE")
+	resolveLogSampleRate, err := strconv.Atoi(resolveLogSampleRateStr)
+	if err != nil {
+		resolveLogSampleRate = 0
+		if resolveLogSampleRateStr != "" {
+			logger.Error("failed to parse 'ROUTER_RESOLVE_LOG_SAMPLE_RATE' - set to the default value",
+				zap.Error(err),
+				zap.String("value", resolveLogSampleRateStr),
+				zap.Int("default", resolveLogSampleRate))
+		}
+	}
+
+	resolveWarmupStr := os.Getenv("ROUTER_RESOLVE_WARMUP")
+	resolveWarmup, err := strconv.ParseBool(resolveWarmupStr)
+	if err != nil {
+		resolveWarmup = false
+		if resolveWarmupStr != "" {
+			logger.Error("failed to parse 'ROUTER_RESOLVE_WARMUP' - set to the default value",
+				zap.Error(err),
+				zap.String("value", resolveWarmupStr),
+				zap.Bool("default", resolveWarmup))
+		}
+	}
+
+	resolveCacheByTriggerNameStr := os.Getenv("ROUTER_RESOLVE_CACHE_BY_TRIGGER_NAME")
+	resolveCacheByTriggerName, err := strconv.ParseBool(resolveCacheByTriggerNameStr)
+	if err != nil {
+		resolveCacheByTriggerName = false
+		if resolveCacheByTriggerNameStr != "" {
+			logger.Error("failed to parse 'ROUTER_RESOLVE_CACHE_BY_TRIGGER_NAME' - set to the default value",
+				zap.Error(err),
+				zap.String("value", resolveCacheByTriggerNameStr),
+				zap.Bool("default", resolveCacheByTriggerName))
+		}
+	}
+
+	skipUnhealthyFunctionsStr := os.Getenv("ROUTER_SKIP_UNHEALTHY_FUNCTIONS")
+	skipUnhealthyFunctions, err := strconv.ParseBool(skipUnhealthyFunctionsStr)
+	if err != nil {
+		skipUnhealthyFunctions = false
+		if skipUnhealthyFunctionsStr != "" {
+			logger.Error("failed to parse 'ROUTER_SKIP_UNHEALTHY_FUNCTIONS' - set to the default value",
+				zap.Error(err),
+				zap.String("value", skipUnhealthyFunctionsStr),
+				zap.Bool("default", skipUnhealthyFunctions))
+		}
+	}
+
+	coalesceFunctionWeightsStr := os.Getenv("ROUTER_COALESCE_FUNCTION_WEIGHTS")
+	coalesceFunctionWeights, err := strconv.ParseBool(coalesceFunctionWeightsStr)
+	if err != nil {
+		coalesceFunctionWeights = false
+		if coalesceFunctionWeightsStr != "" {
+			logger.Error("failed to parse 'ROUTER_COALESCE_FUNCTION_WEIGHTS' - set to the default value",
+				zap.Error(err),
+				zap.String("value", coalesceFunctionWeightsStr),
+				zap.Bool("default", coalesceFunctionWeights))
+		}
+	}
+
+	noCacheTrustedCIDRsStr := os.Getenv("ROUTER_NO_CACHE_TRUSTED_CIDRS")
+	noCacheTrustedNets, err := parseCIDRs(noCacheTrustedCIDRsStr)
+	if err != nil {
+		noCacheTrustedNets = nil
+		logger.Error("failed to parse 'ROUTER_NO_CACHE_TRUSTED_CIDRS' - the no-cache header is only honored in debug mode",
+			zap.Error(err),
+			zap.String("value", noCacheTrustedCIDRsStr))
+	}
+
+	triggers, err := makeHTTPTriggerSet(logger.Named("triggerset"), fmap, fissionClient, kubeClient, executor, triggerSetOptions{
+		roundTripperParams: &tsRoundTripperParams{
+			timeout:           timeout,
+			timeoutExponent:   timeoutExponent,
+			disableKeepAlive:  disableKeepAlive,
+			keepAliveTime:     keepAliveTime,
+			maxRetries:        maxRetries,
+			svcAddrRetryCount: svcAddrRetryCount,
+		},
+		isDebugEnv:                isDebugEnv,
+		unTapServiceTimeout:       unTapServiceTimeout,
+		svcAddrUpdateThrottler:    throttler.MakeThrottler(svcAddrUpdateTimeout),
+		resolveLogSampleRate:      resolveLogSampleRate,
+		resolveWarmup:             resolveWarmup,
+		resolveCacheByTriggerName: resolveCacheByTriggerName,
+		skipUnhealthyFunctions:    skipUnhealthyFunctions,
+		coalesceFunctionWeights:   coalesceFunctionWeights,
+		noCacheTrustedNets:        noCacheTrustedNets,
+	})
 	if err != nil {
 		return errors.Wrap(err, "error making HTTP trigger set")
 	}
@@ -222,3 +304,20 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 
 	return serve(ctx, logger, mgr, port, triggers, displayAccessLog)
 }
+
+// parseCIDRs parses a comma separated list of CIDRs, e.g. "10.0.0.0/8,::1/128".
+func parseCIDRs(s string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, cidr := range strings.Split(s, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
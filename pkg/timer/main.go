@@ -38,7 +38,7 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 		return errors.Wrap(err, "error waiting for CRDs")
 	}
 
-	poster := publisher.MakeWebhookPublisher(logger, routerUrl)
+	poster := publisher.MakeWebhookPublisher(logger, routerUrl, publisher.WebhookOptions{})
 	timerSync, err := MakeTimerSync(ctx, logger, fissionClient, MakeTimer(logger, poster))
 	if err != nil {
 		return errors.Wrap(err, "error making timer sync")
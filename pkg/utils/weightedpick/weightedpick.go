@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package weightedpick selects one of several names in proportion to their weights.
+package weightedpick
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+type (
+	// Item is a name with its weight.
+	Item struct {
+		Name   string `json:"name"`
+		Weight int    `json:"weight"`
+	}
+
+	// Selector picks items in proportion to their weights, using the prefix
+	// sums of the weights.
+	Selector struct {
+		names       []string
+		sumPrefixes []int
+	}
+)
+
+// New returns a Selector over the items, in the given order.
+func New(items []Item) *Selector {
+	s := &Selector{
+		names:       make([]string, 0, len(items)),
+		sumPrefixes: make([]int, 0, len(items)),
+	}
+	sum := 0
+	for _, item := range items {
+		sum += item.Weight
+		s.names = append(s.names, item.Name)
+		s.sumPrefixes = append(s.sumPrefixes, sum)
+	}
+	return s
+}
+
+// Total returns the sum of all weights.
+func (s *Selector) Total() int {
+	if len(s.sumPrefixes) == 0 {
+		return 0
+	}
+	return s.sumPrefixes[len(s.sumPrefixes)-1]
+}
+
+// Len returns the number of items.
+func (s *Selector) Len() int {
+	return len(s.names)
+}
+
+// Items returns the items of the selector, in order.
+func (s *Selector) Items() []Item {
+	items := make([]Item, 0, len(s.names))
+	prev := 0
+	for i, name := range s.names {
+		items = append(items, Item{Name: name, Weight: s.sumPrefixes[i] - prev})
+		prev = s.sumPrefixes[i]
+	}
+	return items
+}
+
+// Pick deterministically returns the name of the first item whose prefix sum
+// is greater than n, or the last item if n is not less than Total. It returns
+// an empty string if there are no items. This is the item the router's former
+// binary search over the prefix sums picked, so items of weight 0 are never
+// picked unless they are last.
+func (s *Selector) Pick(n int) string {
+	if len(s.names) == 0 {
+		return ""
+	}
+	i := sort.Search(len(s.sumPrefixes), func(i int) bool {
+		return s.sumPrefixes[i] > n
+	})
+	if i == len(s.names) {
+		i--
+	}
+	return s.names[i]
+}
+
+// Random returns the name of a randomly picked item. n is drawn from
+// [0, Total] as the router drew it before, so the last item gets one more
+// value of n than its weight.
+func (s *Selector) Random() string {
+	return s.Pick(rand.Intn(s.Total() + 1))
+}
+
+// String returns the names and weights of the items, e.g. "a:20 b:80".
+func (s *Selector) String() string {
+	parts := make([]string, 0, len(s.names))
+	for _, item := range s.Items() {
+		parts = append(parts, fmt.Sprintf("%s:%d", item.Name, item.Weight))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package weightedpick
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPick(t *testing.T) {
+	s := New([]Item{{"a", 10}, {"b", 0}, {"c", 30}, {"d", 60}})
+	if s.Total() != 100 {
+		t.Fatalf("expected total 100, got %d", s.Total())
+	}
+
+	tests := map[int]string{
+		0:   "a",
+		9:   "a",
+		10:  "c",
+		39:  "c",
+		40:  "d",
+		99:  "d",
+		100: "d",
+		200: "d",
+	}
+	for n, want := range tests {
+		if got := s.Pick(n); got != want {
+			t.Errorf("Pick(%d): expected %q, got %q", n, want, got)
+		}
+	}
+
+	if got := s.String(); got != "a:10 b:0 c:30 d:60" {
+		t.Errorf("unexpected String() %q", got)
+	}
+
+	if got := New(nil).Pick(0); got != "" {
+		t.Errorf("expected no pick without items, got %q", got)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	s := New([]Item{{"a", 0}, {"b", 100}})
+	for i := 0; i < 100; i++ {
+		if got := s.Random(); got != "b" {
+			t.Fatalf("expected b, got %q", got)
+		}
+	}
+
+	counts := map[string]int{}
+	s = New([]Item{{"a", 50}, {"b", 50}})
+	for i := 0; i < 1000; i++ {
+		counts[s.Random()]++
+	}
+	if counts["a"] == 0 || counts["b"] == 0 {
+		t.Errorf("expected both items to be picked, got %v", counts)
+	}
+}
+
+// findCeil is the selection the router used before this package, kept
+// verbatim to check that Pick picks the same items.
+func findCeil(randomNumber int, names []string, sumPrefixes []int) string {
+	low := 0
+	high := len(sumPrefixes) - 1
+
+	for {
+		if low >= high {
+			break
+		}
+
+		mid := low + high/2
+		if randomNumber >= sumPrefixes[mid] {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	if sumPrefixes[low] >= randomNumber {
+		return names[low]
+	}
+	return ""
+}
+
+func TestPickMatchesFindCeil(t *testing.T) {
+	// findCeil computes the middle as low + high/2, which doesn't terminate
+	// for some n with four or more items, so the comparison stops at three.
+	var weights [][]int
+	for a := 0; a <= 12; a++ {
+		for b := 0; b <= 12; b++ {
+			weights = append(weights, []int{a, b})
+			for c := 0; c <= 12; c++ {
+				weights = append(weights, []int{a, b, c})
+			}
+		}
+	}
+	weights = append(weights, []int{20, 80}, []int{1, 99}, []int{10, 30, 60})
+
+	for _, ws := range weights {
+		items := make([]Item, 0, len(ws))
+		for i, w := range ws {
+			items = append(items, Item{Name: fmt.Sprintf("fn%d", i), Weight: w})
+		}
+		s := New(items)
+		for n := 0; n <= s.Total(); n++ {
+			if got, want := s.Pick(n), findCeil(n, s.names, s.sumPrefixes); got != want {
+				t.Fatalf("%v: Pick(%d) = %q, findCeil picked %q", s, n, got, want)
+			}
+		}
+	}
+}
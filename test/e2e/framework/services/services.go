@@ -135,7 +135,7 @@ func StartServices(ctx context.Context, f *framework.Framework, mgr manager.Inte
 	}
 	f.AddServiceInfo("mqtrigger-keda", framework.ServiceInfo{})
 
-	err = kubewatcher.Start(ctx, f.ClientGen(), f.Logger(), mgr, routerURL)
+	err = kubewatcher.Start(ctx, f.ClientGen(), f.Logger(), mgr, routerURL, kubewatcher.DefaultOptions())
 	if err != nil {
 		return fmt.Errorf("error starting kubewatcher: %w", err)
 	}